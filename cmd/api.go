@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+)
+
+// apiCmd sends an authenticated request to any StackHawk API endpoint
+var apiCmd = &cobra.Command{
+	Use:   "api <method> <path>",
+	Short: "Send an authenticated request to a StackHawk API endpoint",
+	Long: `Send a request to any StackHawk API endpoint, such as ones hawkop doesn't have a
+command for yet, and print the raw response body.
+
+The path is relative to the API base URL, e.g. /api/v1/user. Authentication, rate
+limiting, and retries work as for every other command. Use --data to send a JSON
+body (or @file to read it from a file) and --param to add query parameters.
+
+Methods other than GET and HEAD may change data, so you are asked to confirm them;
+pass --yes to skip the prompt.`,
+	Example: `  hawkop api GET /api/v1/user
+  hawkop api GET /api/v1/scan/<org-id> --param pageSize=10
+  hawkop api POST /api/v1/org/<org-id>/team --data '{"name": "Platform"}' --yes`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, _ := cmd.Flags().GetString("data")
+		params, _ := cmd.Flags().GetStringArray("param")
+		runAPIRequest(args[0], args[1], data, params)
+	},
+}
+
+// apiMethods are the HTTP methods accepted by the api command
+var apiMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringP("data", "d", "", "JSON request body, or @file to read it from a file")
+	apiCmd.Flags().StringArrayP("param", "p", nil, "Query parameter as key=value (repeatable)")
+}
+
+func runAPIRequest(method string, path string, data string, rawParams []string) {
+	method = strings.ToUpper(method)
+	if !containsString(apiMethods, method) {
+		failf(exitUsage, "Unsupported method: %s. Use one of %s", method, strings.Join(apiMethods, ", "))
+		return
+	}
+
+	if strings.Contains(path, "://") {
+		failf(exitUsage, "Pass an API path such as /api/v1/user, not a full URL. Use --base-url to change the API host.")
+		return
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	params, err := parseAPIParams(rawParams)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	body, err := apiRequestBody(data)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	if body != nil && (method == http.MethodGet || method == http.MethodHead) {
+		failf(exitUsage, "--data cannot be sent with a %s request", method)
+		return
+	}
+
+	if method != http.MethodGet && method != http.MethodHead &&
+		!confirm(fmt.Sprintf("This will send %s %s, which may change data.", method, path)) {
+		return
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	// A nil json.RawMessage would be sent as "null", so only pass a body when there is one
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
+	resp, err := client.DoAuthenticatedRequestWithParams(method, path, reqBody, params)
+	if pending, ok := api.AsPending(err); ok {
+		outputPending(method, path, pending)
+		return
+	}
+	if err != nil {
+		failf(exitCodeFor(err), "Request failed: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to read response: %v", err)
+		return
+	}
+	if len(respBody) == 0 {
+		fmt.Fprintf(errOut, "✅ %s %s: HTTP %d\n", method, path, resp.StatusCode)
+		return
+	}
+
+	fmt.Fprint(out, string(respBody))
+	if !strings.HasSuffix(string(respBody), "\n") {
+		fmt.Fprintln(out)
+	}
+}
+
+// outputPending reports a 202 Accepted answer: the body, which describes the pending
+// work, goes to out and where to poll for the result goes to errOut. The request
+// succeeded, so the exit code stays 0.
+func outputPending(method, path string, pending *api.PendingError) {
+	if pending.Body != "" {
+		fmt.Fprint(out, pending.Body)
+		if !strings.HasSuffix(pending.Body, "\n") {
+			fmt.Fprintln(out)
+		}
+	}
+
+	fmt.Fprintf(errOut, "⏳ %s %s: HTTP 202 Accepted; the API is still processing the request\n", method, path)
+	if pending.Location != "" {
+		fmt.Fprintf(errOut, "   Poll: %s\n", pending.Location)
+	}
+	if pending.PollToken != "" {
+		fmt.Fprintf(errOut, "   Poll token: %s\n", pending.PollToken)
+	}
+	if pending.RetryAfter > 0 {
+		fmt.Fprintf(errOut, "   Retry after: %s\n", pending.RetryAfter)
+	}
+}
+
+// parseAPIParams turns key=value arguments into query parameters. A value may
+// itself contain '='; later values for the same key replace earlier ones.
+func parseAPIParams(rawParams []string) (map[string]string, error) {
+	params := make(map[string]string, len(rawParams))
+	for _, raw := range rawParams {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --param %q: expected key=value", raw)
+		}
+		params[strings.TrimSpace(key)] = value
+	}
+	return params, nil
+}
+
+// apiRequestBody returns the --data value as JSON, reading it from a file when it
+// starts with '@'. An empty value means no body.
+func apiRequestBody(data string) (json.RawMessage, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	if name, ok := strings.CutPrefix(data, "@"); ok {
+		contents, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --data file: %w", err)
+		}
+		data = string(contents)
+	}
+
+	if !json.Valid([]byte(data)) {
+		return nil, fmt.Errorf("--data is not valid JSON")
+	}
+	return json.RawMessage(data), nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/config"
+)
+
+type APICommandTestSuite struct {
+	suite.Suite
+}
+
+func (suite *APICommandTestSuite) TestAPICommandFlags() {
+	assert.Equal(suite.T(), "api <method> <path>", apiCmd.Use)
+	assert.NotNil(suite.T(), apiCmd.Flags().Lookup("data"))
+	assert.NotNil(suite.T(), apiCmd.Flags().Lookup("param"))
+	assert.Error(suite.T(), apiCmd.Args(apiCmd, []string{"GET"}))
+}
+
+func (suite *APICommandTestSuite) TestParseAPIParams() {
+	params, err := parseAPIParams([]string{"pageSize=10", "filter=a=b", "empty="})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]string{"pageSize": "10", "filter": "a=b", "empty": ""}, params)
+
+	_, err = parseAPIParams([]string{"pageSize"})
+	assert.ErrorContains(suite.T(), err, `invalid --param "pageSize"`)
+
+	_, err = parseAPIParams([]string{"=10"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *APICommandTestSuite) TestAPIRequestBody() {
+	body, err := apiRequestBody("")
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), body)
+
+	body, err = apiRequestBody(`{"name": "Platform"}`)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"name": "Platform"}`, string(body))
+
+	file := filepath.Join(suite.T().TempDir(), "body.json")
+	require.NoError(suite.T(), os.WriteFile(file, []byte(`{"env": "Production"}`), 0o600))
+	body, err = apiRequestBody("@" + file)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"env": "Production"}`, string(body))
+
+	_, err = apiRequestBody("{not json")
+	assert.ErrorContains(suite.T(), err, "not valid JSON")
+
+	_, err = apiRequestBody("@" + filepath.Join(suite.T().TempDir(), "missing.json"))
+	assert.ErrorContains(suite.T(), err, "failed to read --data file")
+}
+
+func (suite *APICommandTestSuite) TestRunAPIRequest_Get() {
+	useMockAPI(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() { runAPIRequest("get", "api/v1/user", "", nil) })
+	assert.Contains(suite.T(), stdout, `"email"`)
+	assert.Empty(suite.T(), stderr)
+}
+
+func (suite *APICommandTestSuite) TestRunAPIRequest_Validation() {
+	useMockAPI(suite.T())
+
+	tests := []struct {
+		method, path, data string
+		params             []string
+		want               string
+	}{
+		{"TRACE", "/api/v1/user", "", nil, "Unsupported method: TRACE"},
+		{"GET", "https://api.stackhawk.com/api/v1/user", "", nil, "not a full URL"},
+		{"GET", "/api/v1/user", "", []string{"bad"}, "expected key=value"},
+		{"GET", "/api/v1/user", `{"a": 1}`, nil, "--data cannot be sent with a GET request"},
+		{"POST", "/api/v1/user", "{", nil, "not valid JSON"},
+		{"GET", "/api/v1/missing", "", nil, "Request failed: not found (404)"},
+	}
+	for _, tt := range tests {
+		stdout, stderr := captureOutput(suite.T(), func() { runAPIRequest(tt.method, tt.path, tt.data, tt.params) })
+		assert.Empty(suite.T(), stdout, tt.want)
+		assert.Contains(suite.T(), stderr, tt.want)
+	}
+}
+
+func (suite *APICommandTestSuite) TestRunAPIRequest_NonGetNeedsConfirmation() {
+	useMockAPI(suite.T())
+	stubNonInteractive(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runAPIRequest("DELETE", "/api/v1/app/app-1", "", nil)
+	})
+	assert.Empty(suite.T(), stdout)
+	assert.Contains(suite.T(), stderr, "This will send DELETE /api/v1/app/app-1, which may change data. Re-run with --yes to proceed.")
+	assert.NotContains(suite.T(), stderr, "Request failed", "no request should be sent")
+}
+
+func (suite *APICommandTestSuite) TestRunAPIRequest_Accepted() {
+	resetExitCode(suite.T())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/api/v1/jobs/job-1")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "RUNNING"}`))
+	}))
+	suite.T().Cleanup(server.Close)
+	useBaseURL(suite.T(), server.URL)
+	cfg := &config.Config{APIKey: "key"}
+	cfg.SetJWT(server.URL, "jwt", time.Now().Add(time.Hour))
+	stubConfigFile(suite.T(), cfg)
+
+	stdout, stderr := captureOutput(suite.T(), func() { runAPIRequest("GET", "/api/v1/jobs", "", nil) })
+	assert.Equal(suite.T(), "{\"status\": \"RUNNING\"}\n", stdout)
+	assert.Contains(suite.T(), stderr, "HTTP 202 Accepted")
+	assert.Contains(suite.T(), stderr, "Poll: "+server.URL+"/api/v1/jobs/job-1")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func TestAPICommandTestSuite(t *testing.T) {
+	suite.Run(t, new(APICommandTestSuite))
+}
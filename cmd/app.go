@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"hawkop/internal/api"
-	"hawkop/internal/config"
 	"hawkop/internal/format"
 )
 
@@ -18,7 +22,11 @@ var appCmd = &cobra.Command{
 	Short: "Manage application-related operations",
 	Long: `Manage application-related operations including listing applications in organizations.
 	
-Use subcommands to list applications, view application details, or manage application settings.`,
+Use subcommands to list applications, view application details, or manage application settings.
+
+Subcommands that take an application accept its ID or its name. A name matches
+without regard to case, and a part of a name works when only one application
+contains it, except for delete, which needs the ID or full name.`,
 }
 
 // appListCmd lists applications in an organization
@@ -28,54 +36,140 @@ var appListCmd = &cobra.Command{
 	Long: `List all applications that belong to the specified organization.
 	
 By default, uses your configured default organization. You can specify a different
-organization using the --org flag. This command requires appropriate permissions.`,
+organization using the --org flag. This command requires appropriate permissions.
+
+Use --detailed to add a POLICY column showing the scan policy of each environment,
+with one row per environment when an application has several. JSON output always
+includes the policies the API reports.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		limit, _ := cmd.Flags().GetInt("limit")
 		org, _ := cmd.Flags().GetString("org")
 		status, _ := cmd.Flags().GetString("status")
-		runAppList(format, limit, org, status)
+		detailed, _ := cmd.Flags().GetBool("detailed")
+		runAppList(format, limit, org, status, detailed)
+	},
+}
+
+// appCreateCmd creates a new application in an organization
+var appCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an application in an organization",
+	Long: `Create a new application with an initial environment in the specified organization.
+	
+By default, uses your configured default organization. You can specify a different
+organization using the --org flag. This command requires appropriate permissions.
+
+You are asked to confirm before the application is created; pass --yes to skip the prompt.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		name, _ := cmd.Flags().GetString("name")
+		env, _ := cmd.Flags().GetString("env")
+		runAppCreate(format, org, name, env)
+	},
+}
+
+// appDeleteCmd deletes an application
+var appDeleteCmd = &cobra.Command{
+	Use:   "delete <app>",
+	Short: "Delete an application",
+	Long: `Delete an application and all of its environments. The application must be
+given by ID or full name; unlike other subcommands, a part of a name is not enough.
+	
+This operation cannot be undone. You are asked to confirm before anything is deleted;
+pass --yes to skip the prompt.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		org, _ := cmd.Flags().GetString("org")
+		yes, _ := cmd.Flags().GetBool("confirm")
+		runAppDelete(args[0], org, yes)
+	},
+}
+
+// appAlertTrendCmd shows how one finding changes across an application's scans
+var appAlertTrendCmd = &cobra.Command{
+	Use:   "alert-trend <app> <plugin-id>",
+	Short: "Show a finding's URI count across an application's scans",
+	Long: `Walk an application's completed scans, oldest first, and show the URI count
+for one plugin in each, marking when the finding first appeared, was resolved,
+or reappeared. Findings are matched by fingerprint, so each scan also shows how
+many URIs are new or resolved since the previous scan of its environment.
+
+Each scan's alerts are fetched separately, so use --limit to bound how many of
+the most recent scans are included.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		env, _ := cmd.Flags().GetString("env")
+		limit, _ := cmd.Flags().GetInt("limit")
+		runAppAlertTrend(args[0], args[1], format, org, env, limit)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(appCmd)
 	appCmd.AddCommand(appListCmd)
+	appCmd.AddCommand(appCreateCmd)
+	appCmd.AddCommand(appDeleteCmd)
+	appCmd.AddCommand(appAlertTrendCmd)
 
 	// Add flags for app list command
-	appListCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
+	appListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(appListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(appListCmd)
 	appListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
 	appListCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
-	appListCmd.Flags().StringP("status", "s", "", "Filter by application status (ACTIVE|ENV_INCOMPLETE)")
+	appListCmd.Flags().StringP("status", "s", "", enumUsage("Filter by application status", appStatusValues))
+	completeEnum(appListCmd, "status", appStatusValues)
+	appListCmd.Flags().Bool("detailed", false, "Add each environment's scan policy, one row per environment")
+
+	// Add flags for app create command
+	appCreateCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(appCreateCmd, "format", formatsTableJSON)
+	appCreateCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	appCreateCmd.Flags().StringP("name", "n", "", "Application name (required)")
+	appCreateCmd.Flags().StringP("env", "e", "Development", "Initial environment name")
+	_ = appCreateCmd.MarkFlagRequired("name")
+
+	// Add flags for app delete command
+	appDeleteCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	appDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion of the application")
+	_ = appDeleteCmd.Flags().MarkDeprecated("confirm", "use --yes instead")
+
+	// Add flags for app alert-trend command
+	appAlertTrendCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(appAlertTrendCmd, "format", formatsTableJSON)
+	appAlertTrendCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	appAlertTrendCmd.Flags().StringP("env", "e", "", "Only include scans of this environment")
+	appAlertTrendCmd.Flags().IntP("limit", "l", 20, "Number of most recent scans to include (0 = all)")
 }
 
-func runAppList(outputFormat string, limit int, orgID string, statusFilter string) {
+func runAppList(outputFormat string, limit int, orgID string, statusFilter string, detailed bool) {
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	checkError(err)
 
 	// Validate that we have credentials
 	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
 		return
 	}
 
 	// Determine which organization to use
-	if orgID == "" {
-		orgID = cfg.OrgID
-		if orgID == "" {
-			fmt.Println("❌ No organization specified. Use --org flag or set a default with 'hawkop org set <org-id>'")
-			return
-		}
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
 	}
 
 	// Create API client
-	client := api.NewClient(cfg)
+	client := newAPIClient(cfg)
 
 	// Get organization applications
 	applications, err := client.ListOrganizationApplications(orgID)
 	if err != nil {
-		fmt.Printf("❌ Failed to list applications: %v\n", err)
+		failf(exitCodeFor(err), "Failed to list applications: %v", err)
 		return
 	}
 
@@ -96,33 +190,139 @@ func runAppList(outputFormat string, limit int, orgID string, statusFilter strin
 		applications = applications[:limit]
 	}
 
+	if outputTemplated(applications) {
+		return
+	}
+
 	// Output based on format
 	switch strings.ToLower(outputFormat) {
 	case "json":
 		outputApplicationsJSON(applications)
 	case "table":
-		outputApplicationsTable(applications)
+		if detailed {
+			outputApplicationsDetailedTable(applications)
+		} else {
+			outputApplicationsTable(applications)
+		}
+	case "tsv":
+		if detailed {
+			outputTSV(applicationsDetailedTable(applications))
+		} else {
+			outputTSV(applicationsTable(applications))
+		}
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
+		return
+	}
+}
+
+func runAppCreate(outputFormat string, orgID string, name string, env string) {
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	if strings.TrimSpace(name) == "" {
+		failf(exitUsage, "Application name is required. Use --name to specify one.")
+		return
+	}
+	if !confirm(fmt.Sprintf("This will create application %q (%s) in organization %s.", name, env, orgID)) {
+		return
+	}
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	// Create the application
+	app, err := client.CreateApplication(orgID, api.AppApplication{
+		Name: name,
+		Env:  env,
+	})
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to create application: %v", err)
+		return
+	}
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputApplicationsJSON([]api.AppApplication{*app})
+	case "table":
+		fmt.Fprintf(errOut, "✅ Application created: %s (%s)\n", app.Name, app.ApplicationID)
+		outputApplicationsTable([]api.AppApplication{*app})
 	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+func runAppDelete(appRef string, orgID string, yes bool) {
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
 		return
 	}
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	app, err := resolveApp(client, orgID, appRef, true)
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+	if !yes && !confirm(fmt.Sprintf("Deleting application %s (%s) cannot be undone.", app.Name, app.ApplicationID)) {
+		return
+	}
+
+	if err := client.DeleteApplication(orgID, app.ApplicationID); err != nil {
+		failf(exitCodeFor(err), "Failed to delete application: %v", err)
+		return
+	}
+
+	fmt.Fprintf(errOut, "✅ Application deleted: %s (%s)\n", app.Name, app.ApplicationID)
 }
 
 func outputApplicationsJSON(applications []api.AppApplication) {
 	data, err := json.MarshalIndent(applications, "", "  ")
 	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 		return
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 }
 
 func outputApplicationsTable(applications []api.AppApplication) {
 	if len(applications) == 0 {
-		fmt.Println("No applications found.")
+		fmt.Fprintln(errOut, "No applications found.")
 		return
 	}
 
+	fmt.Fprint(out, renderTable(applicationsTable(applications)))
+}
+
+// applicationsTable lays out applications for table and tsv output
+func applicationsTable(applications []api.AppApplication) *format.TableWriter {
 	table := format.NewTable("ID", "NAME", "ENV", "STATUS", "TYPE")
 
 	for _, app := range applications {
@@ -150,5 +350,303 @@ func outputApplicationsTable(applications []api.AppApplication) {
 		table.AddRow(app.ApplicationID, name, env, status, appType)
 	}
 
-	fmt.Print(table.Render())
+	return table
+}
+
+func outputApplicationsDetailedTable(applications []api.AppApplication) {
+	if len(applications) == 0 {
+		fmt.Fprintln(errOut, "No applications found.")
+		return
+	}
+
+	fmt.Fprint(out, renderTable(applicationsDetailedTable(applications)))
+}
+
+// applicationsDetailedTable lays out applications with each environment's scan
+// policy, one row per environment
+func applicationsDetailedTable(applications []api.AppApplication) *format.TableWriter {
+	table := format.NewTable("ID", "NAME", "ENV", "POLICY", "STATUS", "TYPE")
+
+	for _, app := range applications {
+		for _, env := range appEnvironments(app) {
+			table.AddRow(app.ApplicationID, orNA(app.Name), orNA(env.Env), orNA(env.PolicyName),
+				orNA(app.ApplicationStatus), orNA(app.ApplicationType))
+		}
+	}
+
+	return table
+}
+
+// appEnvironments returns an application's environments, or the single environment
+// the application entry describes when the API doesn't list them
+func appEnvironments(app api.AppApplication) []api.AppEnvironment {
+	if len(app.Environments) > 0 {
+		return app.Environments
+	}
+	return []api.AppEnvironment{{EnvID: app.EnvID, Env: app.Env, PolicyName: app.PolicyName}}
+}
+
+// Changes marked on an alert trend when a finding appears or disappears
+const (
+	trendFirstSeen  = "FIRST SEEN"
+	trendResolved   = "RESOLVED"
+	trendReappeared = "REAPPEARED"
+)
+
+// alertTrend is the URI count of one plugin across an application's scans
+type alertTrend struct {
+	ApplicationID string            `json:"applicationId"`
+	PluginID      string            `json:"pluginId"`
+	Name          string            `json:"name,omitempty"`
+	Points        []alertTrendPoint `json:"points"`
+}
+
+// alertTrendPoint is the plugin's state in one scan
+type alertTrendPoint struct {
+	ScanID    string `json:"scanId"`
+	Env       string `json:"env"`
+	Timestamp string `json:"timestamp"`
+	URICount  int    `json:"uriCount"`
+	Present   bool   `json:"present"`
+	Change    string `json:"change,omitempty"`
+	// NewURIs and ResolvedURIs count findings, by fingerprint, that weren't in or
+	// are no longer in the previous scan of the environment
+	NewURIs      int `json:"newUris"`
+	ResolvedURIs int `json:"resolvedUris"`
+}
+
+func runAppAlertTrend(appRef string, pluginID string, outputFormat string, orgID string, env string, limit int) {
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+	env = resolveEnv(env, orgID, cfg)
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	app, err := resolveApp(client, orgID, appRef, false)
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+	appID := app.ApplicationID
+
+	scanResults, err := client.ListOrganizationScans(orgID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list scans: %v", err)
+		return
+	}
+
+	scans := appScanHistory(scanResults, appID, env)
+	if len(scans) == 0 {
+		fmt.Fprintf(errOut, "No completed scans found for application %s.\n", appID)
+		return
+	}
+	if limit > 0 && len(scans) > limit {
+		scans = scans[len(scans)-limit:]
+	}
+
+	// Fetch each scan's alerts, and the plugin's findings where it has the alert; a
+	// scan whose alerts fail is left out of the trend
+	alertsByScan := make(map[string][]api.ScanAlert, len(scans))
+	findingsByScan := make(map[string][]api.ScanAlertFinding, len(scans))
+	history := make([]api.ApplicationScanResult, 0, len(scans))
+	spinner := startSpinner("Fetching alerts...")
+	for i, scan := range scans {
+		spinner.Update(fmt.Sprintf("Fetching alerts for scan %d/%d...", i+1, len(scans)))
+		alerts, err := client.GetScanAlerts(scan.Scan.ID)
+		if errors.Is(err, context.DeadlineExceeded) {
+			spinner.Stop()
+		}
+		if reportTimeout(err, fmt.Sprintf("fetched alerts for %d of %d scans before the deadline", len(history), len(scans))) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(spinner, "⚠️  Failed to get alerts for scan %s: %v\n", scan.Scan.ID, err)
+			continue
+		}
+		alertsByScan[scan.Scan.ID] = alerts
+		history = append(history, scan)
+
+		if !slices.ContainsFunc(alerts, func(alert api.ScanAlert) bool { return alert.PluginID == pluginID }) {
+			continue
+		}
+		result, err := client.GetAlertFindings(scan.Scan.ID, pluginID)
+		if err != nil {
+			fmt.Fprintf(spinner, "⚠️  Failed to get findings for scan %s: %v\n", scan.Scan.ID, err)
+			continue
+		}
+		findingsByScan[scan.Scan.ID] = result.ApplicationScanAlertUris
+	}
+	spinner.Stop()
+
+	trend := buildAlertTrend(appID, pluginID, history, alertsByScan, findingsByScan)
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputAlertTrendJSON(trend)
+	case "table":
+		outputAlertTrendTable(trend)
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+// appScanHistory returns the application's completed scans, optionally limited to
+// one environment, ordered oldest first
+func appScanHistory(scanResults []api.ApplicationScanResult, appID string, env string) []api.ApplicationScanResult {
+	history := []api.ApplicationScanResult{}
+	for _, result := range scanResults {
+		if result.Scan.ApplicationID != appID || !strings.EqualFold(result.Scan.Status, "COMPLETED") {
+			continue
+		}
+		if env != "" && !strings.EqualFold(result.Scan.Env, env) {
+			continue
+		}
+		history = append(history, result)
+	}
+
+	sort.SliceStable(history, func(a, b int) bool {
+		tsA, _ := strconv.ParseInt(history[a].Scan.Timestamp, 10, 64)
+		tsB, _ := strconv.ParseInt(history[b].Scan.Timestamp, 10, 64)
+		return tsA < tsB
+	})
+	return history
+}
+
+// buildAlertTrend records the plugin's URI count in each scan of history, which must
+// be ordered oldest first. Changes are tracked per environment, so scans of other
+// environments don't mark a finding as resolved. New and resolved URIs are counted
+// by fingerprint from findingsByScan, and left at zero next to a scan that has
+// the alert but no findings there.
+func buildAlertTrend(appID string, pluginID string, history []api.ApplicationScanResult, alertsByScan map[string][]api.ScanAlert, findingsByScan map[string][]api.ScanAlertFinding) alertTrend {
+	trend := alertTrend{ApplicationID: appID, PluginID: pluginID, Points: []alertTrendPoint{}}
+	seen := make(map[string]bool)
+	present := make(map[string]bool)
+	// fingerprints holds the findings of the previous scan of each environment,
+	// or nil when they aren't known
+	fingerprints := make(map[string]map[string]bool)
+
+	for _, scan := range history {
+		point := alertTrendPoint{
+			ScanID:    scan.Scan.ID,
+			Env:       scan.Scan.Env,
+			Timestamp: scan.Scan.Timestamp,
+		}
+		for _, alert := range alertsByScan[scan.Scan.ID] {
+			if alert.PluginID != pluginID {
+				continue
+			}
+			point.Present = true
+			point.URICount += alert.URICount
+			if trend.Name == "" {
+				trend.Name = alert.Name
+			}
+		}
+
+		env := strings.ToLower(scan.Scan.Env)
+		var current map[string]bool
+		if findings, ok := findingsByScan[scan.Scan.ID]; ok || !point.Present {
+			current = make(map[string]bool, len(findings))
+			for _, finding := range findings {
+				current[finding.Fingerprint()] = true
+			}
+		}
+		previous, known := fingerprints[env], fingerprints[env] != nil || !seen[env]
+		if current != nil && known {
+			point.NewURIs = countMissing(current, previous)
+			point.ResolvedURIs = countMissing(previous, current)
+		}
+		fingerprints[env] = current
+
+		switch {
+		case point.Present && !seen[env]:
+			point.Change = trendFirstSeen
+		case point.Present && !present[env]:
+			point.Change = trendReappeared
+		case !point.Present && present[env]:
+			point.Change = trendResolved
+		}
+		seen[env] = seen[env] || point.Present
+		present[env] = point.Present
+
+		trend.Points = append(trend.Points, point)
+	}
+
+	return trend
+}
+
+// countMissing counts the keys of set that aren't in other
+func countMissing(set map[string]bool, other map[string]bool) int {
+	count := 0
+	for key := range set {
+		if !other[key] {
+			count++
+		}
+	}
+	return count
+}
+
+func outputAlertTrendJSON(trend alertTrend) {
+	data, err := json.MarshalIndent(trend, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputAlertTrendTable(trend alertTrend) {
+	if len(trend.Points) == 0 {
+		fmt.Fprintln(errOut, "No scans found.")
+		return
+	}
+
+	title := trend.PluginID
+	if trend.Name != "" {
+		title = fmt.Sprintf("%s (%s)", trend.Name, trend.PluginID)
+	}
+	fmt.Fprintf(errOut, "Trend for %s in application %s\n", title, trend.ApplicationID)
+
+	table := format.NewTable("TIMESTAMP", "SCAN ID", "ENV", "URIS", "NEW", "RESOLVED", "CHANGE")
+
+	found := false
+	for _, point := range trend.Points {
+		timestamp := ""
+		if ts, err := strconv.ParseInt(point.Timestamp, 10, 64); err == nil {
+			timestamp = format.FormatTimestamp(ts, displayLocation)
+		}
+
+		env := point.Env
+		if env == "" {
+			env = "N/A"
+		}
+
+		uriCount := "-"
+		if point.Present {
+			uriCount = format.Number(int64(point.URICount))
+			found = true
+		}
+
+		table.AddRow(timestamp, point.ScanID, env, uriCount, format.Number(int64(point.NewURIs)), format.Number(int64(point.ResolvedURIs)), point.Change)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+
+	if !found {
+		fmt.Fprintf(errOut, "Plugin %s was not found in any of the %d scans.\n", trend.PluginID, len(trend.Points))
+	}
 }
@@ -61,6 +61,9 @@ func (suite *AppCommandTestSuite) TestAppCommand_Structure() {
 	}
 
 	assert.Contains(suite.T(), subcommands, "list")
+	assert.Contains(suite.T(), subcommands, "create")
+	assert.Contains(suite.T(), subcommands, "delete <app>")
+	assert.Contains(suite.T(), subcommands, "alert-trend <app> <plugin-id>")
 }
 
 func (suite *AppCommandTestSuite) TestAppListFlags() {
@@ -84,6 +87,231 @@ func (suite *AppCommandTestSuite) TestAppListFlags() {
 	// Note: type flag may not exist in current implementation
 }
 
+func (suite *AppCommandTestSuite) TestAppCreateFlags() {
+	cmd := appCreateCmd
+
+	nameFlag := cmd.Flags().Lookup("name")
+	assert.NotNil(suite.T(), nameFlag)
+
+	envFlag := cmd.Flags().Lookup("env")
+	assert.NotNil(suite.T(), envFlag)
+	assert.Equal(suite.T(), "Development", envFlag.DefValue)
+
+	orgFlag := cmd.Flags().Lookup("org")
+	assert.NotNil(suite.T(), orgFlag)
+}
+
+func (suite *AppCommandTestSuite) TestAppDeleteFlags() {
+	cmd := appDeleteCmd
+	assert.Equal(suite.T(), "delete <app>", cmd.Use)
+
+	confirmFlag := cmd.Flags().Lookup("confirm")
+	assert.NotNil(suite.T(), confirmFlag)
+	assert.Equal(suite.T(), "false", confirmFlag.DefValue)
+}
+
+func (suite *AppCommandTestSuite) TestAppDelete_DeclinedPrompt() {
+	useMockAPI(suite.T())
+	stubPrompt(suite.T(), "n\n")
+
+	_, stderr := captureOutput(suite.T(), func() { runAppDelete("app-1", "", false) })
+	assert.Contains(suite.T(), stderr, "Deleting application Mock Application (app-1) cannot be undone. Continue? [y/N]: ")
+	assert.Contains(suite.T(), stderr, "Cancelled.")
+	assert.NotContains(suite.T(), stderr, "Application deleted")
+}
+
+func (suite *AppCommandTestSuite) TestAppDelete_ByName() {
+	useMockAPI(suite.T())
+	stubPrompt(suite.T(), "n\n")
+
+	_, stderr := captureOutput(suite.T(), func() { runAppDelete("mock application", "", false) })
+	assert.Contains(suite.T(), stderr, "Deleting application Mock Application (app-1) cannot be undone.")
+}
+
+// Delete can't be undone, so a part of a name isn't enough to pick the app
+func (suite *AppCommandTestSuite) TestAppDelete_RejectsPartialName() {
+	useMockAPI(suite.T())
+	resetExitCode(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() { runAppDelete("mock app", "", true) })
+	assert.Contains(suite.T(), stderr, `application not found: "mock app"`)
+	assert.NotContains(suite.T(), stderr, "Application deleted")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+}
+
+func (suite *AppCommandTestSuite) TestAppDelete_UnknownApp() {
+	useMockAPI(suite.T())
+	resetExitCode(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() { runAppDelete("Mock Aplication", "", true) })
+	assert.Contains(suite.T(), stderr, `application not found: "Mock Aplication". Did you mean "Mock Application"?`)
+	assert.NotContains(suite.T(), stderr, "Application deleted")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+}
+
+func (suite *AppCommandTestSuite) TestMatchApp() {
+	apps := []api.AppApplication{
+		{ApplicationID: "app-1", Name: "Billing API", Env: "Development"},
+		{ApplicationID: "app-1", Name: "Billing API", Env: "Production"},
+		{ApplicationID: "app-2", Name: "Billing API v2"},
+		{ApplicationID: "app-3", Name: "Checkout"},
+		{ApplicationID: "app-4", Name: "app-3"},
+	}
+
+	tests := []struct {
+		name     string
+		nameOrID string
+		want     string
+	}{
+		{"exact ID", "app-2", "app-2"},
+		{"ID before a name equal to it", "app-3", "app-3"},
+		{"exact name before substring", "billing api", "app-1"},
+		{"substring", "CHECK", "app-3"},
+		{"unique substring", "v2", "app-2"},
+	}
+	for _, tt := range tests {
+		app, err := matchApp(apps, tt.nameOrID, false)
+		if assert.NoError(suite.T(), err, tt.name) {
+			assert.Equal(suite.T(), tt.want, app.ApplicationID, tt.name)
+		}
+	}
+}
+
+func (suite *AppCommandTestSuite) TestMatchApp_Ambiguous() {
+	apps := []api.AppApplication{
+		{ApplicationID: "app-1", Name: "Billing API"},
+		{ApplicationID: "app-1", Name: "Billing API", Env: "Production"},
+		{ApplicationID: "app-2", Name: "Billing Worker"},
+	}
+
+	_, err := matchApp(apps, "billing", false)
+	assert.EqualError(suite.T(), err, `"billing" matches 2 applications: Billing API (app-1), Billing Worker (app-2). Use the full name or ID`)
+	assert.Equal(suite.T(), exitUsage, exitCodeFor(err))
+
+	_, err = matchApp(apps, "Payments", false)
+	assert.ErrorIs(suite.T(), err, errAppNotFound)
+	assert.Equal(suite.T(), exitNotFound, exitCodeFor(err))
+
+	// Exact matching still takes IDs and whole names, but not parts of names
+	app, err := matchApp(apps, "billing worker", true)
+	if assert.NoError(suite.T(), err) {
+		assert.Equal(suite.T(), "app-2", app.ApplicationID)
+	}
+	_, err = matchApp(apps, "Worker", true)
+	assert.ErrorIs(suite.T(), err, errAppNotFound)
+}
+
+func (suite *AppCommandTestSuite) TestAppList_DetailedShowsPolicyPerEnv() {
+	useMockAPI(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() { runAppList("tsv", 0, "", "", true) })
+	assert.Empty(suite.T(), stderr)
+	assert.Equal(suite.T(), "ID\tNAME\tENV\tPOLICY\tSTATUS\tTYPE\n"+
+		"app-1\tMock Application\tdevelopment\tDEFAULT\tACTIVE\tSTANDARD\n"+
+		"app-1\tMock Application\tproduction\tN/A\tACTIVE\tSTANDARD\n", stdout)
+}
+
+func (suite *AppCommandTestSuite) TestAppEnvironments_FallsBackToEntryEnv() {
+	app := api.AppApplication{ApplicationID: "app-1", Env: "Development", EnvID: "env-1", PolicyName: "DEFAULT"}
+	assert.Equal(suite.T(), []api.AppEnvironment{{EnvID: "env-1", Env: "Development", PolicyName: "DEFAULT"}}, appEnvironments(app))
+}
+
+func (suite *AppCommandTestSuite) TestAppScanHistory() {
+	scans := []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "s3", ApplicationID: "app-1", Env: "prod", Status: "COMPLETED", Timestamp: "3000"}},
+		{Scan: api.Scan{ID: "s1", ApplicationID: "app-1", Env: "prod", Status: "COMPLETED", Timestamp: "1000"}},
+		{Scan: api.Scan{ID: "s2", ApplicationID: "app-1", Env: "dev", Status: "COMPLETED", Timestamp: "2000"}},
+		{Scan: api.Scan{ID: "running", ApplicationID: "app-1", Env: "prod", Status: "STARTED", Timestamp: "4000"}},
+		{Scan: api.Scan{ID: "other", ApplicationID: "app-2", Env: "prod", Status: "COMPLETED", Timestamp: "1500"}},
+	}
+
+	ids := func(results []api.ApplicationScanResult) []string {
+		scanIDs := []string{}
+		for _, result := range results {
+			scanIDs = append(scanIDs, result.Scan.ID)
+		}
+		return scanIDs
+	}
+
+	assert.Equal(suite.T(), []string{"s1", "s2", "s3"}, ids(appScanHistory(scans, "app-1", "")))
+	assert.Equal(suite.T(), []string{"s1", "s3"}, ids(appScanHistory(scans, "app-1", "PROD")))
+}
+
+func (suite *AppCommandTestSuite) TestBuildAlertTrend() {
+	scan := func(id, env string) api.ApplicationScanResult {
+		return api.ApplicationScanResult{Scan: api.Scan{ID: id, Env: env}}
+	}
+	history := []api.ApplicationScanResult{
+		scan("s1", "prod"), scan("s2", "prod"), scan("d1", "dev"), scan("s3", "prod"), scan("s4", "prod"), scan("s5", "prod"),
+	}
+	xss := func(uris int) api.ScanAlert {
+		return api.ScanAlert{PluginID: "40012", Name: "Cross Site Scripting", URICount: uris}
+	}
+	alertsByScan := map[string][]api.ScanAlert{
+		"s1": {{PluginID: "10020", URICount: 1}},
+		"s2": {xss(5), xss(2)},
+		"d1": {},
+		"s3": {xss(3)},
+		"s5": {xss(1)},
+	}
+
+	trend := buildAlertTrend("app-1", "40012", history, alertsByScan, nil)
+	assert.Equal(suite.T(), "Cross Site Scripting", trend.Name)
+
+	var uris []int
+	var changes []string
+	for _, point := range trend.Points {
+		uris = append(uris, point.URICount)
+		changes = append(changes, point.Change)
+	}
+	assert.Equal(suite.T(), []int{0, 7, 0, 3, 0, 1}, uris)
+	// The dev scan without the finding doesn't resolve it in prod
+	assert.Equal(suite.T(), []string{"", trendFirstSeen, "", "", trendResolved, trendReappeared}, changes)
+}
+
+// New and resolved URIs are counted by fingerprint against the previous scan of
+// the same environment
+func (suite *AppCommandTestSuite) TestBuildAlertTrend_Fingerprints() {
+	scan := func(id, env string) api.ApplicationScanResult {
+		return api.ApplicationScanResult{Scan: api.Scan{ID: id, Env: env}}
+	}
+	history := []api.ApplicationScanResult{
+		scan("s1", "prod"), scan("d1", "dev"), scan("s2", "prod"), scan("s3", "prod"), scan("s4", "prod"), scan("s5", "prod"),
+	}
+	xss := []api.ScanAlert{{PluginID: "40012", URICount: 2}}
+	alertsByScan := map[string][]api.ScanAlert{"s1": xss, "d1": xss, "s2": xss, "s3": xss, "s4": {}, "s5": xss}
+	finding := func(uri string) api.ScanAlertFinding {
+		return api.ScanAlertFinding{PluginID: "40012", URI: uri, RequestMethod: "GET"}
+	}
+	findingsByScan := map[string][]api.ScanAlertFinding{
+		"s1": {finding("/search"), finding("/login")},
+		"d1": {finding("/admin")},
+		// Same plugin and URI count as s1, but one finding moved
+		"s2": {finding("/search/"), finding("/account")},
+		"s5": {finding("/search")},
+	}
+
+	trend := buildAlertTrend("app-1", "40012", history, alertsByScan, findingsByScan)
+
+	var counts [][2]int
+	for _, point := range trend.Points {
+		counts = append(counts, [2]int{point.NewURIs, point.ResolvedURIs})
+	}
+	// s3's findings are unknown, so neither it nor the s4 that follows is counted;
+	// s4 has no alert, so s5's finding is new again
+	assert.Equal(suite.T(), [][2]int{{2, 0}, {1, 0}, {1, 1}, {0, 0}, {0, 0}, {1, 0}}, counts)
+}
+
+func (suite *AppCommandTestSuite) TestOutputAlertTrendTable() {
+	trend := alertTrend{ApplicationID: "app-1", PluginID: "40012", Points: []alertTrendPoint{
+		{ScanID: "s1", Env: "prod"},
+	}}
+
+	stdout, stderr := captureOutput(suite.T(), func() { outputAlertTrendTable(trend) })
+	assert.Contains(suite.T(), stdout, "CHANGE")
+	assert.Contains(suite.T(), stderr, "was not found in any of the 1 scans")
+}
+
 func TestAppCommandTestSuite(t *testing.T) {
 	suite.Run(t, new(AppCommandTestSuite))
 }
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Check StackHawk API credentials",
+	Long:  `Check StackHawk API credentials without changing the configuration.`,
+}
+
+// authTestCmd represents the auth test command
+var authTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Check that an API key can log in, without saving it",
+	Long: `Exchange an API key for a token at the StackHawk login endpoint and report
+whether it worked. Neither the key nor the token is saved, so a key can be checked
+before 'hawkop init' stores it.
+
+Pass the key with --api-key, or pipe it on standard input (or pass --api-key -) to
+keep it out of the process list and shell history. On failure the exit code says
+why: 3 if the key was rejected, 5 if the API rate limited the request or it timed
+out, and 1 if the API couldn't be reached or returned a server error.`,
+	Example: `  hawkop auth test --api-key "$HAWK_API_KEY"
+  printenv HAWK_API_KEY | hawkop auth test --format json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		apiKey, _ := cmd.Flags().GetString("api-key")
+		format, _ := cmd.Flags().GetString("format")
+		runAuthTest(apiKey, format)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authTestCmd)
+
+	authTestCmd.Flags().String("api-key", "", "API key to check; - or no value reads it from standard input")
+	authTestCmd.Flags().StringP("format", "f", "text", enumUsage("Output format", formatsTextJSON))
+	completeEnum(authTestCmd, "format", formatsTextJSON)
+}
+
+// authTestResult is what auth test --format json prints
+type authTestResult struct {
+	Valid     bool       `json:"valid"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Failure   string     `json:"failure,omitempty"` // see classifyAuthFailure
+	Error     string     `json:"error,omitempty"`
+}
+
+// classifyAuthFailure names why a key exchange failed and the exit code to report
+func classifyAuthFailure(err error) (string, int) {
+	var apiErr *api.APIError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout", exitUnavailable
+	case api.IsRateLimited(err):
+		return "rate_limited", exitUnavailable
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+		return "rejected", exitAuth
+	case errors.As(err, &apiErr):
+		return "server_error", exitError
+	default:
+		return "unreachable", exitError
+	}
+}
+
+// authFailureMessages are the text output for each classifyAuthFailure category
+var authFailureMessages = map[string]string{
+	"timeout":      "Timed out waiting for the StackHawk API",
+	"rate_limited": "Rate limited by the StackHawk API; try again later",
+	"rejected":     "API key rejected",
+	"server_error": "The StackHawk API couldn't check the key",
+	"unreachable":  "Could not reach the StackHawk API",
+}
+
+// readAPIKey returns the key given with --api-key, reading it from standard input
+// when the flag is - or empty and input is piped
+func readAPIKey(flagValue string) (string, error) {
+	if flagValue != "" && flagValue != "-" {
+		return flagValue, nil
+	}
+	if flagValue == "" && stdinIsTerminal() {
+		return "", newUsageError(errors.New("no API key given; pass --api-key or pipe the key on standard input"))
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key: %w", err)
+	}
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return "", newUsageError(errors.New("no API key on standard input"))
+	}
+	return apiKey, nil
+}
+
+func runAuthTest(apiKeyFlag string, outputFormat string) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "text" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTextJSON)
+		return
+	}
+
+	apiKey, err := readAPIKey(apiKeyFlag)
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	// The configuration still decides where and how to connect; only the key differs
+	cfg, err := loadConfigFile()
+	checkError(err)
+	cfg.APIKey = apiKey
+	cfg.JWTs = nil
+	client := newAPIClient(cfg)
+
+	auth, err := client.ExchangeAPIKey(apiKey)
+	if err != nil {
+		failure, code := classifyAuthFailure(err)
+		if outputFormat == "json" {
+			outputAuthTestJSON(authTestResult{Failure: failure, Error: err.Error()})
+		}
+		failf(code, "%s: %v", authFailureMessages[failure], err)
+		return
+	}
+
+	if outputFormat == "json" {
+		outputAuthTestJSON(authTestResult{Valid: true, ExpiresAt: &auth.ExpiresAt})
+		return
+	}
+	fmt.Fprintln(out, "✅ API key is valid")
+	fmt.Fprintf(out, "   Token expires: %s (in %s)\n",
+		auth.ExpiresAt.In(displayLocation).Format("2006-01-02 15:04:05 MST"),
+		format.FormatDuration(time.Until(auth.ExpiresAt)))
+}
+
+func outputAuthTestJSON(result authTestResult) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+// useLoginStatus points API clients at a server whose login endpoint answers with status
+func useLoginStatus(t *testing.T, status int) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"nope"}`))
+	}))
+	t.Cleanup(server.Close)
+	useBaseURL(t, server.URL)
+}
+
+// useBaseURL points API clients at url
+func useBaseURL(t *testing.T, url string) {
+	t.Helper()
+
+	orig := newClient
+	newClient = func(cfg *config.Config) *api.Client {
+		client := api.NewClient(cfg)
+		client.SetBaseURL(url)
+		return client
+	}
+	t.Cleanup(func() { newClient = orig })
+}
+
+func TestAuthTest_Valid(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	saved := stubConfigFile(t, &config.Config{})
+
+	stdout, stderr := captureOutput(t, func() { runAuthTest("candidate-key", "text") })
+	assert.Contains(t, stdout, "✅ API key is valid")
+	assert.Contains(t, stdout, "Token expires:")
+	assert.Empty(t, stderr)
+	assert.Equal(t, exitOK, commandExitCode)
+	assert.Empty(t, *saved, "auth test must not save the key or token")
+}
+
+func TestAuthTest_JSONFromStdin(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	stubNonInteractive(t)
+	origIn := in
+	in = strings.NewReader("candidate-key\n")
+	t.Cleanup(func() { in = origIn })
+
+	stdout, _ := captureOutput(t, func() { runAuthTest("", "json") })
+	var result authTestResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.True(t, result.Valid)
+	require.NotNil(t, result.ExpiresAt)
+	assert.Empty(t, result.Failure)
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestAuthTest_Failures(t *testing.T) {
+	tests := []struct {
+		status  int
+		failure string
+		code    int
+	}{
+		{http.StatusUnauthorized, "rejected", exitAuth},
+		{http.StatusForbidden, "rejected", exitAuth},
+		{http.StatusInternalServerError, "server_error", exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			resetExitCode(t)
+			stubConfigFile(t, &config.Config{})
+			useLoginStatus(t, tt.status)
+
+			stdout, stderr := captureOutput(t, func() { runAuthTest("bad-key", "json") })
+			var result authTestResult
+			require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+			assert.False(t, result.Valid)
+			assert.Equal(t, tt.failure, result.Failure)
+			assert.Contains(t, stderr, authFailureMessages[tt.failure])
+			assert.Equal(t, tt.code, commandExitCode)
+		})
+	}
+}
+
+func TestAuthTest_Unreachable(t *testing.T) {
+	resetExitCode(t)
+	stubConfigFile(t, &config.Config{})
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	useBaseURL(t, server.URL)
+
+	_, stderr := captureOutput(t, func() { runAuthTest("key", "text") })
+	assert.Contains(t, stderr, "Could not reach the StackHawk API")
+	assert.Equal(t, exitError, commandExitCode)
+}
+
+func TestAuthTest_NoKey(t *testing.T) {
+	resetExitCode(t)
+	stubPrompt(t, "")
+
+	_, stderr := captureOutput(t, func() { runAuthTest("", "text") })
+	assert.Contains(t, stderr, "no API key given")
+	assert.Equal(t, exitUsage, commandExitCode)
+
+	resetExitCode(t)
+	_, stderr = captureOutput(t, func() { runAuthTest("-", "text") })
+	assert.Contains(t, stderr, "no API key on standard input")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+// Accepted values for enum flags. Flag help, shell completion, and the "Unknown ..."
+// messages are all built from these lists, so adding a value here is enough to
+// offer it everywhere.
+var (
+	formatsTableJSON         = []string{"table", "json"}
+	formatsTableJSONTSV      = []string{"table", "json", "tsv"}
+	formatsTableJSONTSVCSV   = []string{"table", "json", "tsv", "csv"}
+	formatsTableJSONMarkdown = []string{"table", "json", "markdown"}
+	formatsTextJSON          = []string{"text", "json"}
+
+	severityValues   = api.Severities
+	scanStatusValues = []string{"STARTED", "COMPLETED", "ERROR"}
+	appStatusValues  = []string{"ACTIVE", "ENV_INCOMPLETE"}
+	userRoleValues   = []string{"admin", "member", "owner"}
+
+	appMatchValues    = []string{appMatchSubstring, appMatchExact, appMatchRegex}
+	uriMatchValues    = []string{uriMatchGlob, uriMatchRegex}
+	limitScopeValues  = []string{"per-org", "global"}
+	groupByValues     = []string{"cwe"}
+	scanGroupByValues = []string{"app", "env", "status"}
+
+	tableStyleValues  = []string{string(format.StyleMinimal), string(format.StyleBordered)}
+	headerStyleValues = []string{string(format.HeaderUpper), string(format.HeaderTitle), string(format.HeaderSnake), string(format.HeaderCamel)}
+)
+
+// enumUsage appends the accepted values to a flag's help text, e.g.
+// "Output format (table|json)"
+func enumUsage(usage string, values []string) string {
+	return usage + " (" + strings.Join(values, "|") + ")"
+}
+
+// choiceList quotes values for an error message: 'a', 'a' or 'b', or 'a', 'b', or 'c'
+func choiceList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	switch len(quoted) {
+	case 0:
+		return ""
+	case 1:
+		return quoted[0]
+	case 2:
+		return quoted[0] + " or " + quoted[1]
+	default:
+		return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+	}
+}
+
+// useChoices tells the user which values are accepted, leading with the closest
+// one when value looks like a typo of it
+func useChoices(value string, values []string) string {
+	if suggestion, ok := format.Suggest(value, values); ok {
+		return fmt.Sprintf("Did you mean '%s'? Use %s", suggestion, choiceList(values))
+	}
+	return "Use " + choiceList(values)
+}
+
+// completeEnum offers values when completing flag on cmd. File names are never
+// suggested, since none of these flags take a path.
+func completeEnum(cmd *cobra.Command, flag string, values []string) {
+	flags := cmd.Flags()
+	if flags.Lookup(flag) == nil {
+		flags = cmd.PersistentFlags()
+	}
+	if err := flags.SetAnnotation(flag, enumValuesAnnotation, values); err != nil {
+		panic(err)
+	}
+	err := cmd.RegisterFlagCompletionFunc(flag, func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		// Only reachable with a misspelled flag name, which is a programming error
+		panic(err)
+	}
+}
+
+// enumValuesAnnotation is the flag annotation completeEnum records a flag's
+// accepted values under, so defaults from elsewhere can be checked against them
+const enumValuesAnnotation = "hawkop_enum_values"
+
+// failUnknownFormat reports an --format value the command doesn't support
+func failUnknownFormat(outputFormat string, formats []string) {
+	failf(exitUsage, "Unknown format: %s. %s", outputFormat, useChoices(outputFormat, formats))
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completeFlag runs cobra's hidden __complete command for args and returns the
+// suggested values, without the trailing directive line
+func completeFlag(t *testing.T, args ...string) []string {
+	t.Helper()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	require.NoError(t, rootCmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.NotEmpty(t, lines)
+	assert.Equal(t, ":4", lines[len(lines)-1], "completion should not fall back to file names")
+	return lines[:len(lines)-1]
+}
+
+func TestChoiceList(t *testing.T) {
+	assert.Equal(t, "'cwe'", choiceList([]string{"cwe"}))
+	assert.Equal(t, "'table' or 'json'", choiceList(formatsTableJSON))
+	assert.Equal(t, "'table', 'json', or 'tsv'", choiceList(formatsTableJSONTSV))
+}
+
+func TestEnumUsage(t *testing.T) {
+	assert.Equal(t, "Output format (table|json|tsv)", enumUsage("Output format", formatsTableJSONTSV))
+}
+
+func TestCompletion_ScanListFlags(t *testing.T) {
+	assert.Equal(t, formatsTableJSONTSV, completeFlag(t, "scan", "list", "--format", ""))
+	assert.Equal(t, scanStatusValues, completeFlag(t, "scan", "list", "--status", ""))
+	assert.Equal(t, formatsTableJSON, completeFlag(t, "scan", "get", "--format", ""))
+	assert.Equal(t, scanViewNames(), completeFlag(t, "scan", "get", "--view", ""))
+	assert.Equal(t, headerStyleValues, completeFlag(t, "scan", "list", "--header-style", ""))
+}
+
+func TestCompletion_FailedFormatListsSameValues(t *testing.T) {
+	resetExitCode(t)
+	_, stderr := captureOutput(t, func() { failUnknownFormat("xml", formatsTableJSONTSV) })
+	assert.Contains(t, stderr, "Unknown format: xml. Use 'table', 'json', or 'tsv'")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
+
+func TestUseChoices_Suggestion(t *testing.T) {
+	assert.Equal(t, "Did you mean 'json'? Use 'table' or 'json'", useChoices("jsno", formatsTableJSON))
+	assert.Equal(t, "Did you mean 'per-org'? Use 'per-org' or 'global'", useChoices("perorg", limitScopeValues))
+	assert.Equal(t, "Use 'table' or 'json'", useChoices("yaml", formatsTableJSON))
+}
+
+// enumUsagePattern matches the "(a|b|c)" that enumUsage appends to flag help
+var enumUsagePattern = regexp.MustCompile(`\(([^()|\s]+(?:\|[^()|\s]+)+)\)$`)
+
+// TestCompletion_EveryEnumFlag checks that each flag listing its values in its
+// help text completes exactly those values
+func TestCompletion_EveryEnumFlag(t *testing.T) {
+	var walk func(cmd *cobra.Command, path []string)
+	walk = func(cmd *cobra.Command, path []string) {
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			match := enumUsagePattern.FindStringSubmatch(flag.Usage)
+			if match == nil {
+				return
+			}
+			args := append(append([]string{}, path...), "--"+flag.Name, "")
+			assert.Equal(t, strings.Split(match[1], "|"), completeFlag(t, args...), strings.Join(args, " "))
+		})
+		for _, child := range cmd.Commands() {
+			walk(child, append(append([]string{}, path...), child.Name()))
+		}
+	}
+	walk(rootCmd, nil)
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get and set configuration values",
+	Long: `Get and set individual configuration values without editing the config file by hand.
+
+Supported keys:
+  org_id           Default organization ID
+  base_url         StackHawk API base URL (http or https)
+  output_format    Default output format when --format is not given (table|json)
+  request_timeout  HTTP request timeout as a duration (e.g. 30s, 2m)`,
+}
+
+// configSetCmd sets a configuration value
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long: `Set a configuration value. The value is validated for the key before it is saved,
+and all other configuration is preserved. Pass an empty string to clear a key.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigSet(args[0], args[1])
+	},
+}
+
+// configGetCmd gets a configuration value
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Show a configuration value",
+	Long:  `Print the current value of a configuration key.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigGet(args[0])
+	},
+}
+
+// configExportCmd prints the whole configuration as JSON
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the configuration as JSON",
+	Long: `Print the whole configuration as JSON, for example to move it to another machine
+with 'hawkop config import'. ${VAR} references are exported as written.
+
+The output includes your API key unless you pass --redact, which replaces the API
+key and any proxy password with [REDACTED] and leaves out the cached JWT.`,
+	Example: `  hawkop config export > hawkop-config.json
+  hawkop config export --redact`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		redact, _ := cmd.Flags().GetBool("redact")
+		runConfigExport(redact)
+	},
+}
+
+// configImportCmd writes a configuration exported with 'config export'
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a configuration exported as JSON",
+	Long: `Import a configuration written by 'hawkop config export'. Use - to read it from
+stdin. Every value is validated before anything is saved.
+
+By default the imported configuration replaces the current one, after confirming.
+With --merge, only the values set in the file are applied: other settings are kept,
+and instances, baselines, and default environments are added to the existing ones.
+Redacted values are treated as unset, so merging a redacted export keeps your API key.`,
+	Example: `  hawkop config import hawkop-config.json
+  hawkop config import team-defaults.json --merge`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		merge, _ := cmd.Flags().GetBool("merge")
+		runConfigImport(args[0], merge)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+
+	configExportCmd.Flags().Bool("redact", false, "Replace the API key and proxy password with [REDACTED] and omit the JWT")
+	configImportCmd.Flags().Bool("merge", false, "Merge with the existing configuration instead of replacing it")
+}
+
+func runConfigSet(key string, value string) {
+	// Load existing config
+	cfg, err := config.Load()
+	checkError(err)
+
+	key = strings.ToLower(key)
+
+	// Organization IDs share the validation used by 'org set'
+	if key == "org_id" && value != "" {
+		if err := api.ValidateOrgID(value); err != nil {
+			failf(exitCodeFor(err), "%v", err)
+			return
+		}
+	}
+
+	if err := cfg.Set(key, value); err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	// Save configuration
+	err = cfg.Save()
+	checkError(err)
+
+	if value == "" {
+		fmt.Fprintf(errOut, "✅ %s cleared.\n", key)
+		return
+	}
+
+	saved, _ := cfg.Get(key)
+	fmt.Fprintf(errOut, "✅ %s set to: %s\n", key, saved)
+}
+
+func runConfigGet(key string) {
+	// Load existing config
+	cfg, err := config.Load()
+	checkError(err)
+
+	key = strings.ToLower(key)
+
+	value, err := cfg.Get(key)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	if value == "" {
+		fmt.Fprintf(errOut, "%s is not set.\n", key)
+		return
+	}
+
+	fmt.Fprintln(out, value)
+}
+
+func runConfigExport(redact bool) {
+	cfg, err := loadConfigFile()
+	checkError(err)
+
+	data, err := cfg.Export(redact)
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func runConfigImport(path string, merge bool) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to read %s: %v", path, err)
+		return
+	}
+
+	imported, err := config.ParseExport(data)
+	if err != nil {
+		failf(exitUsage, "Invalid configuration in %s: %v", path, err)
+		return
+	}
+	// Organization IDs share the validation used by 'org set'
+	if imported.OrgID != "" && !strings.Contains(imported.OrgID, "$") {
+		if err := api.ValidateOrgID(imported.OrgID); err != nil {
+			failf(exitCodeFor(err), "Invalid configuration in %s: %v", path, err)
+			return
+		}
+	}
+
+	cfg := imported
+	if merge {
+		cfg, err = loadConfigFile()
+		checkError(err)
+		cfg.Merge(imported)
+	} else if !confirm(fmt.Sprintf("This will replace the configuration in %s.", config.GetConfigFile())) {
+		return
+	}
+
+	checkError(saveConfigFile(cfg))
+
+	if merge {
+		fmt.Fprintf(errOut, "✅ Merged configuration from %s\n", path)
+	} else {
+		fmt.Fprintf(errOut, "✅ Imported configuration from %s\n", path)
+	}
+	if cfg.APIKey == "" {
+		fmt.Fprintln(errOut, "⚠️  No API key is configured. Run 'hawkop init' to add one.")
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/config"
+)
+
+type ConfigCommandTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigCommand_Structure() {
+	assert.Equal(suite.T(), "config", configCmd.Use)
+
+	subcommands := []string{}
+	for _, cmd := range configCmd.Commands() {
+		subcommands = append(subcommands, cmd.Use)
+	}
+
+	assert.Contains(suite.T(), subcommands, "set <key> <value>")
+	assert.Contains(suite.T(), subcommands, "get <key>")
+	assert.Contains(suite.T(), subcommands, "export")
+	assert.Contains(suite.T(), subcommands, "import <file>")
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigSetArgs() {
+	assert.Error(suite.T(), configSetCmd.Args(configSetCmd, []string{"org_id"}))
+	assert.NoError(suite.T(), configSetCmd.Args(configSetCmd, []string{"org_id", "test-org-id"}))
+	assert.Error(suite.T(), configGetCmd.Args(configGetCmd, []string{}))
+}
+
+// stubConfigFile serves cfg as the loaded config and records saved configs
+func stubConfigFile(t *testing.T, cfg *config.Config) *[]*config.Config {
+	t.Helper()
+
+	origLoad, origSave := loadConfigFile, saveConfigFile
+	var saved []*config.Config
+	loadConfigFile = func() (*config.Config, error) { return cfg, nil }
+	saveConfigFile = func(c *config.Config) error {
+		saved = append(saved, c)
+		return nil
+	}
+	t.Cleanup(func() { loadConfigFile, saveConfigFile = origLoad, origSave })
+	return &saved
+}
+
+// exportToFile runs config export and writes its output to a temporary file
+func (suite *ConfigCommandTestSuite) exportToFile(cfg *config.Config, redact bool) string {
+	stubConfigFile(suite.T(), cfg)
+	stdout, stderr := captureOutput(suite.T(), func() { runConfigExport(redact) })
+	require.Empty(suite.T(), stderr)
+
+	path := filepath.Join(suite.T().TempDir(), "hawkop-config.json")
+	require.NoError(suite.T(), os.WriteFile(path, []byte(stdout), 0o600))
+	return path
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigExportImport_RoundTrip() {
+	original := &config.Config{
+		APIKey:    "hawk.secret",
+		OrgID:     "test-org-id",
+		Timezone:  "UTC",
+		Baselines: []config.Baseline{{App: "Billing API", Env: "Production", ScanID: "scan-1"}},
+	}
+	path := suite.exportToFile(original, false)
+
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "hawk.old", OutputFormat: "json"})
+	assumeYes = true
+	suite.T().Cleanup(func() { assumeYes = false })
+
+	_, stderr := captureOutput(suite.T(), func() { runConfigImport(path, false) })
+	assert.Contains(suite.T(), stderr, "✅ Imported configuration from "+path)
+	require.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), original, (*saved)[0])
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigImport_MergeKeepsRedactedSecrets() {
+	path := suite.exportToFile(&config.Config{APIKey: "hawk.secret", OrgID: "test-org-id"}, true)
+
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "hawk.mine", OutputFormat: "json"})
+	_, stderr := captureOutput(suite.T(), func() { runConfigImport(path, true) })
+	assert.Contains(suite.T(), stderr, "✅ Merged configuration from "+path)
+	require.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), &config.Config{APIKey: "hawk.mine", OrgID: "test-org-id", OutputFormat: "json"}, (*saved)[0])
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigImport_ReplaceNeedsConfirmation() {
+	path := suite.exportToFile(&config.Config{OrgID: "test-org-id"}, false)
+
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "hawk.mine"})
+	stubNonInteractive(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runConfigImport(path, false) })
+	assert.Contains(suite.T(), stderr, "Re-run with --yes to proceed.")
+	assert.Empty(suite.T(), *saved)
+}
+
+func (suite *ConfigCommandTestSuite) TestConfigImport_Invalid() {
+	path := filepath.Join(suite.T().TempDir(), "bad.json")
+	require.NoError(suite.T(), os.WriteFile(path, []byte(`{"org_id": "not an org id"}`), 0o600))
+
+	saved := stubConfigFile(suite.T(), &config.Config{})
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runConfigImport(path, true) })
+	assert.Contains(suite.T(), stderr, "❌ Invalid configuration in "+path)
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+	assert.Empty(suite.T(), *saved)
+
+	_, stderr = captureOutput(suite.T(), func() { runConfigImport(filepath.Join(suite.T().TempDir(), "missing.json"), true) })
+	assert.Contains(suite.T(), stderr, "❌ Failed to read")
+}
+
+func TestConfigCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigCommandTestSuite))
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// confirm asks the user to approve a change described by prompt, such as
+// "This will delete application app-1.". It returns true straight away when --yes
+// is set. Otherwise it asks for a y/N answer on stdin, and refuses when hawkop
+// can't prompt, so scripts must opt in with --yes.
+func confirm(prompt string) bool {
+	if assumeYes {
+		return true
+	}
+
+	if !canPrompt() {
+		failf(exitUsage, "%s Re-run with --yes to proceed.", prompt)
+		return false
+	}
+
+	fmt.Fprintf(errOut, "%s Continue? [y/N]: ", prompt)
+	answer, _ := readAnswer(bufio.NewReader(in))
+	if !isYes(answer) {
+		fmt.Fprintln(errOut, "Cancelled.")
+		setExitCode(exitError)
+		return false
+	}
+	return true
+}
+
+// isYes reports whether a prompt answer is y or yes, in any case
+func isYes(answer string) bool {
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/format"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check hawkop's setup and connection for common problems",
+	Long: `Run a series of checks on hawkop's configuration and its connection to StackHawk,
+printing each result with a hint for fixing it:
+
+- Config file: exists, is readable and writable, and isn't readable by others
+- Config settings: the file parses and contains only settings hawkop knows
+- API key: one is configured
+- Connectivity: the API base URL can be reached
+- Authentication: the API key can log in
+- Clock: the system clock agrees with the API's, so tokens don't expire early
+
+Nothing is saved. hawkop exits non-zero if a critical check fails: an unreadable
+or invalid config file, no API key, no connection, or a rejected key.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDoctor(config.GetConfigFile())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// maxClockSkew is how far the system clock may drift from the API's before doctor warns
+const maxClockSkew = time.Minute
+
+// Doctor check outcomes
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// doctorCheck is the outcome of one doctor check. A failed check sets ExitCode when
+// it is critical.
+type doctorCheck struct {
+	Name     string
+	Status   string
+	Detail   string
+	Hint     string
+	ExitCode int
+}
+
+// doctorCheckIcons mark each outcome in doctor's output
+var doctorCheckIcons = map[string]string{
+	checkPass: "✅",
+	checkWarn: "⚠️ ",
+	checkFail: "❌",
+	checkSkip: "⏭️ ",
+}
+
+func runDoctor(configFile string) {
+	fmt.Fprintln(out, "🦅 HawkOp Doctor")
+	fmt.Fprintln(out, "================")
+	fmt.Fprintln(out)
+
+	checks := []doctorCheck{checkConfigFile(configFile), checkConfigSettings(configFile)}
+
+	// The configuration still loads when the file is missing, as an empty one
+	cfg, err := loadConfigFile()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	keyCheck := checkAPIKey(cfg)
+	checks = append(checks, keyCheck)
+
+	if keyCheck.Status == checkFail {
+		for _, name := range []string{"Connectivity", "Authentication", "Clock"} {
+			checks = append(checks, doctorCheck{Name: name, Status: checkSkip, Detail: "needs an API key"})
+		}
+	} else {
+		checks = append(checks, checkLogin(cfg)...)
+	}
+
+	failed, code := 0, exitOK
+	for _, check := range checks {
+		fmt.Fprintf(out, "%s %-15s %s\n", doctorCheckIcons[check.Status], check.Name, check.Detail)
+		if check.Hint != "" {
+			fmt.Fprintf(out, "   %-15s → %s\n", "", check.Hint)
+		}
+		if check.ExitCode != exitOK {
+			failed++
+			if code == exitOK {
+				code = check.ExitCode
+			}
+		}
+	}
+	fmt.Fprintln(out)
+
+	if failed > 0 {
+		failf(code, "%d critical check(s) failed", failed)
+		return
+	}
+	fmt.Fprintln(out, "No critical problems found.")
+}
+
+// checkConfigFile checks that the config file exists, can be read and written, and
+// is private to the current user
+func checkConfigFile(path string) doctorCheck {
+	check := doctorCheck{Name: "Config file"}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		check.Status, check.Detail = checkWarn, path+" does not exist"
+		check.Hint = "Run 'hawkop init' to create it"
+		return check
+	}
+	if err != nil {
+		check.Status, check.Detail, check.ExitCode = checkFail, err.Error(), exitError
+		check.Hint = "Check the permissions of " + filepath.Dir(path)
+		return check
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		check.Status, check.Detail, check.ExitCode = checkFail, err.Error(), exitError
+		check.Hint = "Make the file readable by your user: chmod 600 " + path
+		return check
+	}
+	file.Close()
+
+	file, err = os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		check.Status, check.Detail = checkWarn, path+" is not writable"
+		check.Hint = "hawkop can't save settings or cache tokens; chmod 600 " + path
+		return check
+	}
+	file.Close()
+
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		check.Status = checkWarn
+		check.Detail = fmt.Sprintf("%s is readable by other users (%04o)", path, perm)
+		check.Hint = "It holds your API key; chmod 600 " + path
+		return check
+	}
+
+	check.Status, check.Detail = checkPass, path
+	return check
+}
+
+// checkConfigSettings checks that the config file parses and holds only settings
+// this version of hawkop knows
+func checkConfigSettings(path string) doctorCheck {
+	check := doctorCheck{Name: "Config settings"}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		check.Status, check.Detail = checkSkip, "no readable config file"
+		return check
+	}
+
+	err = config.CheckFile(data)
+	switch {
+	case errors.Is(err, config.ErrUnknownFields):
+		check.Status, check.Detail = checkWarn, err.Error()
+		check.Hint = "These settings are ignored; remove them, or upgrade hawkop if a newer version wrote them"
+	case err != nil:
+		check.Status, check.Detail, check.ExitCode = checkFail, err.Error(), exitError
+		check.Hint = "Fix the YAML, or move the file aside and run 'hawkop init'"
+	default:
+		check.Status, check.Detail = checkPass, "all settings recognized"
+	}
+	return check
+}
+
+// checkAPIKey checks that an API key is configured
+func checkAPIKey(cfg *config.Config) doctorCheck {
+	if !cfg.HasValidCredentials() {
+		return doctorCheck{Name: "API key", Status: checkFail, Detail: "not configured",
+			Hint: "Run 'hawkop init' to set your API key", ExitCode: exitAuth}
+	}
+	return doctorCheck{Name: "API key", Status: checkPass, Detail: "configured"}
+}
+
+// checkLogin logs in with the configured API key, without saving the token, and
+// reports connectivity, authentication, and clock skew from the one exchange
+func checkLogin(cfg *config.Config) []doctorCheck {
+	loginCfg := *cfg
+	loginCfg.JWTs = nil
+	client := newAPIClient(&loginCfg)
+
+	connectivity := doctorCheck{Name: "Connectivity", Status: checkPass, Detail: client.BaseURL}
+	authentication := doctorCheck{Name: "Authentication"}
+	clock := doctorCheck{Name: "Clock"}
+
+	sent := time.Now()
+	auth, err := client.ExchangeAPIKey(cfg.APIKey)
+	received := time.Now()
+
+	if err != nil {
+		failure, code := classifyAuthFailure(err)
+		detail := fmt.Sprintf("%s: %v", authFailureMessages[failure], err)
+		if failure == "unreachable" || failure == "timeout" {
+			connectivity.Status, connectivity.Detail, connectivity.ExitCode = checkFail, detail, code
+			connectivity.Hint = "Check your network, --proxy or proxy setting, and base_url or --instance"
+			authentication.Status, authentication.Detail = checkSkip, "needs a connection"
+		} else {
+			authentication.Status, authentication.Detail, authentication.ExitCode = checkFail, detail, code
+			if failure == "rejected" {
+				authentication.Hint = "Create a new API key in StackHawk and run 'hawkop init'"
+			}
+		}
+		clock.Status, clock.Detail = checkSkip, "needs a successful login"
+		return []doctorCheck{connectivity, authentication, clock}
+	}
+
+	authentication.Status, authentication.Detail = checkPass, "API key accepted"
+	clock.Status, clock.Detail, clock.Hint = clockSkewCheck(auth, sent, received)
+	return []doctorCheck{connectivity, authentication, clock}
+}
+
+// clockSkewCheck estimates how far the system clock is from the API's. Tokens last
+// api.TokenLifetime, so the server issued this one at ExpiresAt minus that, which
+// should fall between when the request was sent and when the answer arrived.
+func clockSkewCheck(auth *api.AuthResponse, sent time.Time, received time.Time) (string, string, string) {
+	if !auth.ExpiryReported {
+		return checkSkip, "the API didn't report when the token expires", ""
+	}
+
+	issued := auth.ExpiresAt.Add(-api.TokenLifetime)
+	var skew time.Duration
+	switch {
+	case issued.Before(sent):
+		skew = sent.Sub(issued)
+	case issued.After(received):
+		skew = issued.Sub(received)
+	}
+
+	if skew > maxClockSkew {
+		direction := "ahead of"
+		if issued.After(received) {
+			direction = "behind"
+		}
+		return checkWarn, fmt.Sprintf("system clock is about %s %s the API's", format.FormatDuration(skew), direction),
+			"Sync your clock (e.g. enable NTP) so tokens aren't treated as expired early or late"
+	}
+	return checkPass, "in sync with the API", ""
+}
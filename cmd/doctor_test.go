@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+func TestCheckConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	check := checkConfigFile(path)
+	assert.Equal(t, checkWarn, check.Status)
+	assert.Contains(t, check.Hint, "hawkop init")
+
+	require.NoError(t, os.WriteFile(path, []byte("org_id: org-1\n"), 0644))
+	check = checkConfigFile(path)
+	assert.Equal(t, checkWarn, check.Status)
+	assert.Contains(t, check.Detail, "readable by other users (0644)")
+	assert.Contains(t, check.Hint, "chmod 600")
+
+	require.NoError(t, os.Chmod(path, 0600))
+	check = checkConfigFile(path)
+	assert.Equal(t, checkPass, check.Status)
+	assert.Equal(t, exitOK, check.ExitCode)
+}
+
+func TestCheckConfigSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("org_id: org-1\n"), 0600))
+	assert.Equal(t, checkPass, checkConfigSettings(path).Status)
+
+	require.NoError(t, os.WriteFile(path, []byte("org_id: org-1\nprofile: work\n"), 0600))
+	check := checkConfigSettings(path)
+	assert.Equal(t, checkWarn, check.Status)
+	assert.Contains(t, check.Detail, "profile")
+
+	require.NoError(t, os.WriteFile(path, []byte("org_id: [\n"), 0600))
+	check = checkConfigSettings(path)
+	assert.Equal(t, checkFail, check.Status)
+	assert.Equal(t, exitError, check.ExitCode)
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	sent := time.Now()
+	received := sent.Add(200 * time.Millisecond)
+	issuedAt := func(issued time.Time) *api.AuthResponse {
+		return &api.AuthResponse{ExpiresAt: issued.Add(api.TokenLifetime), ExpiryReported: true}
+	}
+
+	status, _, _ := clockSkewCheck(issuedAt(sent.Add(100*time.Millisecond)), sent, received)
+	assert.Equal(t, checkPass, status)
+
+	status, detail, hint := clockSkewCheck(issuedAt(sent.Add(-5*time.Minute)), sent, received)
+	assert.Equal(t, checkWarn, status)
+	assert.Equal(t, "system clock is about 5m ahead of the API's", detail)
+	assert.Contains(t, hint, "NTP")
+
+	_, detail, _ = clockSkewCheck(issuedAt(received.Add(3*time.Minute)), sent, received)
+	assert.Equal(t, "system clock is about 3m behind the API's", detail)
+
+	status, _, _ = clockSkewCheck(&api.AuthResponse{ExpiresAt: received.Add(api.TokenLifetime)}, sent, received)
+	assert.Equal(t, checkSkip, status, "an estimated expiry says nothing about the server's clock")
+}
+
+func TestRunDoctor(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("api_key: test-api-key\n"), 0600))
+
+	stdout, stderr := captureOutput(t, func() { runDoctor(path) })
+	assert.Contains(t, stdout, "✅ Authentication  API key accepted")
+	assert.Contains(t, stdout, "No critical problems found.")
+	assert.Empty(t, stderr)
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestRunDoctor_NoAPIKey(t *testing.T) {
+	resetExitCode(t)
+	stubConfigFile(t, &config.Config{})
+	refuseAPI(t)
+
+	stdout, stderr := captureOutput(t, func() { runDoctor(filepath.Join(t.TempDir(), "config.yaml")) })
+	assert.Contains(t, stdout, "❌ API key         not configured")
+	assert.Contains(t, stdout, "⏭️  Authentication  needs an API key")
+	assert.Contains(t, stderr, "1 critical check(s) failed")
+	assert.Equal(t, exitAuth, commandExitCode)
+}
+
+func TestRunDoctor_Rejected(t *testing.T) {
+	resetExitCode(t)
+	stubConfigFile(t, &config.Config{APIKey: "revoked"})
+	useLoginStatus(t, 401)
+
+	stdout, _ := captureOutput(t, func() { runDoctor(filepath.Join(t.TempDir(), "config.yaml")) })
+	assert.Contains(t, stdout, "✅ Connectivity")
+	assert.Contains(t, stdout, "❌ Authentication  API key rejected")
+	assert.Equal(t, exitAuth, commandExitCode)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hawkop/internal/api"
+)
+
+// Process exit codes. Scripts can branch on these instead of parsing messages.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitUnavailable = 5
+)
+
+// exitCodes describes each exit code for --print-exit-codes, in order
+var exitCodes = []struct {
+	Code    int
+	Meaning string
+}{
+	{exitOK, "Success"},
+	{exitError, "Error not covered by another code"},
+	{exitUsage, "Invalid arguments, flags, or flag values"},
+	{exitAuth, "Missing or rejected credentials, or access denied"},
+	{exitNotFound, "Requested resource not found"},
+	{exitUnavailable, "Rate limited by the API, still processing the request (202), or timed out (--timeout)"},
+}
+
+// commandExitCode is the exit code recorded by the running command. Commands
+// report failures on errOut and return, so Execute reads the code from here.
+var commandExitCode = exitOK
+
+// usageError marks an error caused by how hawkop was invoked rather than by the API
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// newUsageError wraps err as a usage error; a nil err stays nil
+func newUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return usageError{err: err}
+}
+
+// exitCodeFor maps an error to the exit code hawkop reports for it
+func exitCodeFor(err error) int {
+	var usage usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.DeadlineExceeded), api.IsRateLimited(err), errors.Is(err, api.ErrPending):
+		return exitUnavailable
+	case api.IsAuthError(err):
+		return exitAuth
+	case api.IsNotFound(err), errors.Is(err, errAppNotFound), errors.Is(err, errOrgNotFound):
+		return exitNotFound
+	case errors.As(err, &usage), errors.Is(err, api.ErrInvalidOrgID), errors.Is(err, api.ErrInvalidID):
+		return exitUsage
+	default:
+		return exitError
+	}
+}
+
+// setExitCode records code as the command's exit code. The first failure wins, so a
+// later generic error doesn't hide a more specific one.
+func setExitCode(code int) {
+	if commandExitCode == exitOK {
+		commandExitCode = code
+	}
+}
+
+// failf prints an error message to errOut and records code as the exit code
+func failf(code int, format string, args ...interface{}) {
+	fmt.Fprintf(errOut, "❌ "+format+"\n", args...)
+	setExitCode(code)
+}
+
+// printExitCodes writes the exit code table for --print-exit-codes
+func printExitCodes() {
+	for _, c := range exitCodes {
+		fmt.Fprintf(out, "%d  %s\n", c.Code, c.Meaning)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"hawkop/internal/api"
+)
+
+// resetExitCode clears the recorded exit code before and after a test
+func resetExitCode(t *testing.T) {
+	t.Helper()
+	commandExitCode = exitOK
+	t.Cleanup(func() { commandExitCode = exitOK })
+}
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, exitOK},
+		{"generic", errors.New("boom"), exitError},
+		{"server error", &api.APIError{StatusCode: 500}, exitError},
+		{"unauthorized", fmt.Errorf("failed to list apps: %w", &api.APIError{StatusCode: 401}), exitAuth},
+		{"forbidden", &api.APIError{StatusCode: 403}, exitAuth},
+		{"no credentials", fmt.Errorf("failed to get JWT: %w", api.ErrNoCredentials), exitAuth},
+		{"auth failed", fmt.Errorf("%w: HTTP 401", api.ErrAuthFailed), exitAuth},
+		{"not found", &api.APIError{StatusCode: 404}, exitNotFound},
+		{"wrapped not found", fmt.Errorf("scan scan-9: %w", api.ErrNotFound), exitNotFound},
+		{"rate limited", &api.APIError{StatusCode: 429}, exitUnavailable},
+		{"timeout", fmt.Errorf("request failed: %w", context.DeadlineExceeded), exitUnavailable},
+		{"accepted", fmt.Errorf("failed to list apps: %w", &api.PendingError{}), exitUnavailable},
+		{"invalid org", api.ValidateOrgID("not an id"), exitUsage},
+		{"invalid app", fmt.Errorf("delete: %w", api.ErrInvalidID), exitUsage},
+		{"usage", newUsageError(errors.New("unknown table style")), exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCodeFor(tt.err))
+		})
+	}
+
+	assert.NoError(t, newUsageError(nil))
+}
+
+func TestFailf_FirstCodeWins(t *testing.T) {
+	resetExitCode(t)
+
+	_, stderr := captureOutput(t, func() {
+		failf(exitNotFound, "Scan not found: %s", "scan-9")
+		failf(exitError, "Failed to format JSON")
+	})
+	assert.Equal(t, "❌ Scan not found: scan-9\n❌ Failed to format JSON\n", stderr)
+	assert.Equal(t, exitNotFound, commandExitCode)
+}
+
+func TestRunCommands_ExitCodes(t *testing.T) {
+	useMockAPI(t)
+
+	resetExitCode(t)
+	captureOutput(t, func() { runScanGet("scan-1", "xml", "", false, 10) })
+	assert.Equal(t, exitUsage, commandExitCode)
+
+	resetExitCode(t)
+	captureOutput(t, func() { runScanGet("missing-scan", "json", "", false, 10) })
+	assert.Equal(t, exitNotFound, commandExitCode)
+
+	resetExitCode(t)
+	captureOutput(t, func() { runScanGet("scan-1", "json", "", false, 10) })
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestExecute_ExitCodes(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		_ = rootCmd.Flags().Set("print-exit-codes", "false")
+	})
+
+	rootCmd.SetArgs([]string{"--no-such-flag"})
+	assert.Equal(t, exitUsage, Execute())
+
+	rootCmd.SetArgs([]string{"--print-exit-codes"})
+	var code int
+	captured, _ := captureOutput(t, func() { code = Execute() })
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, captured, "0  Success\n")
+	assert.Contains(t, captured, "5  Rate limited by the API, still processing the request (202), or timed out (--timeout)\n")
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+// scanFindingsCmd lists the URIs where a scan found one alert
+var scanFindingsCmd = &cobra.Command{
+	Use:   "findings <scan-id> <plugin-id>",
+	Short: "List the URIs where a scan found an alert",
+	Long: `List each URI where a scan found the alert with the given plugin ID, with the
+request method and triage status. Use 'hawkop scan alerts' to find plugin IDs.
+
+JSON output includes each finding's fingerprint, which is the same in every scan
+that reports the finding at the same URI, parameter, and method.
+
+--uri keeps only findings whose URI matches a pattern. By default the pattern is a
+glob matched against the whole URI, where * matches any run of characters
+(including /) and ? matches one character, so /api/* covers everything under /api.
+With --uri-match regex the pattern is a regular expression that may match anywhere
+in the URI; anchor it with ^ and $ to match the whole URI.`,
+	Example: `  hawkop scan findings <scan-id> 40012
+  hawkop scan findings <scan-id> 40012 --uri '/api/*'
+  hawkop scan findings <scan-id> 40012 --uri '^/api/v[12]/' --uri-match regex`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		uri, _ := cmd.Flags().GetString("uri")
+		uriMatch, _ := cmd.Flags().GetString("uri-match")
+		limit, _ := cmd.Flags().GetInt("limit")
+		runScanFindings(args[0], args[1], format, scanFindingsOptions{URI: uri, URIMatch: uriMatch, Limit: limit})
+	},
+}
+
+func init() {
+	scanCmd.AddCommand(scanFindingsCmd)
+
+	scanFindingsCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(scanFindingsCmd, "format", formatsTableJSON)
+	scanFindingsCmd.Flags().String("uri", "", "Only show findings whose URI matches this pattern")
+	scanFindingsCmd.Flags().String("uri-match", uriMatchGlob, enumUsage("How --uri is matched", uriMatchValues))
+	completeEnum(scanFindingsCmd, "uri-match", uriMatchValues)
+	scanFindingsCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
+}
+
+// scanFindingsOptions holds the filters applied by scan findings
+type scanFindingsOptions struct {
+	URI      string
+	URIMatch string // glob (default) or regex; see compileURIMatcher
+	Limit    int
+}
+
+// URI match modes for --uri-match
+const (
+	uriMatchGlob  = "glob"
+	uriMatchRegex = "regex"
+)
+
+// compileURIMatcher compiles the --uri pattern once for the given mode. An empty
+// pattern matches every URI.
+func compileURIMatcher(pattern, mode string) (*regexp.Regexp, error) {
+	var expr string
+	switch strings.ToLower(mode) {
+	case "", uriMatchGlob:
+		expr = globToRegexp(pattern)
+	case uriMatchRegex:
+		expr = pattern
+	default:
+		return nil, fmt.Errorf("unknown --uri-match mode: %s. %s", mode, useChoices(mode, uriMatchValues))
+	}
+
+	matcher, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --uri pattern %q: %w", pattern, err)
+	}
+	return matcher, nil
+}
+
+// globToRegexp converts a glob to an anchored regular expression. Unlike
+// path.Match, * also matches /, since URI globs usually mean "anything below".
+func globToRegexp(glob string) string {
+	if glob == "" {
+		return ""
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return expr.String()
+}
+
+// filterFindingsByURI keeps the findings whose URI matches matcher
+func filterFindingsByURI(findings []api.ScanAlertFinding, matcher *regexp.Regexp) []api.ScanAlertFinding {
+	filtered := []api.ScanAlertFinding{}
+	for _, finding := range findings {
+		if matcher.MatchString(finding.URI) {
+			filtered = append(filtered, finding)
+		}
+	}
+	return filtered
+}
+
+func runScanFindings(scanID string, pluginID string, outputFormat string, opts scanFindingsOptions) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+
+	// Validate the pattern before fetching so a typo fails fast
+	matcher, err := compileURIMatcher(opts.URI, opts.URIMatch)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	client := newAPIClient(cfg)
+	result, err := client.GetAlertFindings(scanID, pluginID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get findings: %v", err)
+		return
+	}
+
+	findings := filterFindingsByURI(result.ApplicationScanAlertUris, matcher)
+
+	// Apply limit if specified
+	if opts.Limit > 0 && len(findings) > opts.Limit {
+		findings = findings[:opts.Limit]
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		data, err := json.MarshalIndent(fingerprintedFindings(findings), "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "table":
+		outputFindingsTable(findings, len(result.ApplicationScanAlertUris))
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+// fingerprintedFinding is a finding as scan findings prints it in JSON, with the
+// fingerprint that identifies it in other scans
+type fingerprintedFinding struct {
+	api.ScanAlertFinding
+	Fingerprint string `json:"fingerprint"`
+}
+
+func fingerprintedFindings(findings []api.ScanAlertFinding) []fingerprintedFinding {
+	result := make([]fingerprintedFinding, len(findings))
+	for i, finding := range findings {
+		result[i] = fingerprintedFinding{ScanAlertFinding: finding, Fingerprint: finding.Fingerprint()}
+	}
+	return result
+}
+
+// outputFindingsTable prints findings, noting how many the filters left out of total
+func outputFindingsTable(findings []api.ScanAlertFinding, total int) {
+	if len(findings) == 0 {
+		fmt.Fprintln(errOut, "No findings found.")
+		return
+	}
+
+	table := format.NewTable("URI", "METHOD", "STATUS")
+	for _, finding := range findings {
+		method := finding.RequestMethod
+		if method == "" {
+			method = "N/A"
+		}
+		status := finding.Status
+		if status == "" {
+			status = "N/A"
+		}
+		table.AddRow(finding.URI, method, status)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+	fmt.Fprintf(errOut, "Showing %d of %d findings.\n", len(findings), total)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+)
+
+func TestCompileURIMatcher_Glob(t *testing.T) {
+	matcher, err := compileURIMatcher("/api/*", "glob")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/api/v1/users"))
+	assert.True(t, matcher.MatchString("/api/"))
+	assert.False(t, matcher.MatchString("/login"))
+	assert.False(t, matcher.MatchString("/v2/api/users"), "globs match the whole URI")
+
+	// ? matches one character and other regex characters are literal
+	matcher, err = compileURIMatcher("/v?/search.json", "")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/v1/search.json"))
+	assert.False(t, matcher.MatchString("/v10/search.json"))
+	assert.False(t, matcher.MatchString("/v1/searchxjson"))
+
+	// Brackets that would be an invalid regex are fine in a glob
+	matcher, err = compileURIMatcher("/items[", "glob")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/items["))
+}
+
+func TestCompileURIMatcher_Regex(t *testing.T) {
+	matcher, err := compileURIMatcher(`^/api/v[12]/`, "regex")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/api/v2/users"))
+	assert.False(t, matcher.MatchString("/api/v3/users"))
+
+	// Unanchored patterns match anywhere in the URI
+	matcher, err = compileURIMatcher("users", "REGEX")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/api/v2/users/42"))
+}
+
+func TestCompileURIMatcher_Invalid(t *testing.T) {
+	_, err := compileURIMatcher("/items[", "regex")
+	assert.ErrorContains(t, err, `invalid --uri pattern "/items["`)
+
+	_, err = compileURIMatcher("/api/*", "prefix")
+	assert.ErrorContains(t, err, "unknown --uri-match mode: prefix")
+
+	// No pattern matches everything
+	matcher, err := compileURIMatcher("", "glob")
+	require.NoError(t, err)
+	assert.True(t, matcher.MatchString("/anything"))
+}
+
+func TestRunScanFindings_FiltersByURI(t *testing.T) {
+	useMockAPI(t)
+
+	stdout, _ := captureOutput(t, func() {
+		runScanFindings("scan-1", "40012", "json", scanFindingsOptions{URI: "/api/*"})
+	})
+
+	var findings []api.ScanAlertFinding
+	require.NoError(t, json.Unmarshal([]byte(stdout), &findings))
+	require.Len(t, findings, 2)
+	assert.Equal(t, "/api/v1/search", findings[0].URI)
+	assert.Equal(t, "/api/v2/users", findings[1].URI)
+
+	var fingerprinted []fingerprintedFinding
+	require.NoError(t, json.Unmarshal([]byte(stdout), &fingerprinted))
+	assert.Equal(t, findings[0].Fingerprint(), fingerprinted[0].Fingerprint)
+
+	stdout, stderr := captureOutput(t, func() {
+		runScanFindings("scan-1", "40012", "table", scanFindingsOptions{URI: "^/login$", URIMatch: "regex"})
+	})
+	assert.Contains(t, stdout, "/login")
+	assert.NotContains(t, stdout, "/api/")
+	assert.Contains(t, stderr, "Showing 1 of 3 findings.")
+}
+
+func TestRunScanFindings_InvalidPattern(t *testing.T) {
+	resetExitCode(t)
+
+	_, stderr := captureOutput(t, func() {
+		runScanFindings("scan-1", "40012", "table", scanFindingsOptions{URI: "(", URIMatch: "regex"})
+	})
+	assert.Contains(t, stderr, "❌ invalid --uri pattern")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+// scanFindingsExportCmd exports every finding of a scan as CSV
+var scanFindingsExportCmd = &cobra.Command{
+	Use:   "findings-export <scan-id>",
+	Short: "Export a scan's findings as CSV, one row per vulnerable URI",
+	Long: `Fetch the findings of each of a scan's alerts and write them as CSV, one row per
+URI with the alert's plugin ID, name, severity, and CWE, and the finding's request
+method and triage status. Rows are ordered by severity, then plugin ID.
+
+Findings are fetched for several alerts at once (see --concurrency) while respecting
+the API rate limit. --severity keeps one severity; --min-severity keeps that
+severity and anything more severe. Alerts whose findings can't be fetched are
+listed after the export.`,
+	Example: `  hawkop scan findings-export <scan-id> --output findings.csv
+  hawkop scan findings-export <scan-id> --min-severity Medium`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		severity, _ := cmd.Flags().GetString("severity")
+		minSeverity, _ := cmd.Flags().GetString("min-severity")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		runScanFindingsExport(args[0], findingsExportOptions{Output: output, Severity: severity, MinSeverity: minSeverity, Concurrency: concurrency})
+	},
+}
+
+func init() {
+	scanCmd.AddCommand(scanFindingsExportCmd)
+
+	scanFindingsExportCmd.Flags().String("output", "", "Write the CSV to this file instead of stdout")
+	scanFindingsExportCmd.Flags().StringP("severity", "s", "", enumUsage("Only export alerts of this severity", severityValues))
+	completeEnum(scanFindingsExportCmd, "severity", severityValues)
+	scanFindingsExportCmd.Flags().String("min-severity", "", enumUsage("Only export alerts of this severity or higher", severityValues))
+	completeEnum(scanFindingsExportCmd, "min-severity", severityValues)
+	scanFindingsExportCmd.Flags().IntP("concurrency", "c", api.DefaultAlertConcurrency, "Number of alerts to fetch findings for concurrently")
+}
+
+// findingsExportOptions holds the settings of scan findings-export
+type findingsExportOptions struct {
+	Output      string
+	Severity    string
+	MinSeverity string
+	Concurrency int
+}
+
+// findingsExportHeaders are the CSV columns, written in the --header-style
+var findingsExportHeaders = []string{"PLUGIN ID", "NAME", "SEVERITY", "CWE", "URI", "METHOD", "STATUS"}
+
+func runScanFindingsExport(scanID string, opts findingsExportOptions) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+	if opts.Concurrency < 1 {
+		failf(exitUsage, "--concurrency must be at least 1")
+		return
+	}
+	if opts.Severity != "" && opts.MinSeverity != "" {
+		failf(exitUsage, "--severity and --min-severity cannot be used together")
+		return
+	}
+	if !validSeverityFilter(opts.Severity) || !validSeverityFilter(opts.MinSeverity) {
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	client := newAPIClient(cfg)
+	alerts, err := client.GetScanAlerts(scanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan alerts: %v", err)
+		return
+	}
+
+	alerts = filterAlertsByMinSeverity(filterAlertsBySeverity(alerts, opts.Severity), opts.MinSeverity)
+	sort.SliceStable(alerts, func(a, b int) bool {
+		if rankA, rankB := severityRank(alerts[a].Severity), severityRank(alerts[b].Severity); rankA != rankB {
+			return rankA < rankB
+		}
+		return alerts[a].PluginID < alerts[b].PluginID
+	})
+
+	results := client.FetchAlertFindings(scanID, alerts, opts.Concurrency)
+
+	failures := newPartialError("alerts", len(results))
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	headers := make([]string, len(findingsExportHeaders))
+	for i, header := range findingsExportHeaders {
+		headers[i] = format.ApplyHeaderStyle(header, headerStyle)
+	}
+	_ = writer.Write(headers)
+
+	rows := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures.add(result.Alert.PluginID, result.Alert.Name, result.Err)
+			continue
+		}
+		for _, finding := range result.Findings {
+			_ = writer.Write([]string{result.Alert.PluginID, result.Alert.Name, result.Alert.Severity, result.Alert.CWEID,
+				finding.URI, finding.RequestMethod, finding.Status})
+			rows++
+		}
+	}
+	writer.Flush()
+
+	if failures.allFailed() {
+		reportPartialFailures(failures, "get findings")
+		return
+	}
+
+	if opts.Output == "" {
+		fmt.Fprint(out, buf.String())
+	} else {
+		// Findings describe exploitable weaknesses, so the file is private to the user
+		if err := os.WriteFile(opts.Output, buf.Bytes(), 0600); err != nil {
+			failf(exitCodeFor(err), "Failed to write %s: %v", opts.Output, err)
+			return
+		}
+		fmt.Fprintf(errOut, "✅ Exported %d findings from %d alerts to %s\n", rows, len(results)-len(failures.Failures), opts.Output)
+	}
+	reportPartialFailures(failures, "get findings")
+}
+
+// filterAlertsByMinSeverity keeps the alerts at least as severe as minSeverity; an
+// empty minSeverity keeps all
+func filterAlertsByMinSeverity(alerts []api.ScanAlert, minSeverity string) []api.ScanAlert {
+	if minSeverity == "" {
+		return alerts
+	}
+
+	threshold := severityRank(minSeverity)
+	filtered := []api.ScanAlert{}
+	for _, alert := range alerts {
+		if severityRank(alert.Severity) <= threshold {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered
+}
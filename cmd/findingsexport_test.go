@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+// useFindingsExportServer serves a scan with High, Medium, and Low alerts and their
+// findings. Fetching the findings of the plugins in forbidden fails with 403.
+func useFindingsExportServer(t *testing.T, forbidden ...string) {
+	t.Helper()
+
+	findings := map[string][]api.ScanAlertFinding{
+		"40012": {
+			{PluginID: "40012", URI: "/search?q=1", RequestMethod: "GET", Status: "UNKNOWN"},
+			{PluginID: "40012", URI: "/users", RequestMethod: "POST", Status: "RISK_ACCEPTED"},
+		},
+		"10020": {{PluginID: "10020", URI: "/a,b", RequestMethod: "GET", Status: "UNKNOWN"}},
+		"10021": {{PluginID: "10021", URI: "/", RequestMethod: "GET", Status: "UNKNOWN"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/scan/scan-1/alerts" {
+			alerts := api.ScanAlertsResponse{}
+			alerts.ApplicationScanResults = append(alerts.ApplicationScanResults, api.ScanAlertsResult{
+				ApplicationAlerts: []api.ScanAlert{
+					{PluginID: "10021", Name: "Missing Header", Severity: "Low", CWEID: "693"},
+					{PluginID: "10020", Name: "Frameable", Severity: "Medium", CWEID: "1021"},
+					{PluginID: "40012", Name: "Reflected XSS", Severity: "High", CWEID: "79"},
+				},
+			})
+			_ = json.NewEncoder(w).Encode(alerts)
+			return
+		}
+
+		pluginID, ok := strings.CutPrefix(r.URL.Path, "/api/v1/scan/scan-1/alert/")
+		if !ok || findings[pluginID] == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if containsString(forbidden, pluginID) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.ScanAlertFindingsResponse{ApplicationScanAlertUris: findings[pluginID]})
+	}))
+	t.Cleanup(server.Close)
+
+	useBaseURL(t, server.URL)
+	cfg := &config.Config{APIKey: "key"}
+	cfg.SetJWT(server.URL, "jwt", time.Now().Add(time.Hour))
+	stubConfigFile(t, cfg)
+}
+
+func TestFindingsExport_CSV(t *testing.T) {
+	resetExitCode(t)
+	useFindingsExportServer(t)
+
+	stdout, stderr := captureOutput(t, func() { runScanFindingsExport("scan-1", findingsExportOptions{Concurrency: 2}) })
+	assert.Equal(t, "PLUGIN ID,NAME,SEVERITY,CWE,URI,METHOD,STATUS\n"+
+		"40012,Reflected XSS,High,79,/search?q=1,GET,UNKNOWN\n"+
+		"40012,Reflected XSS,High,79,/users,POST,RISK_ACCEPTED\n"+
+		"10020,Frameable,Medium,1021,\"/a,b\",GET,UNKNOWN\n"+
+		"10021,Missing Header,Low,693,/,GET,UNKNOWN\n", stdout)
+	assert.Empty(t, stderr)
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestFindingsExport_MinSeverityToFile(t *testing.T) {
+	resetExitCode(t)
+	useFindingsExportServer(t)
+	path := filepath.Join(t.TempDir(), "findings.csv")
+
+	stdout, stderr := captureOutput(t, func() {
+		runScanFindingsExport("scan-1", findingsExportOptions{Output: path, MinSeverity: "medium", Concurrency: 1})
+	})
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Exported 3 findings from 2 alerts to "+path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Missing Header")
+	assert.Equal(t, 4, strings.Count(string(data), "\n"))
+}
+
+func TestFindingsExport_PartialFailure(t *testing.T) {
+	resetExitCode(t)
+	useFindingsExportServer(t, "10020")
+
+	stdout, stderr := captureOutput(t, func() { runScanFindingsExport("scan-1", findingsExportOptions{Severity: "Medium", Concurrency: 1}) })
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "❌ Failed to get findings for all 1 alerts:")
+	assert.Equal(t, exitAuth, commandExitCode)
+
+	resetExitCode(t)
+	stdout, stderr = captureOutput(t, func() { runScanFindingsExport("scan-1", findingsExportOptions{Concurrency: 4}) })
+	assert.Contains(t, stdout, "Reflected XSS")
+	assert.NotContains(t, stdout, "Frameable")
+	assert.Contains(t, stderr, "⚠️  Failed to get findings for 1 of 3 alerts:")
+	assert.Contains(t, stderr, "Frameable (10020)")
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestFindingsExport_Validation(t *testing.T) {
+	resetExitCode(t)
+	_, stderr := captureOutput(t, func() {
+		runScanFindingsExport("scan-1", findingsExportOptions{Severity: "High", MinSeverity: "Low", Concurrency: 1})
+	})
+	assert.Contains(t, stderr, "--severity and --min-severity cannot be used together")
+	assert.Equal(t, exitUsage, commandExitCode)
+
+	_, stderr = captureOutput(t, func() { runScanFindingsExport("scan-1", findingsExportOptions{MinSeverity: "Critcal", Concurrency: 1}) })
+	assert.Contains(t, stderr, "Unknown severity: Critcal")
+}
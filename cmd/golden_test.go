@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/testutil"
+)
+
+// captureOutput redirects the command writers to buffers while fn runs and
+// returns what was written to out (data) and errOut (messages)
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	origOut, origErr := out, errOut
+	out, errOut = &stdout, &stderr
+	defer func() { out, errOut = origOut, origErr }()
+
+	fn()
+	return stdout.String(), stderr.String()
+}
+
+// captureStdout returns the data written to out while fn runs
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	stdout, _ := captureOutput(t, fn)
+	return stdout
+}
+
+// assertGolden compares output with testdata/<name>, rewriting it when -update is set
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	testutil.AssertGolden(t, filepath.Join("testdata", name), []byte(got))
+}
+
+// useMockAPI points commands at a mock API server with a valid configuration
+// for the rest of the test
+func useMockAPI(t *testing.T) *testutil.MockAPI {
+	t.Helper()
+
+	mockAPI := testutil.NewMockAPI(t)
+	origLoad, origClient := loadConfigFile, newClient
+	loadConfigFile = func() (*config.Config, error) {
+		cfg := *mockAPI.Config
+		return &cfg, nil
+	}
+	newClient = mockAPI.NewClient
+	t.Cleanup(func() { loadConfigFile, newClient = origLoad, origClient })
+	stubScanCacheDir(t)
+
+	return mockAPI
+}
+
+// assertCommandJSONGolden runs a command against the mock API and compares its
+// JSON output with testdata/<name>. The command must not print any messages.
+func assertCommandJSONGolden(t *testing.T, name string, run func()) {
+	t.Helper()
+
+	useMockAPI(t)
+	testutil.AssertJSONGolden(t, filepath.Join("testdata", name), func(w io.Writer) {
+		var stderr bytes.Buffer
+		origOut, origErr := out, errOut
+		out, errOut = w, &stderr
+		defer func() { out, errOut = origOut, origErr }()
+
+		run()
+		assert.Empty(t, stderr.String())
+	})
+}
+
+func TestCommandJSON_Golden(t *testing.T) {
+	t.Run("scan list", func(t *testing.T) {
+		assertCommandJSONGolden(t, "scan-list.json.golden", func() {
+			runScanList("json", "", scanListOptions{}, false, 0)
+		})
+	})
+	t.Run("app list", func(t *testing.T) {
+		assertCommandJSONGolden(t, "app-list.json.golden", func() { runAppList("json", 0, "", "", false) })
+	})
+	t.Run("user list", func(t *testing.T) {
+		assertCommandJSONGolden(t, "user-list.json.golden", func() { runUserList("json", 0, "", "", timeRange{}, false) })
+	})
+	t.Run("org list", func(t *testing.T) {
+		assertCommandJSONGolden(t, "org-list.json.golden", func() { runOrgList("json", 0) })
+	})
+}
+
+func TestCommandTSV_Golden(t *testing.T) {
+	// Timestamps are formatted in the --timezone location
+	origLocation := displayLocation
+	displayLocation = time.UTC
+	t.Cleanup(func() { displayLocation = origLocation })
+
+	t.Run("scan list", func(t *testing.T) {
+		useMockAPI(t)
+		stdout, stderr := captureOutput(t, func() { runScanList("tsv", "", scanListOptions{Totals: true}, false, 0) })
+		assert.Empty(t, stderr)
+		assertGolden(t, "scan-list.tsv.golden", stdout)
+	})
+	t.Run("app list", func(t *testing.T) {
+		useMockAPI(t)
+		stdout, stderr := captureOutput(t, func() { runAppList("tsv", 0, "", "", false) })
+		assert.Empty(t, stderr)
+		assertGolden(t, "app-list.tsv.golden", stdout)
+	})
+}
+
+func goldenScanResults() []api.ApplicationScanResult {
+	return []api.ApplicationScanResult{
+		{
+			Scan: api.Scan{
+				ID:              "scan-1",
+				ApplicationID:   "app-1",
+				ApplicationName: "Test App",
+				Env:             "production",
+				Status:          "COMPLETED",
+				Timestamp:       "1756596062834",
+			},
+			ScanDuration: "45",
+			URLCount:     "10",
+			AlertStats:   &api.AlertStats{High: 2, Medium: 3, Low: 1, Total: 6},
+			PolicyName:   "Default",
+			Tags:         []api.ScanTag{{Name: "branch", Value: "main"}, {Name: "commit", Value: "abc123"}},
+			Metadata: map[string]interface{}{
+				"zeta":  "last",
+				"alpha": "first",
+				"mid":   map[string]interface{}{"y": 2.0, "x": 1.0},
+			},
+		},
+	}
+}
+
+func TestScansJSON_Golden(t *testing.T) {
+	first := captureStdout(t, func() { outputScansJSON(goldenScanResults()) })
+
+	// Output must be byte-identical across runs
+	for i := 0; i < 5; i++ {
+		again := captureStdout(t, func() { outputScansJSON(goldenScanResults()) })
+		assert.Equal(t, first, again)
+	}
+
+	assertGolden(t, "scans.json.golden", first)
+}
+
+func TestApplicationsJSON_Golden(t *testing.T) {
+	apps := []api.AppApplication{
+		{
+			ApplicationID:     "app-1",
+			Name:              "Test Application",
+			Env:               "Development",
+			ApplicationStatus: "ACTIVE",
+			ApplicationType:   "STANDARD",
+			CloudScanTarget:   map[string]interface{}{"url": "https://example.com", "enabled": true},
+		},
+	}
+
+	got := captureStdout(t, func() { outputApplicationsJSON(apps) })
+	assertGolden(t, "apps.json.golden", got)
+}
+
+func TestScanJSON_NumericInputIsStable(t *testing.T) {
+	// The same scan sent with numeric or string scalars renders identically
+	var fromNumbers, fromStrings []api.ApplicationScanResult
+	require.NoError(t, json.Unmarshal([]byte(`[{"scan":{"id":"s"},"scanDuration":45,"urlCount":10}]`), &fromNumbers))
+	require.NoError(t, json.Unmarshal([]byte(`[{"scan":{"id":"s"},"scanDuration":"45","urlCount":"10"}]`), &fromStrings))
+
+	a := captureStdout(t, func() { outputScansJSON(fromNumbers) })
+	b := captureStdout(t, func() { outputScansJSON(fromStrings) })
+	assert.Equal(t, a, b)
+	assert.True(t, bytes.Contains([]byte(a), []byte(`"scanDuration": 45`)))
+}
+
+func TestOutput_MessagesGoToErrOut(t *testing.T) {
+	stdout, stderr := captureOutput(t, func() { outputScansTable(nil, false) })
+	assert.Empty(t, stdout)
+	assert.Equal(t, "No scans found.\n", stderr)
+
+	stdout, stderr = captureOutput(t, func() { outputScansTable(goldenScanResults(), false) })
+	assert.Contains(t, stdout, "scan-1")
+	assert.Empty(t, stderr)
+}
+
+func TestStreamScansJSON_MatchesBufferedOutput(t *testing.T) {
+	mockAPI := testutil.NewMockAPI(t)
+	client := mockAPI.NewClient(mockAPI.Config)
+	opts := scanListOptions{Limit: 100}
+
+	results, err := fetchScanList(client, testutil.MockOrgID, opts)
+	require.NoError(t, err)
+	buffered := captureStdout(t, func() { outputScansJSON(results) })
+
+	var streamErr error
+	streamed := captureStdout(t, func() { _, streamErr = streamScansJSON(client, testutil.MockOrgID, opts) })
+	require.NoError(t, streamErr)
+	assert.Equal(t, buffered, streamed)
+
+	// Filters that match nothing still produce a valid empty array
+	opts.Env = "no-such-env"
+	empty := captureStdout(t, func() { _, streamErr = streamScansJSON(client, testutil.MockOrgID, opts) })
+	require.NoError(t, streamErr)
+	assert.Equal(t, "[]\n", empty)
+}
+
+func TestScanSchema_Golden(t *testing.T) {
+	stdout, stderr := captureOutput(t, func() { runSchema("scan", "") })
+	assert.Empty(t, stderr)
+	assertGolden(t, "scan.schema.json.golden", stdout)
+}
+
+func TestSchema_AllTypes(t *testing.T) {
+	for _, st := range schemaTypes {
+		stdout := captureStdout(t, func() { runSchema(st.name, "") })
+
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &doc), st.name)
+		assert.Equal(t, "object", doc["type"], st.name)
+	}
+
+	// Go type names are accepted too
+	_, ok := findSchemaType("ScanAlert")
+	assert.True(t, ok)
+
+	_, stderr := captureOutput(t, func() { runSchema("widget", "") })
+	assert.Contains(t, stderr, "Unknown schema type")
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkop/internal/format"
+)
+
+// outputGroupedTable prints each group under a heading with its key and item count,
+// followed by the table that render lays out for its items. one and many name a
+// single item and several, e.g. "scan" and "scans".
+func outputGroupedTable[T any](groups []format.Group[T], one string, many string, render func([]T) *format.TableWriter) {
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		noun := many
+		if len(group.Items) == 1 {
+			noun = one
+		}
+		heading := fmt.Sprintf("%s (%d %s)", group.Key, len(group.Items), noun)
+		fmt.Fprintf(out, "%s\n%s\n", heading, strings.Repeat("=", len([]rune(heading))))
+		fmt.Fprint(out, renderTable(render(group.Items)))
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"hawkop/internal/api"
+)
+
+// validateID checks that id, an argument naming a kind of object such as "scan",
+// looks like an API ID (see api.ValidateID), so a pasted URL or stray quote fails
+// before any request is made rather than as a confusing 404. --no-id-validation
+// turns the check off.
+func validateID(kind string, id string) error {
+	if noIDValidation {
+		return nil
+	}
+	if err := api.ValidateID(kind, id); err != nil {
+		if id == "" {
+			return newUsageError(err)
+		}
+		return newUsageError(fmt.Errorf("%w (pass --no-id-validation to send it anyway)", err))
+	}
+	return nil
+}
+
+// validIDs validates each id as a kind ID, printing the first problem and
+// returning false if one isn't well formed
+func validIDs(kind string, ids ...string) bool {
+	for _, id := range ids {
+		if err := validateID(kind, id); err != nil {
+			failf(exitCodeFor(err), "%v", err)
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
+	"hawkop/internal/api"
 	"hawkop/internal/config"
 )
 
@@ -21,71 +23,143 @@ var initCmd = &cobra.Command{
 	
 The API key will be securely stored in your local configuration file and used for
 authenticating with the StackHawk API. You can optionally set a default organization
-to use for subsequent commands.`,
+to use for subsequent commands.
+
+--api-key and --org skip their prompts. With --format json, init never prompts:
+it keeps the configured API key unless --api-key is given, and prints the result
+as JSON instead of the welcome text, for provisioning scripts.`,
+	Example: `  hawkop init
+  hawkop init --api-key "$HAWK_API_KEY" --org <org-id> --format json`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		runInit()
+		apiKey, _ := cmd.Flags().GetString("api-key")
+		org, _ := cmd.Flags().GetString("org")
+		format, _ := cmd.Flags().GetString("format")
+		runInit(initOptions{APIKey: apiKey, OrgID: org, Format: format})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringP("format", "f", "text", enumUsage("Output format", formatsTextJSON))
+	completeEnum(initCmd, "format", formatsTextJSON)
+	initCmd.Flags().String("api-key", "", "API key to save instead of prompting for one")
+	initCmd.Flags().StringP("org", "o", "", "Default organization ID to save instead of prompting for one")
+	_ = initCmd.Flags().SetAnnotation("org", noProjectDefault, []string{"true"})
 }
 
-func runInit() {
-	fmt.Println("🦅 Welcome to HawkOp!")
-	fmt.Println()
-	fmt.Println("Let's set up your StackHawk credentials...")
-	fmt.Println()
+// initOptions holds the values given to init as flags
+type initOptions struct {
+	APIKey string
+	OrgID  string
+	Format string // text (default) or json
+}
 
-	// Load existing config
-	cfg, err := config.Load()
-	checkError(err)
+// initResult is what init --format json prints once the configuration is saved
+type initResult struct {
+	ConfigFile       string `json:"configFile"`
+	APIKeyConfigured bool   `json:"apiKeyConfigured"`
+	OrgID            string `json:"orgID"`
+}
+
+func runInit(opts initOptions) {
+	outputFormat := strings.ToLower(opts.Format)
+	if outputFormat == "" {
+		outputFormat = "text"
+	}
+	if outputFormat != "text" && outputFormat != "json" {
+		failUnknownFormat(opts.Format, formatsTextJSON)
+		return
+	}
+	interactive := outputFormat == "text"
+
+	if opts.OrgID != "" {
+		if err := api.ValidateOrgID(opts.OrgID); err != nil {
+			failf(exitUsage, "%v", err)
+			return
+		}
+	}
 
-	// Prompt for API key
-	apiKey, err := promptForAPIKey(cfg.APIKey)
+	if interactive {
+		fmt.Fprintln(errOut, "🦅 Welcome to HawkOp!")
+		fmt.Fprintln(errOut)
+		fmt.Fprintln(errOut, "Let's set up your StackHawk credentials...")
+		fmt.Fprintln(errOut)
+	}
+
+	// Load the config file as written, so environment overrides aren't saved into it
+	cfg, err := loadConfigFile()
 	checkError(err)
 
+	// Prompt for API key unless one was given
+	apiKey := opts.APIKey
+	if apiKey == "" && interactive {
+		apiKey, err = promptForAPIKey(cfg.APIKey)
+		checkError(err)
+	} else if apiKey == "" && cfg.APIKey == "" {
+		failf(exitUsage, "No API key configured. Pass --api-key with --format json.")
+		return
+	}
+
 	if apiKey != "" {
 		cfg.SetAPIKey(apiKey)
 	}
 
-	// Prompt for default organization (optional)
-	orgID, err := promptForOrgID(cfg.OrgID)
-	checkError(err)
+	// Prompt for default organization (optional) unless one was given
+	orgID := opts.OrgID
+	if orgID == "" && interactive {
+		orgID, err = promptForOrgID(cfg.OrgID)
+		checkError(err)
+	}
 
 	if orgID != "" {
 		cfg.SetOrgID(orgID)
 	}
 
 	// Save configuration
-	err = cfg.Save()
+	err = saveConfigFile(cfg)
 	checkError(err)
 
-	fmt.Println()
-	fmt.Println("✅ Configuration saved successfully!")
-	fmt.Printf("   Config file: %s\n", config.GetConfigFile())
+	if !interactive {
+		data, err := json.MarshalIndent(initResult{
+			ConfigFile:       config.GetConfigFile(),
+			APIKeyConfigured: cfg.APIKey != "",
+			OrgID:            cfg.OrgID,
+		}, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	fmt.Fprintln(errOut)
+	fmt.Fprintln(errOut, "✅ Configuration saved successfully!")
+	fmt.Fprintf(errOut, "   Config file: %s\n", config.GetConfigFile())
 
 	if cfg.APIKey != "" {
-		fmt.Println("   API key: configured")
+		fmt.Fprintln(errOut, "   API key: configured")
 	}
 	if cfg.OrgID != "" {
-		fmt.Printf("   Default org ID: %s\n", cfg.OrgID)
+		fmt.Fprintf(errOut, "   Default org ID: %s\n", cfg.OrgID)
 	}
 
-	fmt.Println()
-	fmt.Println("You can now use hawkop commands. Try:")
-	fmt.Println("  hawkop status")
-	fmt.Println("  hawkop org list")
+	fmt.Fprintln(errOut)
+	fmt.Fprintln(errOut, "You can now use hawkop commands. Try:")
+	fmt.Fprintln(errOut, "  hawkop status")
+	fmt.Fprintln(errOut, "  hawkop org list")
 }
 
 func promptForAPIKey(currentKey string) (string, error) {
 	if currentKey != "" {
-		fmt.Printf("Current API key: %s...%s\n",
+		fmt.Fprintf(errOut, "Current API key: %s...%s\n",
 			currentKey[:min(8, len(currentKey))],
 			strings.Repeat("*", max(0, len(currentKey)-8)))
-		fmt.Print("Enter new API key (or press Enter to keep current): ")
+		fmt.Fprint(errOut, "Enter new API key (or press Enter to keep current): ")
 	} else {
-		fmt.Print("Enter your StackHawk API key: ")
+		fmt.Fprint(errOut, "Enter your StackHawk API key: ")
 	}
 
 	// Read password without echo
@@ -94,7 +168,7 @@ func promptForAPIKey(currentKey string) (string, error) {
 		return "", fmt.Errorf("failed to read API key: %w", err)
 	}
 
-	fmt.Println() // Print newline after hidden input
+	fmt.Fprintln(errOut) // Print newline after hidden input
 
 	apiKey := strings.TrimSpace(string(byteKey))
 
@@ -114,10 +188,10 @@ func promptForOrgID(currentOrgID string) (string, error) {
 	reader := bufio.NewReader(os.Stdin)
 
 	if currentOrgID != "" {
-		fmt.Printf("Current default org ID: %s\n", currentOrgID)
-		fmt.Print("Enter new org ID (or press Enter to keep current): ")
+		fmt.Fprintf(errOut, "Current default org ID: %s\n", currentOrgID)
+		fmt.Fprint(errOut, "Enter new org ID (or press Enter to keep current): ")
 	} else {
-		fmt.Print("Enter default org ID (optional): ")
+		fmt.Fprint(errOut, "Enter default org ID (optional): ")
 	}
 
 	input, err := reader.ReadString('\n')
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/config"
+)
+
+func TestRunInit_JSON(t *testing.T) {
+	resetExitCode(t)
+	saved := stubConfigFile(t, &config.Config{})
+
+	stdout, stderr := captureOutput(t, func() {
+		runInit(initOptions{APIKey: "new-key", OrgID: "org-1", Format: "json"})
+	})
+
+	var result initResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, initResult{ConfigFile: config.GetConfigFile(), APIKeyConfigured: true, OrgID: "org-1"}, result)
+	assert.Empty(t, stderr, "JSON output replaces the welcome text and prompts")
+	assert.Equal(t, exitOK, commandExitCode)
+
+	require.Len(t, *saved, 1)
+	assert.Equal(t, "new-key", (*saved)[0].APIKey)
+	assert.Equal(t, "org-1", (*saved)[0].OrgID)
+}
+
+func TestRunInit_JSONKeepsConfiguredKey(t *testing.T) {
+	resetExitCode(t)
+	saved := stubConfigFile(t, &config.Config{APIKey: "old-key", OrgID: "org-1"})
+
+	stdout, _ := captureOutput(t, func() { runInit(initOptions{Format: "json"}) })
+
+	assert.JSONEq(t, `{"configFile": "`+config.GetConfigFile()+`", "apiKeyConfigured": true, "orgID": "org-1"}`, stdout)
+	require.Len(t, *saved, 1)
+	assert.Equal(t, "old-key", (*saved)[0].APIKey)
+}
+
+func TestRunInit_JSONRequiresKey(t *testing.T) {
+	resetExitCode(t)
+	saved := stubConfigFile(t, &config.Config{})
+
+	stdout, stderr := captureOutput(t, func() { runInit(initOptions{OrgID: "org-1", Format: "json"}) })
+
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Pass --api-key with --format json")
+	assert.Equal(t, exitUsage, commandExitCode)
+	assert.Empty(t, *saved)
+}
+
+func TestRunInit_Validation(t *testing.T) {
+	resetExitCode(t)
+	saved := stubConfigFile(t, &config.Config{})
+
+	_, stderr := captureOutput(t, func() { runInit(initOptions{APIKey: "key", Format: "yaml"}) })
+	assert.Contains(t, stderr, "Unknown format: yaml")
+	assert.Equal(t, exitUsage, commandExitCode)
+
+	_, stderr = captureOutput(t, func() { runInit(initOptions{APIKey: "key", OrgID: "../org", Format: "json"}) })
+	assert.Contains(t, stderr, "invalid organization ID")
+	assert.Empty(t, *saved)
+}
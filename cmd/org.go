@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -30,10 +35,31 @@ var orgSetCmd = &cobra.Command{
 	Long: `Set the default organization ID that will be used for subsequent commands.
 	
 The organization ID will be stored in your configuration file and used as the default
-for commands that require an organization context.`,
+for commands that require an organization context.
+
+The ID must be one of the organizations you belong to, so a typo is caught here
+rather than as a "not found" error on every later command. Pass --force to store
+an organization that isn't listed for you yet.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		runOrgSet(args[0])
+		force, _ := cmd.Flags().GetBool("force")
+		runOrgSet(args[0], force)
+	},
+}
+
+// orgSwitchCmd picks the default organization from a list
+var orgSwitchCmd = &cobra.Command{
+	Use:   "switch",
+	Short: "Choose the default organization from a list",
+	Long: `List the organizations you belong to and set the one you pick by number as the
+default, like 'hawkop org set' without copying its ID.
+
+Picking needs a terminal. With --format json the organizations are printed instead,
+without prompting, so scripts can choose one and pass its ID to 'hawkop org set'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		runOrgSwitch(format)
 	},
 }
 
@@ -57,6 +83,27 @@ var orgClearCmd = &cobra.Command{
 	},
 }
 
+// orgSetEnvCmd sets the default environment for an organization
+var orgSetEnvCmd = &cobra.Command{
+	Use:   "set-env <env>",
+	Short: "Set the default environment for an organization",
+	Long: `Set the environment used when --env is omitted from 'scan list' and 'org alerts'.
+	
+The default is stored per organization in your configuration file. It applies to the
+organization given with --org, or your default organization. An explicit --env
+always takes precedence. Use --clear to remove the default.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		org, _ := cmd.Flags().GetString("org")
+		clearEnv, _ := cmd.Flags().GetBool("clear")
+		env := ""
+		if len(args) > 0 {
+			env = args[0]
+		}
+		runOrgSetEnv(env, org, clearEnv)
+	},
+}
+
 // orgListCmd lists all organizations the user belongs to
 var orgListCmd = &cobra.Command{
 	Use:   "list",
@@ -72,26 +119,143 @@ name, plan, and other details.`,
 	},
 }
 
+// orgAlertsCmd collects alerts across the latest scans of every application in an organization
+var orgAlertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "List alerts across all applications in an organization",
+	Long: `Collect security alerts from the latest completed scan of every application
+and environment in the organization.
+	
+Scans are fetched concurrently (see --concurrency) while respecting the API rate limit.
+Scans whose alerts could not be fetched are reported after the results.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		severity, _ := cmd.Flags().GetString("severity")
+		env, _ := cmd.Flags().GetString("env")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		runOrgAlerts(format, org, severity, env, concurrency)
+	},
+}
+
+// orgSLACmd reports how long open High and Medium findings have gone unresolved
+var orgSLACmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Report the age of open High and Medium findings against an SLA",
+	Long: `For the latest completed scan of every application and environment, report how
+long each open High and Medium finding has been present and flag those older than
+their SLA (--high-days and --medium-days).
+
+A finding's age is measured from the oldest scan in its current, unbroken run:
+walking back through the environment's scan history, the run ends at the first scan
+without the plugin. Only the most recent --history scans of each environment are
+examined, so a finding present in all of them is reported with a minimum age,
+shown as e.g. "45+". Alerts are fetched for every examined scan, so this command
+makes many more requests than 'org alerts'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		env, _ := cmd.Flags().GetString("env")
+		highDays, _ := cmd.Flags().GetInt("high-days")
+		mediumDays, _ := cmd.Flags().GetInt("medium-days")
+		history, _ := cmd.Flags().GetInt("history")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		runOrgSLA(format, org, env, slaPolicy{HighDays: highDays, MediumDays: mediumDays}, history, concurrency)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(orgCmd)
 	orgCmd.AddCommand(orgSetCmd)
+	orgCmd.AddCommand(orgSwitchCmd)
 	orgCmd.AddCommand(orgGetCmd)
 	orgCmd.AddCommand(orgClearCmd)
+	orgCmd.AddCommand(orgSetEnvCmd)
 	orgCmd.AddCommand(orgListCmd)
+	orgCmd.AddCommand(orgAlertsCmd)
+	orgCmd.AddCommand(orgSLACmd)
+
+	// Add flags for org set command
+	orgSetCmd.Flags().Bool("force", false, "Store the ID without checking that you belong to the organization")
+
+	// Add flags for org switch command
+	orgSwitchCmd.Flags().StringP("format", "f", "text", enumUsage("Output format; json lists the choices without prompting", formatsTextJSON))
+	completeEnum(orgSwitchCmd, "format", formatsTextJSON)
+
+	// Add flags for org set-env command
+	orgSetEnvCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	orgSetEnvCmd.Flags().Bool("clear", false, "Remove the organization's default environment")
 
 	// Add flags for org list command
-	orgListCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
+	orgListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(orgListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(orgListCmd)
 	orgListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
+
+	// Add flags for org alerts command
+	orgAlertsCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(orgAlertsCmd, "format", formatsTableJSON)
+	orgAlertsCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	orgAlertsCmd.Flags().StringP("severity", "s", "", enumUsage("Filter by severity", severityValues))
+	completeEnum(orgAlertsCmd, "severity", severityValues)
+	orgAlertsCmd.Flags().StringP("env", "e", "", "Only include these environments (comma-separated)")
+	orgAlertsCmd.Flags().IntP("concurrency", "c", api.DefaultAlertConcurrency, "Number of scans to fetch alerts for concurrently")
+
+	// Add flags for org sla command
+	orgSLACmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(orgSLACmd, "format", formatsTableJSON)
+	orgSLACmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	orgSLACmd.Flags().StringP("env", "e", "", "Only include these environments (comma-separated)")
+	orgSLACmd.Flags().Int("high-days", 30, "Days a High finding may stay open")
+	orgSLACmd.Flags().Int("medium-days", 90, "Days a Medium finding may stay open")
+	orgSLACmd.Flags().Int("history", 20, "Number of most recent scans per environment to examine (0 = all)")
+	orgSLACmd.Flags().IntP("concurrency", "c", api.DefaultAlertConcurrency, "Number of scans to fetch alerts for concurrently")
 }
 
-func runOrgSet(orgID string) {
+func runOrgSet(orgID string, force bool) {
 	// Load existing config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	checkError(err)
 
 	// Validate that we have credentials
 	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Reject malformed IDs before they are stored
+	if err := api.ValidateOrgID(orgID); err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	name := ""
+	if !force {
+		orgs, err := listUserOrganizations(cfg)
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to check organization membership: %v. Use --force to set it anyway.", err)
+			return
+		}
+		idx := slices.IndexFunc(orgs, func(org api.Organization) bool { return org.ID == orgID })
+		if idx < 0 {
+			failf(exitNotFound, "You don't belong to organization %s. Use --force to set it anyway.", orgID)
+			if len(orgs) > 0 {
+				fmt.Fprintln(errOut, "Available organizations:")
+				for _, org := range orgs {
+					fmt.Fprintf(errOut, "  %s  %s\n", org.ID, org.Name)
+				}
+			}
+			return
+		}
+		name = orgs[idx].Name
+	}
+
+	label := orgID
+	if name != "" {
+		label = fmt.Sprintf("%s (%s)", name, orgID)
+	}
+	if cfg.OrgID == orgID {
+		fmt.Fprintf(errOut, "%s is already your default organization.\n", label)
 		return
 	}
 
@@ -99,10 +263,83 @@ func runOrgSet(orgID string) {
 	cfg.SetOrgID(orgID)
 
 	// Save configuration
-	err = cfg.Save()
+	if err := saveConfig(cfg); err != nil {
+		failf(exitCodeFor(err), "Failed to save default organization: %v", err)
+		return
+	}
+
+	fmt.Fprintf(errOut, "✅ Default organization ID set to: %s\n", label)
+}
+
+// orgChoice is one organization org switch offers, as --format json prints it
+type orgChoice struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+func runOrgSwitch(outputFormat string) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "text" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTextJSON)
+		return
+	}
+
+	cfg, err := loadConfig()
 	checkError(err)
 
-	fmt.Printf("✅ Default organization ID set to: %s\n", orgID)
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	orgs, err := listUserOrganizations(cfg)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list organizations: %v", err)
+		return
+	}
+	if len(orgs) == 0 {
+		failf(exitNotFound, "You don't belong to any organizations.")
+		return
+	}
+
+	if outputFormat == "json" {
+		choices := make([]orgChoice, len(orgs))
+		for i, org := range orgs {
+			choices[i] = orgChoice{Index: i + 1, ID: org.ID, Name: org.Name, Current: org.ID == cfg.OrgID}
+		}
+		data, err := json.MarshalIndent(choices, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	if !canPrompt() {
+		failf(exitUsage, "Choosing an organization needs a terminal. Use 'hawkop org set <org-id>', or --format json to list the choices.")
+		return
+	}
+
+	fmt.Fprintln(errOut, "Choose your default organization:")
+	selected, ok := chooseOrganization(bufio.NewReader(in), orgs, cfg.OrgID)
+	if !ok {
+		failf(exitUsage, "No organization selected; the default is unchanged.")
+		return
+	}
+	if selected.ID == cfg.OrgID {
+		fmt.Fprintf(errOut, "%s (%s) is already your default organization.\n", selected.Name, selected.ID)
+		return
+	}
+
+	cfg.SetOrgID(selected.ID)
+	if err := saveConfig(cfg); err != nil {
+		failf(exitCodeFor(err), "Failed to save default organization: %v", err)
+		return
+	}
+	fmt.Fprintf(errOut, "✅ Default organization set to: %s (%s)\n", selected.Name, selected.ID)
 }
 
 func runOrgGet() {
@@ -111,10 +348,13 @@ func runOrgGet() {
 	checkError(err)
 
 	if cfg.OrgID == "" {
-		fmt.Println("No default organization ID configured.")
-		fmt.Println("Use 'hawkop org set <org-id>' to set one.")
+		fmt.Fprintln(errOut, "No default organization ID configured.")
+		fmt.Fprintln(errOut, "Use 'hawkop org set <org-id>' to set one.")
 	} else {
-		fmt.Printf("Default organization ID: %s\n", cfg.OrgID)
+		fmt.Fprintf(out, "Default organization ID: %s\n", cfg.OrgID)
+		if env := cfg.DefaultEnv(cfg.OrgID); env != "" {
+			fmt.Fprintf(out, "Default environment: %s\n", env)
+		}
 	}
 }
 
@@ -124,7 +364,7 @@ func runOrgClear() {
 	checkError(err)
 
 	if cfg.OrgID == "" {
-		fmt.Println("No default organization ID is currently set.")
+		fmt.Fprintln(errOut, "No default organization ID is currently set.")
 		return
 	}
 
@@ -135,27 +375,61 @@ func runOrgClear() {
 	err = cfg.Save()
 	checkError(err)
 
-	fmt.Println("✅ Default organization ID cleared.")
+	fmt.Fprintln(errOut, "✅ Default organization ID cleared.")
+}
+
+func runOrgSetEnv(env string, orgID string, clearEnv bool) {
+	env = strings.TrimSpace(env)
+	if clearEnv && env != "" {
+		failf(exitUsage, "--clear does not take an environment")
+		return
+	}
+	if !clearEnv && env == "" {
+		failf(exitUsage, "Specify an environment, or use --clear to remove the default.")
+		return
+	}
+
+	// Load existing config
+	cfg, err := config.Load()
+	checkError(err)
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	cfg.SetDefaultEnv(orgID, env)
+
+	// Save configuration
+	err = cfg.Save()
+	checkError(err)
+
+	if clearEnv {
+		fmt.Fprintf(errOut, "✅ Default environment cleared for organization %s.\n", orgID)
+		return
+	}
+	fmt.Fprintf(errOut, "✅ Default environment for organization %s set to: %s\n", orgID, env)
 }
 
 func runOrgList(outputFormat string, limit int) {
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	checkError(err)
 
 	// Validate that we have credentials
 	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
 		return
 	}
 
 	// Create API client
-	client := api.NewClient(cfg)
+	client := newAPIClient(cfg)
 
 	// Get organizations
 	orgs, err := client.ListOrganizations()
 	if err != nil {
-		fmt.Printf("❌ Failed to list organizations: %v\n", err)
+		failf(exitCodeFor(err), "Failed to list organizations: %v", err)
 		return
 	}
 
@@ -164,14 +438,20 @@ func runOrgList(outputFormat string, limit int) {
 		orgs = orgs[:limit]
 	}
 
+	if outputTemplated(orgs) {
+		return
+	}
+
 	// Output based on format
 	switch strings.ToLower(outputFormat) {
 	case "json":
 		outputJSON(orgs)
 	case "table":
 		outputTable(orgs)
+	case "tsv":
+		outputTSV(orgsTable(orgs))
 	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
 		return
 	}
 }
@@ -179,18 +459,23 @@ func runOrgList(outputFormat string, limit int) {
 func outputJSON(orgs []api.Organization) {
 	data, err := json.MarshalIndent(orgs, "", "  ")
 	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 		return
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 }
 
 func outputTable(orgs []api.Organization) {
 	if len(orgs) == 0 {
-		fmt.Println("No organizations found.")
+		fmt.Fprintln(errOut, "No organizations found.")
 		return
 	}
 
+	fmt.Fprint(out, renderTable(orgsTable(orgs)))
+}
+
+// orgsTable lays out organizations for table and tsv output
+func orgsTable(orgs []api.Organization) *format.TableWriter {
 	table := format.NewTable("ID", "NAME", "PLAN", "CREATED")
 
 	for _, org := range orgs {
@@ -198,7 +483,7 @@ func outputTable(orgs []api.Organization) {
 		if org.CreatedTimestamp != "" {
 			// Convert millisecond timestamp to readable date
 			if ts, err := strconv.ParseInt(org.CreatedTimestamp, 10, 64); err == nil {
-				created = time.Unix(ts/1000, 0).Format("2006-01-02")
+				created = format.TimeIn(ts, displayLocation).Format("2006-01-02")
 			}
 		}
 
@@ -210,5 +495,468 @@ func outputTable(orgs []api.Organization) {
 		table.AddRow(org.ID, org.Name, plan, created)
 	}
 
-	fmt.Print(table.Render())
+	return table
+}
+
+func runOrgAlerts(outputFormat string, orgID string, severityFilter string, env string, concurrency int) {
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	if concurrency < 1 {
+		failf(exitUsage, "--concurrency must be at least 1")
+		return
+	}
+	if !validSeverityFilter(severityFilter) {
+		return
+	}
+
+	env = resolveEnv(env, orgID, cfg)
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	spinner := startSpinner("Fetching scans...")
+	results, err := client.CollectOrgAlerts(orgID, &api.CollectAlertsOptions{
+		Concurrency: concurrency,
+		Envs:        splitCommaList(env),
+		Progress:    alertFetchProgress(spinner),
+	})
+	spinner.Stop()
+	if err != nil {
+		if !reportTimeout(err, "no scans were listed") {
+			failf(exitCodeFor(err), "Failed to collect organization alerts: %v", err)
+		}
+		return
+	}
+
+	// Report partial results when the deadline cut collection short
+	timedOut := 0
+	for _, result := range results {
+		if errors.Is(result.Err, context.DeadlineExceeded) {
+			timedOut++
+		}
+	}
+	if timedOut > 0 {
+		reportTimeout(context.DeadlineExceeded, fmt.Sprintf("collected alerts for %d of %d scans", len(results)-timedOut, len(results)))
+	}
+
+	// Order results by application and environment for stable output
+	scanIDs := make([]string, 0, len(results))
+	for scanID := range results {
+		scanIDs = append(scanIDs, scanID)
+	}
+	sort.Slice(scanIDs, func(i, j int) bool {
+		a, b := results[scanIDs[i]].Scan.Scan, results[scanIDs[j]].Scan.Scan
+		if a.ApplicationName != b.ApplicationName {
+			return a.ApplicationName < b.ApplicationName
+		}
+		if a.Env != b.Env {
+			return a.Env < b.Env
+		}
+		return a.ID < b.ID
+	})
+
+	// Apply severity filter if specified; scans whose alerts couldn't be fetched
+	// are summarized after the results
+	failures := newPartialError("scans", len(scanIDs))
+	collected := make([]api.OrgScanAlerts, 0, len(scanIDs))
+	for _, scanID := range scanIDs {
+		result := results[scanID]
+		if result.Err != nil {
+			failures.add(scanID, result.Scan.Scan.ApplicationName, result.Err)
+		}
+		result.Alerts = filterAlertsBySeverity(result.Alerts, severityFilter)
+		collected = append(collected, result)
+	}
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputOrgAlertsJSON(collected)
+	case "table":
+		outputOrgAlertsTable(collected)
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+
+	reportPartialFailures(failures, "get alerts")
+}
+
+func outputOrgAlertsJSON(collected []api.OrgScanAlerts) {
+	type scanAlertsOutput struct {
+		ScanID          string          `json:"scanId"`
+		ApplicationID   string          `json:"applicationId"`
+		ApplicationName string          `json:"applicationName"`
+		Env             string          `json:"env,omitempty"`
+		Alerts          []api.ScanAlert `json:"alerts"`
+		Error           string          `json:"error,omitempty"`
+	}
+
+	output := make([]scanAlertsOutput, 0, len(collected))
+	for _, result := range collected {
+		entry := scanAlertsOutput{
+			ScanID:          result.Scan.Scan.ID,
+			ApplicationID:   result.Scan.Scan.ApplicationID,
+			ApplicationName: result.Scan.Scan.ApplicationName,
+			Env:             result.Scan.Scan.Env,
+			Alerts:          result.Alerts,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		if entry.Alerts == nil {
+			entry.Alerts = []api.ScanAlert{}
+		}
+		output = append(output, entry)
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputOrgAlertsTable(collected []api.OrgScanAlerts) {
+	table := format.NewTable("APPLICATION", "ENV", "SCAN ID", "PLUGIN ID", "NAME", "SEVERITY", "URIS")
+	rows := 0
+
+	for _, result := range collected {
+		if result.Err != nil {
+			continue
+		}
+
+		appName := result.Scan.Scan.ApplicationName
+		if appName == "" {
+			appName = "N/A"
+		}
+
+		env := result.Scan.Scan.Env
+		if env == "" {
+			env = "N/A"
+		}
+
+		for _, alert := range result.Alerts {
+			table.AddRow(appName, env, result.Scan.Scan.ID, alert.PluginID, alert.Name, alert.Severity, format.Number(int64(alert.URICount)))
+			rows++
+		}
+	}
+
+	if rows == 0 {
+		fmt.Fprintln(errOut, "No alerts found.")
+	} else {
+		fmt.Fprint(out, renderTable(table))
+	}
+}
+
+// slaPolicy is how many days a finding of each severity may stay open
+type slaPolicy struct {
+	HighDays   int
+	MediumDays int
+}
+
+// days returns the SLA for a severity; only High and Medium findings have one
+func (p slaPolicy) days(severity string) (int, bool) {
+	switch severityRank(severity) {
+	case 0:
+		return p.HighDays, true
+	case 1:
+		return p.MediumDays, true
+	default:
+		return 0, false
+	}
+}
+
+// slaHistory is the examined scan history of one application/environment, oldest
+// first and ending with its latest completed scan
+type slaHistory struct {
+	Scans []api.ApplicationScanResult
+	// Truncated is set when older scans exist beyond the examined history
+	Truncated bool
+}
+
+// slaFinding is an open finding in an environment's latest scan and how long it has been open
+type slaFinding struct {
+	ApplicationID      string `json:"applicationId"`
+	ApplicationName    string `json:"applicationName"`
+	Env                string `json:"env,omitempty"`
+	ScanID             string `json:"scanId"`
+	PluginID           string `json:"pluginId"`
+	Name               string `json:"name"`
+	Severity           string `json:"severity"`
+	FirstSeenScanID    string `json:"firstSeenScanId"`
+	FirstSeenTimestamp string `json:"firstSeenTimestamp"`
+	DaysOpen           int    `json:"daysOpen"`
+	// DaysOpenIsMinimum is set when the finding is present in every examined scan
+	// and older scans weren't examined
+	DaysOpenIsMinimum bool `json:"daysOpenIsMinimum,omitempty"`
+	SLADays           int  `json:"slaDays"`
+	Overdue           bool `json:"overdue"`
+}
+
+func runOrgSLA(outputFormat string, orgID string, env string, policy slaPolicy, historyLimit int, concurrency int) {
+	if policy.HighDays < 1 || policy.MediumDays < 1 {
+		failf(exitUsage, "--high-days and --medium-days must be at least 1")
+		return
+	}
+	if historyLimit < 0 {
+		failf(exitUsage, "--history cannot be negative")
+		return
+	}
+	if concurrency < 1 {
+		failf(exitUsage, "--concurrency must be at least 1")
+		return
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+	env = resolveEnv(env, orgID, cfg)
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	spinner := startSpinner("Fetching scans...")
+	scanResults, err := client.ListOrganizationScans(orgID)
+	if err != nil {
+		spinner.Stop()
+		if !reportTimeout(err, "no scans were listed") {
+			failf(exitCodeFor(err), "Failed to list scans: %v", err)
+		}
+		return
+	}
+
+	histories := slaScanHistories(scanResults, env, historyLimit)
+	scans := []api.ApplicationScanResult{}
+	for _, history := range histories {
+		scans = append(scans, history.Scans...)
+	}
+	results := client.FetchScanAlerts(scans, concurrency, alertFetchProgress(spinner))
+	spinner.Stop()
+
+	// Scans whose alerts couldn't be fetched are left out of their history
+	alertsByScan := make(map[string][]api.ScanAlert, len(results))
+	failed, timedOut := 0, 0
+	for _, scan := range scans {
+		result := results[scan.Scan.ID]
+		switch {
+		case errors.Is(result.Err, context.DeadlineExceeded):
+			timedOut++
+		case result.Err != nil:
+			failed++
+			fmt.Fprintf(errOut, "⚠️  Failed to get alerts for scan %s (%s): %v\n", scan.Scan.ID, scan.Scan.ApplicationName, result.Err)
+		default:
+			alertsByScan[scan.Scan.ID] = result.Alerts
+		}
+	}
+	if timedOut > 0 {
+		reportTimeout(context.DeadlineExceeded, fmt.Sprintf("fetched alerts for %d of %d scans", len(scans)-timedOut-failed, len(scans)))
+	}
+
+	now := time.Now()
+	findings := []slaFinding{}
+	for _, history := range histories {
+		findings = append(findings, buildSLAFindings(history, alertsByScan, policy, now)...)
+	}
+	sortSLAFindings(findings)
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputSLAFindingsJSON(findings)
+	case "table":
+		outputSLAFindingsTable(findings)
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+// slaScanHistories returns, for the latest completed scan of each application and
+// environment, that environment's completed scans limited to the newest limit
+// (0 = all). env, a comma-separated list, restricts the environments included.
+func slaScanHistories(scanResults []api.ApplicationScanResult, env string, limit int) []slaHistory {
+	histories := []slaHistory{}
+	for _, latest := range api.LatestCompletedScans(scanResults) {
+		if env != "" && !envListContains(env, latest.Scan.Env) {
+			continue
+		}
+
+		scans := []api.ApplicationScanResult{}
+		for _, scan := range appScanHistory(scanResults, latest.Scan.ApplicationID, "") {
+			if scan.Scan.Env == latest.Scan.Env {
+				scans = append(scans, scan)
+			}
+		}
+
+		history := slaHistory{Scans: scans}
+		if limit > 0 && len(scans) > limit {
+			history.Scans = scans[len(scans)-limit:]
+			history.Truncated = true
+		}
+		histories = append(histories, history)
+	}
+	return histories
+}
+
+// buildSLAFindings reports the High and Medium findings of the history's latest
+// scan. Each finding's age runs from the oldest scan of the unbroken run of scans,
+// ending with the latest, that include its plugin. Scans missing from alertsByScan
+// are skipped; nothing is reported if the latest scan is missing.
+func buildSLAFindings(history slaHistory, alertsByScan map[string][]api.ScanAlert, policy slaPolicy, now time.Time) []slaFinding {
+	scans := []api.ApplicationScanResult{}
+	plugins := make(map[string]map[string]bool)
+	for _, scan := range history.Scans {
+		alerts, ok := alertsByScan[scan.Scan.ID]
+		if !ok {
+			continue
+		}
+		scans = append(scans, scan)
+		plugins[scan.Scan.ID] = make(map[string]bool, len(alerts))
+		for _, alert := range alerts {
+			plugins[scan.Scan.ID][alert.PluginID] = true
+		}
+	}
+	if len(scans) == 0 || scans[len(scans)-1].Scan.ID != history.Scans[len(history.Scans)-1].Scan.ID {
+		return nil
+	}
+	latest := scans[len(scans)-1]
+
+	findings := []slaFinding{}
+	for _, summary := range summarizeAlerts(alertsByScan[latest.Scan.ID]) {
+		slaDays, ok := policy.days(summary.Severity)
+		if !ok {
+			continue
+		}
+
+		first := len(scans) - 1
+		for first > 0 && plugins[scans[first-1].Scan.ID][summary.PluginID] {
+			first--
+		}
+		firstSeen := scans[first].Scan
+
+		daysOpen := 0
+		if ts, err := strconv.ParseInt(firstSeen.Timestamp, 10, 64); err == nil {
+			if age := now.Sub(time.UnixMilli(ts)); age > 0 {
+				daysOpen = int(age.Hours() / 24)
+			}
+		}
+
+		findings = append(findings, slaFinding{
+			ApplicationID:      latest.Scan.ApplicationID,
+			ApplicationName:    latest.Scan.ApplicationName,
+			Env:                latest.Scan.Env,
+			ScanID:             latest.Scan.ID,
+			PluginID:           summary.PluginID,
+			Name:               summary.Name,
+			Severity:           summary.Severity,
+			FirstSeenScanID:    firstSeen.ID,
+			FirstSeenTimestamp: firstSeen.Timestamp,
+			DaysOpen:           daysOpen,
+			DaysOpenIsMinimum:  first == 0 && history.Truncated,
+			SLADays:            slaDays,
+			Overdue:            daysOpen > slaDays,
+		})
+	}
+	return findings
+}
+
+// sortSLAFindings orders overdue findings first, then the oldest, most severe findings
+func sortSLAFindings(findings []slaFinding) {
+	sort.SliceStable(findings, func(a, b int) bool {
+		fa, fb := findings[a], findings[b]
+		if fa.Overdue != fb.Overdue {
+			return fa.Overdue
+		}
+		if fa.DaysOpen != fb.DaysOpen {
+			return fa.DaysOpen > fb.DaysOpen
+		}
+		if rankA, rankB := severityRank(fa.Severity), severityRank(fb.Severity); rankA != rankB {
+			return rankA < rankB
+		}
+		if fa.ApplicationName != fb.ApplicationName {
+			return fa.ApplicationName < fb.ApplicationName
+		}
+		if fa.Env != fb.Env {
+			return fa.Env < fb.Env
+		}
+		return fa.PluginID < fb.PluginID
+	})
+}
+
+func outputSLAFindingsJSON(findings []slaFinding) {
+	data, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputSLAFindingsTable(findings []slaFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(errOut, "No open High or Medium findings.")
+		return
+	}
+
+	table := format.NewTable("APPLICATION", "ENV", "PLUGIN ID", "NAME", "SEVERITY", "FIRST SEEN", "DAYS OPEN", "SLA DAYS", "STATUS")
+	overdue := 0
+	for _, finding := range findings {
+		appName := finding.ApplicationName
+		if appName == "" {
+			appName = "N/A"
+		}
+
+		env := finding.Env
+		if env == "" {
+			env = "N/A"
+		}
+
+		firstSeen := "N/A"
+		if ts, err := strconv.ParseInt(finding.FirstSeenTimestamp, 10, 64); err == nil {
+			firstSeen = format.FormatTimestamp(ts, displayLocation)
+		}
+
+		daysOpen := strconv.Itoa(finding.DaysOpen)
+		if finding.DaysOpenIsMinimum {
+			daysOpen += "+"
+		}
+
+		status := "OK"
+		if finding.Overdue {
+			status = "OVERDUE"
+			overdue++
+		}
+
+		table.AddRow(appName, env, finding.PluginID, finding.Name, finding.Severity, firstSeen, daysOpen, strconv.Itoa(finding.SLADays), status)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+	fmt.Fprintf(errOut, "%d of %d open findings are past their SLA.\n", overdue, len(findings))
 }
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+type OrgCommandTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OrgCommandTestSuite) TestOrgCommand_Structure() {
+	subcommands := []string{}
+	for _, cmd := range orgCmd.Commands() {
+		subcommands = append(subcommands, cmd.Use)
+	}
+
+	assert.Contains(suite.T(), subcommands, "set <org-id>")
+	assert.Contains(suite.T(), subcommands, "switch")
+	assert.Contains(suite.T(), subcommands, "set-env <env>")
+	assert.Contains(suite.T(), subcommands, "alerts")
+	assert.Contains(suite.T(), subcommands, "sla")
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSetEnvFlags() {
+	assert.NotNil(suite.T(), orgSetEnvCmd.Flags().Lookup("org"))
+	assert.NotNil(suite.T(), orgSetEnvCmd.Flags().Lookup("clear"))
+	assert.Error(suite.T(), orgSetEnvCmd.Args(orgSetEnvCmd, []string{"a", "b"}))
+
+	assert.NotNil(suite.T(), orgAlertsCmd.Flags().Lookup("env"))
+}
+
+func (suite *OrgCommandTestSuite) TestRunOrgSetEnv_Validation() {
+	_, stderr := captureOutput(suite.T(), func() { runOrgSetEnv("", "org-1", false) })
+	assert.Contains(suite.T(), stderr, "--clear")
+
+	_, stderr = captureOutput(suite.T(), func() { runOrgSetEnv("Production", "org-1", true) })
+	assert.Contains(suite.T(), stderr, "--clear does not take an environment")
+}
+
+func (suite *OrgCommandTestSuite) TestRunOrgSLA_Validation() {
+	_, stderr := captureOutput(suite.T(), func() { runOrgSLA("table", "", "", slaPolicy{HighDays: 0, MediumDays: 90}, 20, 4) })
+	assert.Contains(suite.T(), stderr, "--high-days and --medium-days must be at least 1")
+
+	_, stderr = captureOutput(suite.T(), func() { runOrgSLA("table", "", "", slaPolicy{HighDays: 30, MediumDays: 90}, -1, 4) })
+	assert.Contains(suite.T(), stderr, "--history cannot be negative")
+}
+
+func (suite *OrgCommandTestSuite) TestSLAScanHistories() {
+	scan := func(id, app, env, status string, ts int) api.ApplicationScanResult {
+		return api.ApplicationScanResult{Scan: api.Scan{ID: id, ApplicationID: app, Env: env, Status: status, Timestamp: fmt.Sprint(ts)}}
+	}
+	scans := []api.ApplicationScanResult{
+		scan("p3", "app-1", "Production", "COMPLETED", 3000),
+		scan("d1", "app-1", "Development", "COMPLETED", 1500),
+		scan("p1", "app-1", "Production", "COMPLETED", 1000),
+		scan("p4", "app-1", "Production", "STARTED", 4000),
+		scan("p2", "app-1", "Production", "COMPLETED", 2000),
+	}
+	ids := func(history slaHistory) []string {
+		result := []string{}
+		for _, scan := range history.Scans {
+			result = append(result, scan.Scan.ID)
+		}
+		return result
+	}
+
+	histories := slaScanHistories(scans, "", 2)
+	require.Len(suite.T(), histories, 2)
+	assert.Equal(suite.T(), []string{"p2", "p3"}, ids(histories[0]))
+	assert.True(suite.T(), histories[0].Truncated)
+	assert.Equal(suite.T(), []string{"d1"}, ids(histories[1]))
+	assert.False(suite.T(), histories[1].Truncated)
+
+	histories = slaScanHistories(scans, "production", 0)
+	require.Len(suite.T(), histories, 1)
+	assert.Equal(suite.T(), []string{"p1", "p2", "p3"}, ids(histories[0]))
+	assert.False(suite.T(), histories[0].Truncated)
+}
+
+func (suite *OrgCommandTestSuite) TestBuildSLAFindings() {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) string {
+		return fmt.Sprint(now.Add(-time.Duration(days) * 24 * time.Hour).UnixMilli())
+	}
+	scan := func(id string, days int) api.ApplicationScanResult {
+		return api.ApplicationScanResult{Scan: api.Scan{ID: id, ApplicationID: "app-1", ApplicationName: "Billing", Env: "Production", Timestamp: daysAgo(days)}}
+	}
+	alert := func(pluginID, severity string) api.ScanAlert {
+		return api.ScanAlert{PluginID: pluginID, Name: "Plugin " + pluginID, Severity: severity}
+	}
+
+	history := slaHistory{Scans: []api.ApplicationScanResult{scan("s1", 100), scan("s2", 60), scan("s3", 40), scan("s4", 10)}, Truncated: true}
+	alertsByScan := map[string][]api.ScanAlert{
+		"s1": {alert("A", "High"), alert("B", "High")},
+		"s2": {alert("A", "High")},
+		"s3": {alert("A", "High"), alert("B", "High"), alert("C", "Medium")},
+		"s4": {alert("A", "High"), alert("B", "High"), alert("C", "Medium"), alert("D", "Low"), alert("E", "High")},
+	}
+	policy := slaPolicy{HighDays: 30, MediumDays: 90}
+
+	byPlugin := func(findings []slaFinding) map[string]slaFinding {
+		result := make(map[string]slaFinding, len(findings))
+		for _, finding := range findings {
+			result[finding.PluginID] = finding
+		}
+		return result
+	}
+
+	findings := byPlugin(buildSLAFindings(history, alertsByScan, policy, now))
+	require.Len(suite.T(), findings, 4, "Low findings have no SLA")
+
+	// Present in every examined scan, so the age is only a lower bound
+	assert.Equal(suite.T(), "s1", findings["A"].FirstSeenScanID)
+	assert.Equal(suite.T(), 100, findings["A"].DaysOpen)
+	assert.True(suite.T(), findings["A"].DaysOpenIsMinimum)
+	assert.True(suite.T(), findings["A"].Overdue)
+
+	// Resolved in s2, so the current run starts at s3
+	assert.Equal(suite.T(), "s3", findings["B"].FirstSeenScanID)
+	assert.Equal(suite.T(), 40, findings["B"].DaysOpen)
+	assert.False(suite.T(), findings["B"].DaysOpenIsMinimum)
+	assert.True(suite.T(), findings["B"].Overdue)
+
+	assert.Equal(suite.T(), 90, findings["C"].SLADays)
+	assert.False(suite.T(), findings["C"].Overdue)
+
+	assert.Equal(suite.T(), "s4", findings["E"].FirstSeenScanID)
+	assert.Equal(suite.T(), 10, findings["E"].DaysOpen)
+	assert.Equal(suite.T(), "s4", findings["E"].ScanID)
+
+	// A scan whose alerts couldn't be fetched is skipped rather than breaking the run
+	delete(alertsByScan, "s2")
+	findings = byPlugin(buildSLAFindings(history, alertsByScan, policy, now))
+	assert.Equal(suite.T(), "s1", findings["B"].FirstSeenScanID)
+
+	// Nothing is reported without the latest scan's alerts
+	delete(alertsByScan, "s4")
+	assert.Empty(suite.T(), buildSLAFindings(history, alertsByScan, policy, now))
+}
+
+func (suite *OrgCommandTestSuite) TestOutputSLAFindingsTable() {
+	findings := []slaFinding{
+		{ApplicationName: "Billing", Env: "Production", PluginID: "C", Name: "Plugin C", Severity: "Medium", DaysOpen: 40, SLADays: 90},
+		{ApplicationName: "Billing", Env: "Production", PluginID: "A", Name: "Plugin A", Severity: "High", DaysOpen: 45, DaysOpenIsMinimum: true, SLADays: 30, Overdue: true},
+	}
+	sortSLAFindings(findings)
+	assert.Equal(suite.T(), "A", findings[0].PluginID, "overdue findings sort first")
+
+	stdout, stderr := captureOutput(suite.T(), func() { outputSLAFindingsTable(findings) })
+	lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+	require.Len(suite.T(), lines, 4)
+	assert.Contains(suite.T(), lines[2], "45+")
+	assert.Contains(suite.T(), lines[2], "OVERDUE")
+	assert.Contains(suite.T(), lines[3], "OK")
+	assert.Contains(suite.T(), stderr, "1 of 2 open findings are past their SLA.")
+}
+
+func (suite *OrgCommandTestSuite) TestOrgAlerts_PartialFailure() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T(), "/api/v1/scan/org-a-app-2/alerts")
+
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runOrgAlerts("table", "org-a", "", "", 2)
+	})
+
+	assert.Contains(suite.T(), stdout, "org-a-app-1")
+	assert.NotContains(suite.T(), stdout, "org-a-app-2")
+	assert.Contains(suite.T(), stderr, "⚠️  Failed to get alerts for 1 of 2 scans:")
+	assert.Contains(suite.T(), stderr, "app-2 (org-a-app-2)")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgAlerts_AllFailed() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T(), "/api/v1/scan/org-a-app-1/alerts", "/api/v1/scan/org-a-app-2/alerts")
+
+	_, stderr := captureOutput(suite.T(), func() {
+		runOrgAlerts("json", "org-a", "", "", 2)
+	})
+
+	assert.Contains(suite.T(), stderr, "❌ Failed to get alerts for all 2 scans:")
+	assert.Equal(suite.T(), exitAuth, commandExitCode)
+}
+
+// switchOrgs are the organizations offered by org switch in tests
+var switchOrgs = []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}
+
+func (suite *OrgCommandTestSuite) TestOrgSwitch_Picks() {
+	resetExitCode(suite.T())
+	cfg := &config.Config{APIKey: "key", OrgID: "org-a"}
+	stubConfigFile(suite.T(), cfg)
+	stubOrganizations(suite.T(), switchOrgs, nil)
+	saved := stubPrompt(suite.T(), "3\n2\n")
+
+	stdout, stderr := captureOutput(suite.T(), func() { runOrgSwitch("text") })
+	assert.Empty(suite.T(), stdout)
+	assert.Contains(suite.T(), stderr, "1) Alpha (org-a) [current]")
+	assert.Contains(suite.T(), stderr, "2) Beta (org-b)\n")
+	assert.Contains(suite.T(), stderr, "Please enter a number between 1 and 2")
+	assert.Contains(suite.T(), stderr, "✅ Default organization set to: Beta (org-b)")
+	require.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), "org-b", (*saved)[0].OrgID)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSwitch_KeepsCurrentOrCancels() {
+	resetExitCode(suite.T())
+	stubConfigFile(suite.T(), &config.Config{APIKey: "key", OrgID: "org-a"})
+	stubOrganizations(suite.T(), switchOrgs, nil)
+	saved := stubPrompt(suite.T(), "1\n")
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSwitch("text") })
+	assert.Contains(suite.T(), stderr, "Alpha (org-a) is already your default organization.")
+	assert.Empty(suite.T(), *saved)
+
+	stubPrompt(suite.T(), "")
+	_, stderr = captureOutput(suite.T(), func() { runOrgSwitch("text") })
+	assert.Contains(suite.T(), stderr, "No organization selected; the default is unchanged.")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSwitch_JSONListsChoices() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "key", OrgID: "org-b"})
+	stubOrganizations(suite.T(), switchOrgs, nil)
+
+	stdout, stderr := captureOutput(suite.T(), func() { runOrgSwitch("json") })
+	assert.JSONEq(suite.T(), `[
+		{"index": 1, "id": "org-a", "name": "Alpha", "current": false},
+		{"index": 2, "id": "org-b", "name": "Beta", "current": true}
+	]`, stdout)
+	assert.Empty(suite.T(), stderr)
+	assert.Empty(suite.T(), *saved)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSwitch_NeedsTerminal() {
+	resetExitCode(suite.T())
+	stubConfigFile(suite.T(), &config.Config{APIKey: "key"})
+	stubOrganizations(suite.T(), switchOrgs, nil)
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSwitch("text") })
+	assert.Contains(suite.T(), stderr, "Choosing an organization needs a terminal")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSet_ValidatesMembership() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "key", OrgID: "org-a"})
+	stubOrganizations(suite.T(), switchOrgs, nil)
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSet("org-b", false) })
+	assert.Contains(suite.T(), stderr, "✅ Default organization ID set to: Beta (org-b)")
+	require.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), "org-b", (*saved)[0].OrgID)
+
+	// Setting the current default again leaves the config alone
+	_, stderr = captureOutput(suite.T(), func() { runOrgSet("org-b", false) })
+	assert.Contains(suite.T(), stderr, "Beta (org-b) is already your default organization.")
+	assert.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSet_RejectsUnknownOrg() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "key", OrgID: "org-a"})
+	stubOrganizations(suite.T(), switchOrgs, nil)
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSet("org-c", false) })
+	assert.Contains(suite.T(), stderr, "❌ You don't belong to organization org-c. Use --force to set it anyway.")
+	assert.Contains(suite.T(), stderr, "Available organizations:\n  org-a  Alpha\n  org-b  Beta\n")
+	assert.Empty(suite.T(), *saved)
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSet_NotSavedWhileReplaying() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "hawk.real", OrgID: "org-a"})
+	snapshotDir = suite.T().TempDir()
+	suite.T().Cleanup(func() { snapshotDir = "" })
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSet("org-b", true) })
+	assert.Contains(suite.T(), stderr, "isn't saved while replaying a snapshot")
+	assert.Empty(suite.T(), *saved, "placeholder credentials must not replace the real ones")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *OrgCommandTestSuite) TestOrgSet_Force() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "key", OrgID: "org-a"})
+	stubOrganizations(suite.T(), nil, errors.New("should not be called"))
+
+	_, stderr := captureOutput(suite.T(), func() { runOrgSet("org-c", true) })
+	assert.Contains(suite.T(), stderr, "✅ Default organization ID set to: org-c")
+	require.Len(suite.T(), *saved, 1)
+	assert.Equal(suite.T(), "org-c", (*saved)[0].OrgID)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func TestOrgCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(OrgCommandTestSuite))
+}
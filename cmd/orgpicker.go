@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+// pickOrganization asks the user to choose one of orgs by number, then offers to
+// save the choice as the default organization. An empty answer or end of input
+// cancels the selection.
+func pickOrganization(cfg *config.Config, orgs []api.Organization) (string, bool) {
+	reader := bufio.NewReader(in)
+
+	fmt.Fprintln(errOut, "No organization specified. Choose one of your organizations:")
+	selected, ok := chooseOrganization(reader, orgs, "")
+	if !ok {
+		failf(exitUsage, "No organization selected. Use --org flag or set a default with 'hawkop org set <org-id>'")
+		return "", false
+	}
+
+	fmt.Fprintf(errOut, "Save %s as your default organization? [y/N]: ", selected.Name)
+	answer, _ := readAnswer(reader)
+	if isYes(answer) {
+		cfg.SetOrgID(selected.ID)
+		if err := saveConfig(cfg); err != nil {
+			fmt.Fprintf(errOut, "⚠️  Failed to save default organization: %v\n", err)
+		} else {
+			fmt.Fprintf(errOut, "✅ Default organization ID set to: %s\n", selected.ID)
+		}
+	}
+
+	return selected.ID, true
+}
+
+// chooseOrganization lists orgs by number, marking currentID, and reads the number
+// of one until the answer is valid. It returns false if the user gives an empty
+// answer or input ends.
+func chooseOrganization(reader *bufio.Reader, orgs []api.Organization, currentID string) (api.Organization, bool) {
+	for i, org := range orgs {
+		marker := ""
+		if org.ID == currentID {
+			marker = " [current]"
+		}
+		fmt.Fprintf(errOut, "  %d) %s (%s)%s\n", i+1, org.Name, org.ID, marker)
+	}
+
+	for {
+		fmt.Fprintf(errOut, "Organization [1-%d]: ", len(orgs))
+		answer, err := readAnswer(reader)
+		if err != nil || answer == "" {
+			return api.Organization{}, false
+		}
+
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(orgs) {
+			return orgs[n-1], true
+		}
+		fmt.Fprintf(errOut, "Please enter a number between 1 and %d.\n", len(orgs))
+	}
+}
+
+// readAnswer reads one line of input, trimmed. A final line without a newline
+// is still returned; io.EOF is only reported when nothing was read.
+func readAnswer(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
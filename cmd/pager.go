@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultPager is used when neither HAWKOP_PAGER nor PAGER is set
+const defaultPager = "less"
+
+// usePager pipes standard output through a pager when it is a terminal (--pager)
+var usePager bool
+
+// activePager is the pager receiving out while a command runs, if any
+var activePager *pagerProcess
+
+// pagerProcess is a running pager fed through its standard input
+type pagerProcess struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// pagerCommand returns the pager to run, preferring HAWKOP_PAGER over PAGER
+func pagerCommand() string {
+	if pager := strings.TrimSpace(os.Getenv("HAWKOP_PAGER")); pager != "" {
+		return pager
+	}
+	if pager := strings.TrimSpace(os.Getenv("PAGER")); pager != "" {
+		return pager
+	}
+	return defaultPager
+}
+
+// openPager starts the pager command writing to w. The command is split on
+// whitespace rather than run through a shell.
+func openPager(command string, w io.Writer) (*pagerProcess, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no pager command")
+	}
+
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("pager %q not found", fields[0])
+	}
+
+	pager := exec.Command(path, fields[1:]...)
+	pager.Stdout = w
+	pager.Stderr = os.Stderr
+
+	// Like git, default less to keep colors (R), exit on short output (F), and
+	// leave the output on screen (X)
+	pager.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		pager.Env = append(pager.Env, "LESS=FRX")
+	}
+
+	stdin, err := pager.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := pager.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start pager %q: %w", fields[0], err)
+	}
+
+	return &pagerProcess{cmd: pager, stdin: stdin}, nil
+}
+
+// close ends the pager's input and waits for the user to exit it
+func (p *pagerProcess) close() {
+	_ = p.stdin.Close()
+	_ = p.cmd.Wait()
+}
+
+// startPager redirects out through the pager for --pager. Paging is skipped when
+// stdout is not a terminal, for --watch, or when the pager can't be started.
+func startPager(cmd *cobra.Command) {
+	if !usePager || !stdoutIsTerminal() {
+		return
+	}
+	if watch := cmd.Flags().Lookup("watch"); watch != nil && watch.Changed {
+		return
+	}
+
+	pager, err := openPager(pagerCommand(), os.Stdout)
+	if err != nil {
+		fmt.Fprintf(errOut, "⚠️  %v; showing output without a pager\n", err)
+		return
+	}
+
+	activePager = pager
+	out = pager.stdin
+}
+
+// stopPager flushes paged output and restores out
+func stopPager() {
+	if activePager == nil {
+		return
+	}
+	activePager.close()
+	activePager = nil
+	out = os.Stdout
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerCommand_Precedence(t *testing.T) {
+	t.Setenv("HAWKOP_PAGER", "")
+	t.Setenv("PAGER", "")
+	assert.Equal(t, "less", pagerCommand())
+
+	t.Setenv("PAGER", "more")
+	assert.Equal(t, "more", pagerCommand())
+
+	t.Setenv("HAWKOP_PAGER", "less -S")
+	assert.Equal(t, "less -S", pagerCommand())
+}
+
+func TestOpenPager_PipesOutput(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat not available")
+	}
+
+	var buf bytes.Buffer
+	pager, err := openPager("cat", &buf)
+	require.NoError(t, err)
+
+	_, err = pager.stdin.Write([]byte("ID  NAME\n"))
+	require.NoError(t, err)
+	pager.close()
+
+	assert.Equal(t, "ID  NAME\n", buf.String())
+}
+
+func TestOpenPager_Unavailable(t *testing.T) {
+	_, err := openPager("hawkop-no-such-pager", &bytes.Buffer{})
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = openPager("  ", &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestStartPager_SkippedWithoutTerminal(t *testing.T) {
+	if stdoutIsTerminal() {
+		t.Skip("stdout is a terminal")
+	}
+	usePager = true
+	t.Cleanup(func() { usePager = false })
+
+	before := out
+	startPager(&cobra.Command{})
+	assert.Nil(t, activePager)
+	assert.Equal(t, before, out)
+	stopPager()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// itemFailure records why one item of a multi-item operation failed
+type itemFailure struct {
+	ID   string
+	Name string
+	Err  error
+}
+
+// label names the item as "Name (ID)", or just the ID when it has no name
+func (f itemFailure) label() string {
+	if f.Name == "" || f.Name == f.ID {
+		return f.ID
+	}
+	return fmt.Sprintf("%s (%s)", f.Name, f.ID)
+}
+
+// partialError collects the failures of an operation that works through many
+// items, such as every organization for --all-orgs, and carries on past each one.
+// It unwraps to the individual errors, so exitCodeFor and the api predicates see
+// through it.
+type partialError struct {
+	Items    string // plural noun for the items, e.g. "organizations"
+	Total    int
+	Failures []itemFailure
+}
+
+// newPartialError starts collecting failures among total items
+func newPartialError(items string, total int) *partialError {
+	return &partialError{Items: items, Total: total}
+}
+
+// add records that the item with the given ID and name failed with err
+func (e *partialError) add(id, name string, err error) {
+	e.Failures = append(e.Failures, itemFailure{ID: id, Name: name, Err: err})
+}
+
+func (e *partialError) Error() string {
+	details := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		details[i] = fmt.Sprintf("%s: %v", failure.label(), failure.Err)
+	}
+	return fmt.Sprintf("failed for %d of %d %s: %s", len(e.Failures), e.Total, e.Items, strings.Join(details, "; "))
+}
+
+func (e *partialError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// allFailed reports whether no item succeeded
+func (e *partialError) allFailed() bool {
+	return e.Total > 0 && len(e.Failures) >= e.Total
+}
+
+// errOrNil returns e when anything failed, and nil otherwise
+func (e *partialError) errOrNil() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e
+}
+
+// reportPartialFailures prints a summary of the failed items after the results.
+// Failures only affect the exit code when every item failed; otherwise the
+// results that did come back are the command's answer. A nil e reports nothing.
+func reportPartialFailures(e *partialError, action string) {
+	if e == nil || len(e.Failures) == 0 {
+		return
+	}
+
+	if e.allFailed() {
+		failf(exitCodeFor(e), "Failed to %s for all %d %s:", action, e.Total, e.Items)
+	} else {
+		fmt.Fprintf(errOut, "⚠️  Failed to %s for %d of %d %s:\n", action, len(e.Failures), e.Total, e.Items)
+	}
+	for _, failure := range e.Failures {
+		fmt.Fprintf(errOut, "   - %s: %v\n", failure.label(), failure.Err)
+	}
+}
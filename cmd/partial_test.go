@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/testutil"
+)
+
+// useMultiOrgServer points commands at a server where the user belongs to org-a
+// ("Team A") and org-b ("Team B"). Each organization has a scan of its own, and
+// requests to a path in forbidden get 403 Forbidden.
+func useMultiOrgServer(t *testing.T, forbidden ...string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		for _, path := range forbidden {
+			if r.URL.Path == path {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/user":
+			user := api.UserResponse{}
+			for _, org := range []api.Organization{{ID: "org-a", Name: "Team A"}, {ID: "org-b", Name: "Team B"}} {
+				user.User.External.Organizations = append(user.User.External.Organizations, api.OrganizationMembership{Organization: org})
+			}
+			_ = json.NewEncoder(w).Encode(user)
+		case strings.HasSuffix(r.URL.Path, "/alerts"):
+			alerts := api.ScanAlertsResponse{}
+			alerts.ApplicationScanResults = append(alerts.ApplicationScanResults, api.ScanAlertsResult{
+				ApplicationAlerts: []api.ScanAlert{{PluginID: "10001", Name: "SQL Injection", Severity: "High"}},
+			})
+			_ = json.NewEncoder(w).Encode(alerts)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/scan/"):
+			// Each organization has one scan per application, named after the org
+			org := strings.TrimPrefix(r.URL.Path, "/api/v1/scan/")
+			scans := api.OrganizationScansResponse{}
+			for _, app := range []string{"app-1", "app-2"} {
+				scans.ApplicationScanResults = append(scans.ApplicationScanResults, api.ApplicationScanResult{
+					Scan: api.Scan{ID: org + "-" + app, ApplicationID: app, ApplicationName: app, Env: "Development", Status: "COMPLETED", Timestamp: "1756596062834"},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(scans)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := *testutil.NewConfig(server.URL)
+	origLoad, origClient := loadConfigFile, newClient
+	loadConfigFile = func() (*config.Config, error) {
+		c := cfg
+		return &c, nil
+	}
+	newClient = func(cfg *config.Config) *api.Client {
+		client := api.NewClient(cfg)
+		client.SetBaseURL(server.URL)
+		return client
+	}
+	t.Cleanup(func() { loadConfigFile, newClient = origLoad, origClient })
+}
+
+func TestPartialError(t *testing.T) {
+	failures := newPartialError("organizations", 3)
+	assert.NoError(t, failures.errOrNil())
+
+	forbidden := &api.APIError{StatusCode: http.StatusForbidden}
+	failures.add("org-b", "Team B", forbidden)
+	failures.add("org-c", "", errors.New("boom"))
+
+	err := failures.errOrNil()
+	assert.Error(t, err)
+	assert.False(t, failures.allFailed())
+	assert.Contains(t, err.Error(), "failed for 2 of 3 organizations")
+	assert.Contains(t, err.Error(), "Team B (org-b)")
+	assert.Contains(t, err.Error(), "org-c: boom")
+
+	// The individual errors stay reachable through the multi-error
+	var apiErr *api.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, api.IsAuthError(err))
+}
+
+func TestReportPartialFailures(t *testing.T) {
+	t.Run("some failed", func(t *testing.T) {
+		resetExitCode(t)
+		failures := newPartialError("organizations", 2)
+		failures.add("org-b", "Team B", &api.APIError{StatusCode: http.StatusForbidden})
+
+		_, stderr := captureOutput(t, func() { reportPartialFailures(failures, "list scans") })
+		assert.Contains(t, stderr, "⚠️  Failed to list scans for 1 of 2 organizations:")
+		assert.Contains(t, stderr, "   - Team B (org-b): ")
+		assert.Equal(t, exitOK, commandExitCode)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		resetExitCode(t)
+		failures := newPartialError("organizations", 1)
+		failures.add("org-b", "Team B", &api.APIError{StatusCode: http.StatusForbidden})
+
+		_, stderr := captureOutput(t, func() { reportPartialFailures(failures, "list scans") })
+		assert.Contains(t, stderr, "❌ Failed to list scans for all 1 organizations:")
+		assert.Equal(t, exitAuth, commandExitCode)
+	})
+
+	t.Run("nothing failed", func(t *testing.T) {
+		resetExitCode(t)
+		_, stderr := captureOutput(t, func() { reportPartialFailures(nil, "list scans") })
+		assert.Empty(t, stderr)
+		assert.Equal(t, exitOK, commandExitCode)
+	})
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+// policyCmd represents the policy command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "View scan policies",
+	Long: `View the scan policies available to an organization: the plugins each policy runs
+and how aggressively they test. 'hawkop scan get' shows which policy a scan used.`,
+}
+
+// policyListCmd lists scan policies
+var policyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scan policies in an organization",
+	Long: `List the scan policies available to the organization with their names, IDs, and
+the number of plugins each one runs.
+
+By default, uses your configured default organization.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		limit, _ := cmd.Flags().GetInt("limit")
+		org, _ := cmd.Flags().GetString("org")
+		runPolicyList(format, limit, org)
+	},
+}
+
+// policyShowCmd shows one scan policy and its plugins
+var policyShowCmd = &cobra.Command{
+	Use:   "show <policy>",
+	Short: "Show a scan policy and its plugins",
+	Long: `Show a scan policy's details and the plugins it runs. The policy may be given by
+ID or by name, such as the policy name shown by 'hawkop scan get'.`,
+	Example: `  hawkop policy show DEFAULT
+  hawkop policy show <policy-id> --format json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		runPolicyShow(args[0], format, org)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyListCmd)
+	policyCmd.AddCommand(policyShowCmd)
+
+	// Add flags for policy list command
+	policyListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(policyListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(policyListCmd)
+	policyListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
+	policyListCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+
+	// Add flags for policy show command
+	policyShowCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(policyShowCmd, "format", formatsTableJSON)
+	policyShowCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+}
+
+// fetchPolicies lists the organization's policies, reporting any failure itself
+func fetchPolicies(orgID string) ([]api.Policy, bool) {
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return nil, false
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return nil, false
+	}
+
+	client := newAPIClient(cfg)
+	policies, err := client.ListPolicies(orgID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list policies: %v", err)
+		return nil, false
+	}
+	return policies, true
+}
+
+func runPolicyList(outputFormat string, limit int, orgID string) {
+	policies, ok := fetchPolicies(orgID)
+	if !ok {
+		return
+	}
+
+	// Apply limit if specified
+	if limit > 0 && len(policies) > limit {
+		policies = policies[:limit]
+	}
+
+	if outputTemplated(policies) {
+		return
+	}
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputPolicyJSON(policies)
+	case "table":
+		if len(policies) == 0 {
+			fmt.Fprintln(errOut, "No policies found.")
+			return
+		}
+		fmt.Fprint(out, renderTable(policiesTable(policies)))
+	case "tsv":
+		outputTSV(policiesTable(policies))
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
+	}
+}
+
+func runPolicyShow(policyRef string, outputFormat string, orgID string) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "table" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+
+	policies, ok := fetchPolicies(orgID)
+	if !ok {
+		return
+	}
+
+	policy, found := findPolicy(policies, policyRef)
+	if !found {
+		failf(exitNotFound, "Policy not found: %s. Run 'hawkop policy list' to see available policies.", policyRef)
+		return
+	}
+
+	if outputFormat == "json" {
+		outputPolicyJSON(policy)
+		return
+	}
+	outputPolicyDetails(policy)
+}
+
+// findPolicy returns the policy with the given ID, or else the one whose name or
+// display name matches ref ignoring case
+func findPolicy(policies []api.Policy, ref string) (api.Policy, bool) {
+	for _, policy := range policies {
+		if policy.ID == ref {
+			return policy, true
+		}
+	}
+	for _, policy := range policies {
+		if strings.EqualFold(policy.Name, ref) || (policy.DisplayName != "" && strings.EqualFold(policy.DisplayName, ref)) {
+			return policy, true
+		}
+	}
+	return api.Policy{}, false
+}
+
+// policiesTable lays out policies with their plugin counts for table and tsv output
+func policiesTable(policies []api.Policy) *format.TableWriter {
+	table := format.NewTable("ID", "NAME", "PLUGINS", "SOURCE")
+
+	for _, policy := range policies {
+		name := policy.DisplayName
+		if name == "" {
+			name = policy.Name
+		}
+		if name == "" {
+			name = "N/A"
+		}
+
+		table.AddRow(policy.ID, name, format.Number(int64(len(policy.Plugins))), policySource(policy))
+	}
+
+	return table
+}
+
+// policySource tells organization policies apart from the ones StackHawk provides
+func policySource(policy api.Policy) string {
+	if policy.OrganizationID != "" {
+		return "organization"
+	}
+	return "stackhawk"
+}
+
+func outputPolicyJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+// outputPolicyDetails prints a policy's fields followed by its plugins
+func outputPolicyDetails(policy api.Policy) {
+	details := format.NewTable("FIELD", "VALUE")
+	details.AddRow("Policy ID", policy.ID)
+	details.AddRow("Name", policy.Name)
+	if policy.DisplayName != "" {
+		details.AddRow("Display Name", policy.DisplayName)
+	}
+	if policy.Description != "" {
+		details.AddRow("Description", policy.Description)
+	}
+	details.AddRow("Source", policySource(policy))
+	details.AddRow("Plugins", format.Number(int64(len(policy.Plugins))))
+	fmt.Fprint(out, renderTable(details))
+
+	if len(policy.Plugins) == 0 {
+		return
+	}
+
+	plugins := format.NewTable("PLUGIN ID", "NAME", "THRESHOLD", "STRENGTH")
+	for _, plugin := range policy.Plugins {
+		plugins.AddRow(plugin.PluginID, orNA(plugin.Name), orNA(plugin.AlertThreshold), orNA(plugin.AttackStrength))
+	}
+	fmt.Fprintln(out)
+	fmt.Fprint(out, renderTable(plugins))
+}
+
+// orNA returns value, or N/A when it is empty
+func orNA(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/api"
+)
+
+type PolicyCommandTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PolicyCommandTestSuite) TestPolicyCommand_Structure() {
+	assert.Equal(suite.T(), "policy", policyCmd.Use)
+
+	subcommands := []string{}
+	for _, cmd := range policyCmd.Commands() {
+		subcommands = append(subcommands, cmd.Use)
+	}
+	assert.Contains(suite.T(), subcommands, "list")
+	assert.Contains(suite.T(), subcommands, "show <policy>")
+	assert.Error(suite.T(), policyShowCmd.Args(policyShowCmd, []string{}))
+}
+
+func (suite *PolicyCommandTestSuite) TestPolicyList_Table() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runPolicyList("table", 0, "") })
+	assert.Contains(suite.T(), stdout, "PLUGINS")
+	assert.Regexp(suite.T(), `policy-1\s+Default\s+2\s+stackhawk`, stdout)
+	assert.Regexp(suite.T(), `policy-2\s+api-quick\s+1\s+organization`, stdout)
+}
+
+func (suite *PolicyCommandTestSuite) TestPolicyList_JSON() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runPolicyList("json", 1, "") })
+
+	var policies []api.Policy
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &policies))
+	require.Len(suite.T(), policies, 1)
+	assert.Equal(suite.T(), "DEFAULT", policies[0].Name)
+	assert.Len(suite.T(), policies[0].Plugins, 2)
+}
+
+func (suite *PolicyCommandTestSuite) TestPolicyShow() {
+	useMockAPI(suite.T())
+
+	// Policies can be named the way scan get shows them
+	stdout, _ := captureOutput(suite.T(), func() { runPolicyShow("default", "table", "") })
+	assert.Contains(suite.T(), stdout, "Standard web application scan")
+	assert.Regexp(suite.T(), `40018\s+SQL Injection\s+LOW\s+HIGH`, stdout)
+
+	stdout, _ = captureOutput(suite.T(), func() { runPolicyShow("policy-2", "json", "") })
+	var policy api.Policy
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &policy))
+	assert.Equal(suite.T(), "api-quick", policy.Name)
+
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runPolicyShow("missing", "table", "") })
+	assert.Contains(suite.T(), stderr, "❌ Policy not found: missing")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+}
+
+func (suite *PolicyCommandTestSuite) TestFindPolicy_PrefersID() {
+	policies := []api.Policy{{ID: "a", Name: "b"}, {ID: "b", Name: "c"}}
+
+	policy, ok := findPolicy(policies, "b")
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "b", policy.ID)
+}
+
+func TestPolicyCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyCommandTestSuite))
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+)
+
+var (
+	// errAppNotFound is returned when no application matches a name or ID
+	errAppNotFound = errors.New("application not found")
+	// errOrgNotFound is returned when none of the user's organizations matches a
+	// name or ID
+	errOrgNotFound = errors.New("organization not found")
+)
+
+// resolveApp finds the application a name or ID given on the command line refers
+// to among the organization's applications; see matchApp. Irreversible commands
+// pass exact so a part of a name can't select an application.
+func resolveApp(client *api.Client, orgID, nameOrID string, exact bool) (api.AppApplication, error) {
+	apps, err := client.ListOrganizationApplications(orgID)
+	if err != nil {
+		return api.AppApplication{}, fmt.Errorf("failed to list applications: %w", err)
+	}
+	return matchApp(apps, nameOrID, exact)
+}
+
+// matchApp finds the application nameOrID refers to; see matchNamed
+func matchApp(apps []api.AppApplication, nameOrID string, exact bool) (api.AppApplication, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" {
+		return api.AppApplication{}, newUsageError(errors.New("no application given"))
+	}
+
+	// The API can list an application once per environment
+	var unique []api.AppApplication
+	seen := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		if !seen[app.ApplicationID] {
+			seen[app.ApplicationID] = true
+			unique = append(unique, app)
+		}
+	}
+
+	return matchNamed(unique, nameOrID, exact, "applications", errAppNotFound,
+		func(app api.AppApplication) (string, string) { return app.ApplicationID, app.Name })
+}
+
+// matchOrg finds the organization idOrName refers to; see matchNamed
+func matchOrg(orgs []api.Organization, idOrName string) (api.Organization, error) {
+	return matchNamed(orgs, strings.TrimSpace(idOrName), false, "organizations", errOrgNotFound,
+		func(org api.Organization) (string, string) { return org.ID, org.Name })
+}
+
+// matchNamed finds the item ref refers to, trying an exact ID, then an exact name,
+// then, unless exact is set, a name containing ref; names are compared without
+// regard to case. The first tier with any match decides, and more than one item
+// (described by the plural noun) matching in that tier is a usage error listing
+// them. When nothing matches, the error wraps notFound and suggests a close name.
+func matchNamed[T any](items []T, ref string, exact bool, noun string, notFound error, describe func(T) (id, name string)) (T, error) {
+	var zero T
+	lower := strings.ToLower(ref)
+	tiers := []func(id, name string) bool{
+		func(id, name string) bool { return id == ref },
+		func(id, name string) bool { return strings.EqualFold(name, ref) },
+		func(id, name string) bool { return strings.Contains(strings.ToLower(name), lower) },
+	}
+	if exact {
+		tiers = tiers[:2]
+	}
+	for _, matches := range tiers {
+		var found []T
+		for _, item := range items {
+			if matches(describe(item)) {
+				found = append(found, item)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		}
+
+		candidates := make([]string, len(found))
+		for i, item := range found {
+			id, name := describe(item)
+			candidates[i] = fmt.Sprintf("%s (%s)", name, id)
+		}
+		return zero, newUsageError(fmt.Errorf("%q matches %d %s: %s. Use the full name or ID",
+			ref, len(found), noun, strings.Join(candidates, ", ")))
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		_, names[i] = describe(item)
+	}
+	if suggestion, ok := format.Suggest(ref, names); ok {
+		return zero, fmt.Errorf("%w: %q. Did you mean %q?", notFound, ref, suggestion)
+	}
+	return zero, fmt.Errorf("%w: %q", notFound, ref)
+}
+
+// resolveOrgList resolves each entry of a comma-separated --org list to one of the
+// user's organizations, skipping repeats. Entries that match none, or several,
+// are returned as failures, so the organizations that did resolve can still be
+// queried.
+func resolveOrgList(orgs []api.Organization, list string) ([]api.Organization, []itemFailure) {
+	var selected []api.Organization
+	var failures []itemFailure
+	seen := make(map[string]bool)
+	for _, ref := range splitCommaList(list) {
+		org, err := matchOrg(orgs, ref)
+		if err != nil {
+			failures = append(failures, itemFailure{ID: ref, Err: err})
+			continue
+		}
+		if !seen[org.ID] {
+			seen[org.ID] = true
+			selected = append(selected, org)
+		}
+	}
+	return selected, failures
+}
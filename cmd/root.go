@@ -1,10 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/format"
 )
 
 var (
@@ -13,6 +25,88 @@ var (
 	Date    = "unknown"
 )
 
+// Input and output destinations. Data goes to out and human-facing messages and errors
+// go to errOut, so output can be piped cleanly. Prompts read answers from in. Tests may
+// replace any of them.
+var (
+	in     io.Reader = os.Stdin
+	out    io.Writer = os.Stdout
+	errOut io.Writer = os.Stderr
+)
+
+var (
+	// snapshotDir is the directory of recorded API responses (--snapshot-dir)
+	snapshotDir string
+	// captureDir receives a redacted copy of every API exchange for bug reports (--capture)
+	captureDir string
+	// recordSnapshot saves live responses into snapshotDir instead of replaying them (--record)
+	recordSnapshot bool
+	// maxRetryWait caps the wait after a rate limited (429) response (--max-retry-wait)
+	maxRetryWait time.Duration
+	// baseURL overrides the API base URL (--base-url)
+	baseURL string
+	// instance selects a named API instance such as prod or eu (--instance)
+	instance string
+	// noColor disables colored and graphical terminal output (--no-color)
+	noColor bool
+	// noEmoji leaves emoji out of Markdown reports (--no-emoji)
+	noEmoji bool
+	// strictAPI fails on response fields hawkop doesn't know (--strict-api)
+	strictAPI bool
+	// tableStyleName is the raw --table-style value, parsed into tableStyle before each command
+	tableStyleName string
+	tableStyle     format.TableStyle = format.StyleMinimal
+	// headerStyleName is the raw --header-style value, parsed into headerStyle before each command
+	headerStyleName string
+	headerStyle     format.HeaderStyle = format.HeaderUpper
+	// hideEmptyColumns drops table columns that are blank or N/A in every row (--hide-empty-columns)
+	hideEmptyColumns bool
+	// timezoneName is the raw --timezone value, resolved into displayLocation before each command
+	timezoneName    string
+	displayLocation = time.Local
+	// proxyURL routes API requests through a proxy, overriding the proxy config and environment (--proxy)
+	proxyURL string
+	// httpCache revalidates API responses with ETag/Last-Modified instead of re-downloading them (--http-cache)
+	httpCache bool
+	// noFollowRedirects reports API redirects as errors instead of following them (--no-follow-redirects)
+	noFollowRedirects bool
+	// noInteractive disables prompts, such as picking an organization (--no-interactive)
+	noInteractive bool
+	// assumeYes approves confirmation prompts without asking (--yes)
+	assumeYes bool
+	// operationTimeout bounds the whole command, across all requests and pages (--timeout)
+	operationTimeout time.Duration
+	// debug logs each API response and rate limit wait to errOut (--debug)
+	debug bool
+	// quiet hides progress spinners (--quiet)
+	quiet bool
+	// noIDValidation sends scan, team, and user ID arguments without checking their format (--no-id-validation)
+	noIDValidation bool
+	// maxRetries and retryDelay control resending requests after connection failures
+	// (--max-retries, --retry-delay); unless passed, the config settings apply
+	maxRetries int
+	retryDelay time.Duration
+	// retryOn lists the error statuses a GET is resent after (--retry-on)
+	retryOn []int
+	// localeName picks how table output groups the digits of large numbers (--locale)
+	localeName string
+)
+
+// Sources of configuration and API clients for commands. Tests replace them to
+// run commands against a mock API without reading the user's config file.
+var (
+	loadConfigFile = config.Load
+	saveConfigFile = (*config.Config).Save
+	newClient      = api.NewClient
+)
+
+// operationCtx carries the --timeout deadline for the running command. API clients
+// created by newAPIClient are bound to it.
+var (
+	operationCtx    = context.Background()
+	operationCancel context.CancelFunc
+)
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "hawkop",
@@ -21,14 +115,79 @@ var rootCmd = &cobra.Command{
 scanner and platform. It provides developers and security teams with streamlined 
 access to StackHawk's dynamic application security testing (DAST) capabilities 
 directly from the terminal.`,
-	// Uncomment the following line if your bare application has an action associated with it
-	// Run: func(cmd *cobra.Command, args []string) { },
+	Run: func(cmd *cobra.Command, args []string) {
+		if printCodes, _ := cmd.Flags().GetBool("print-exit-codes"); printCodes {
+			printExitCodes()
+			return
+		}
+		cmd.Help()
+	},
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		config.Warnings = errOut
+		applyConfigDefaults(cmd)
+		applyProjectDefaults(cmd)
+
+		style, err := format.ParseTableStyle(tableStyleName)
+		checkError(newUsageError(err))
+		tableStyle = style
+
+		hStyle, err := format.ParseHeaderStyle(headerStyleName)
+		checkError(newUsageError(err))
+		headerStyle = hStyle
+
+		loc, err := resolveTimezone()
+		checkError(newUsageError(err))
+		displayLocation = loc
+
+		format.SetNumberGrouping(resolveNumberGrouping(cmd))
+
+		if err := config.ValidateRetries(maxRetries); err != nil {
+			checkError(newUsageError(fmt.Errorf("invalid --max-retries: %w", err)))
+		}
+		if err := config.ValidateRetryDelay(retryDelay); err != nil {
+			checkError(newUsageError(fmt.Errorf("invalid --retry-delay: %w", err)))
+		}
+		if err := api.ValidateRetryStatuses(retryOn); err != nil {
+			checkError(newUsageError(fmt.Errorf("invalid --retry-on: %w", err)))
+		}
+
+		// After the timezone, which the template's date functions use
+		outputTemplate = nil
+		if templateText != "" {
+			tmpl, err := loadTemplate(templateText)
+			checkError(newUsageError(err))
+			outputTemplate = tmpl
+		}
+		outputSelect = nil
+		if selectText != "" {
+			path, err := format.ParseSelectPath(selectText)
+			if err != nil {
+				checkError(newUsageError(fmt.Errorf("invalid --select: %w", err)))
+			}
+			outputSelect = path
+		}
+
+		startOperation()
+		startPager(cmd)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		stopPager()
+		if operationCancel != nil {
+			operationCancel()
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() error {
-	return rootCmd.Execute()
+// This is called by main.main(). It only needs to happen once to the rootCmd. It
+// returns the process exit code: cobra only returns errors for bad arguments and
+// flags, and commands record their own failures with failf.
+func Execute() int {
+	commandExitCode = exitOK
+	if err := rootCmd.Execute(); err != nil {
+		return exitUsage
+	}
+	return commandExitCode
 }
 
 func init() {
@@ -37,15 +196,414 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/hawkop/config.json)")
+	rootCmd.PersistentFlags().StringVar(&snapshotDir, "snapshot-dir", "", "Serve API responses from recorded JSON fixtures in this directory")
+	rootCmd.PersistentFlags().StringVar(&captureDir, "capture", "", "Write each API request and response, with secrets redacted, to files in this directory")
+	rootCmd.PersistentFlags().BoolVar(&recordSnapshot, "record", false, "Record live API responses into --snapshot-dir for later replay")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", api.MaxConnectRetriesDefault, fmt.Sprintf("Times to resend a request after a connection failure or --retry-on status, 0 to %d (overrides max_retries)", config.MaxRetriesLimit))
+	rootCmd.PersistentFlags().DurationVar(&retryDelay, "retry-delay", api.ConnectRetryBackoffDefault, fmt.Sprintf("Wait before the first resend, doubling each time, up to %s (overrides retry_delay)", config.MaxRetryDelay))
+	rootCmd.PersistentFlags().IntSliceVar(&retryOn, "retry-on", slices.Clone(api.RetryStatusesDefault), "Comma-separated HTTP statuses to resend GET requests after; 429 is always retried")
+	rootCmd.PersistentFlags().DurationVar(&maxRetryWait, "max-retry-wait", api.MaxRetryAfterDefault, "Maximum time to wait before retrying a rate limited request")
+	rootCmd.PersistentFlags().StringVar(&baseURL, "base-url", "", "StackHawk API base URL (overrides --instance and config)")
+	rootCmd.PersistentFlags().StringVar(&instance, "instance", "", "Named API instance to use (prod or a name from the instances config map)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored and graphical output")
+	rootCmd.PersistentFlags().BoolVar(&strictAPI, "strict-api", false, "Fail when an API response has fields hawkop doesn't know, to debug schema changes")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Leave emoji out of Markdown reports")
+	rootCmd.PersistentFlags().StringVar(&tableStyleName, "table-style", string(format.StyleMinimal), enumUsage("Table style", tableStyleValues))
+	completeEnum(rootCmd, "table-style", tableStyleValues)
+	rootCmd.PersistentFlags().StringVar(&headerStyleName, "header-style", string(format.HeaderUpper), enumUsage("Table header style", headerStyleValues))
+	completeEnum(rootCmd, "header-style", headerStyleValues)
+	rootCmd.PersistentFlags().DurationVar(&operationTimeout, "timeout", 0, "Overall time limit for the command across all requests (0 = no limit)")
+	rootCmd.PersistentFlags().BoolVar(&hideEmptyColumns, "hide-empty-columns", false, "Drop table columns that are empty or N/A in every row")
+	rootCmd.PersistentFlags().StringVar(&timezoneName, "timezone", "", "Time zone for displayed timestamps, e.g. UTC or America/New_York (default local)")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "Proxy URL for API requests (http, https, or socks5; overrides HTTP_PROXY/HTTPS_PROXY)")
+	rootCmd.PersistentFlags().BoolVar(&httpCache, "http-cache", false, "Cache API responses on disk and reuse them when the API reports they haven't changed")
+	rootCmd.PersistentFlags().BoolVar(&noFollowRedirects, "no-follow-redirects", false, "Treat redirects from the API as errors instead of following them")
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "Never prompt for input, e.g. to pick an organization")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to confirmation prompts, e.g. before deleting an application")
+	rootCmd.PersistentFlags().BoolVar(&usePager, "pager", false, "Page output through $HAWKOP_PAGER, $PAGER, or less when stdout is a terminal")
+	rootCmd.PersistentFlags().BoolVar(&config.StrictPermissions, "strict-perms", false, "Refuse to load a config file that other users can read or write")
+	rootCmd.PersistentFlags().StringVar(&localeName, "locale", "", "Locale for grouping digits in tables, e.g. en_US or de_DE, or plain for none (default from LC_ALL, LC_NUMERIC, or LANG)")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log each API response and the rate limit it reports to stderr")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Don't show progress spinners while fetching many pages or scans")
+	rootCmd.PersistentFlags().BoolVar(&noIDValidation, "no-id-validation", false, "Send scan, team, and user ID arguments as given, without checking their format")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("version", "v", false, "show version information")
+	rootCmd.Flags().Bool("print-exit-codes", false, "List the exit codes hawkop returns and what they mean")
+}
+
+// applyConfigDefaults fills in flags the user didn't pass from configured defaults,
+// such as --format from output_format. A default the command doesn't support, like
+// table for 'hawkop version', leaves the command's own default in place.
+func applyConfigDefaults(cmd *cobra.Command) {
+	formatFlag := cmd.Flags().Lookup("format")
+	if formatFlag == nil || formatFlag.Changed {
+		return
+	}
+
+	// Commands report config load errors themselves
+	cfg, err := loadConfigFile()
+	if err != nil || cfg.OutputFormat == "" {
+		return
+	}
+	if formats, ok := formatFlag.Annotations[enumValuesAnnotation]; ok && !slices.Contains(formats, cfg.OutputFormat) {
+		return
+	}
+	_ = cmd.Flags().Set("format", cfg.OutputFormat)
+}
+
+// projectFlags are the flags a project file can supply, with the setting for each
+var projectFlags = []struct {
+	name  string
+	value func(*config.Project) string
+}{
+	{"org", func(p *config.Project) string { return p.Org }},
+	{"app", func(p *config.Project) string { return p.App }},
+	{"env", func(p *config.Project) string { return p.Env }},
+}
+
+// noProjectDefault is the flag annotation that keeps a project file from filling
+// in a flag whose meaning differs from the project setting of the same name
+const noProjectDefault = "hawkop_no_project_default"
+
+// findProject locates the project file for the working directory. Tests may
+// replace it.
+var findProject = func() (*config.Project, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return config.FindProject(dir)
+}
+
+// applyProjectDefaults fills in --org, --app, and --env from the nearest project
+// file when the command has them, they have no default of their own, and the user
+// didn't pass them. As flag values they take precedence over the config file's
+// default organization and environments.
+func applyProjectDefaults(cmd *cobra.Command) {
+	var applied []string
+	var project *config.Project
+	for _, pf := range projectFlags {
+		flag := cmd.Flags().Lookup(pf.name)
+		if flag == nil || flag.Changed || flag.DefValue != "" || flag.Annotations[noProjectDefault] != nil {
+			continue
+		}
+		if project == nil {
+			var err error
+			if project, err = findProject(); err != nil {
+				fmt.Fprintf(errOut, "⚠️  Ignoring project file: %v\n", err)
+				return
+			}
+			if project == nil {
+				return
+			}
+		}
+		if value := pf.value(project); value != "" {
+			_ = cmd.Flags().Set(pf.name, value)
+			applied = append(applied, fmt.Sprintf("--%s %s", pf.name, value))
+		}
+	}
+	if len(applied) > 0 {
+		fmt.Fprintf(errOut, "Using %s from %s. Pass the flags to override.\n", strings.Join(applied, ", "), project.Path)
+	}
+}
+
+// resolveTimezone returns the location timestamps are shown in: --timezone, then the
+// timezone config setting, then the local zone
+func resolveTimezone() (*time.Location, error) {
+	name := timezoneName
+	if name == "" {
+		// Commands report config load errors themselves
+		if cfg, err := loadConfigFile(); err == nil {
+			name = cfg.Timezone
+		}
+	}
+	return format.LoadLocation(name)
+}
+
+// resolveNumberGrouping returns how tables group digits: plain for TSV and CSV output,
+// which are read by machines, otherwise per --locale or the locale environment variables
+func resolveNumberGrouping(cmd *cobra.Command) format.NumberGrouping {
+	if formatFlag := cmd.Flags().Lookup("format"); formatFlag != nil {
+		if name := strings.ToLower(formatFlag.Value.String()); name == "tsv" || name == "csv" {
+			return format.GroupPlain
+		}
+	}
+	if localeName != "" {
+		return format.LocaleGrouping(localeName)
+	}
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return format.LocaleGrouping(value)
+		}
+	}
+	return format.GroupComma
+}
+
+// renderTable renders a table in the styles chosen with --table-style and --header-style,
+// dropping empty columns when --hide-empty-columns is set
+func renderTable(table *format.TableWriter) string {
+	if hideEmptyColumns {
+		table.HideEmptyColumns()
+	}
+	table.SetHeaderStyle(headerStyle)
+	return table.RenderStyle(tableStyle)
+}
+
+// outputTSV writes table to out as tab-separated values, using the --header-style names
+func outputTSV(table *format.TableWriter) {
+	table.SetHeaderStyle(headerStyle)
+	fmt.Fprint(out, table.TSV().Render())
+}
+
+// outputCSV writes table to out as comma-separated values, using the --header-style names
+func outputCSV(table *format.TableWriter) {
+	table.SetHeaderStyle(headerStyle)
+	fmt.Fprint(out, table.CSV())
+}
+
+// startOperation applies the --timeout deadline to operationCtx
+func startOperation() {
+	if operationTimeout > 0 {
+		operationCtx, operationCancel = context.WithTimeout(context.Background(), operationTimeout)
+	}
+}
+
+// reportTimeout prints how far the command got if err is due to --timeout,
+// returning true when it was
+func reportTimeout(err error, progress string) bool {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	fmt.Fprintf(errOut, "⏱️  Timed out after %s (--timeout); %s\n", operationTimeout, progress)
+	setExitCode(exitUnavailable)
+	return true
 }
 
 func checkError(err error) {
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		stopPager()
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// replayingSnapshot reports whether API responses are served from a snapshot directory
+func replayingSnapshot() bool {
+	return snapshotDir != "" && !recordSnapshot
+}
+
+// loadConfig loads the configuration for API commands. When replaying a snapshot,
+// a placeholder API key is set in memory so no real API key is required; the
+// client authenticates replayed requests itself.
+func loadConfig() (*config.Config, error) {
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if replayingSnapshot() && cfg.APIKey == "" {
+		cfg.APIKey = "snapshot"
+	}
+
+	return cfg, nil
+}
+
+// errReplayingSnapshot is returned by saveConfig while replaying a snapshot
+var errReplayingSnapshot = newUsageError(errors.New("the configuration isn't saved while replaying a snapshot; run without --snapshot-dir to change it"))
+
+// saveConfig saves a configuration returned by loadConfig. While replaying a
+// snapshot it refuses, since the placeholder credentials would replace the real ones.
+func saveConfig(cfg *config.Config) error {
+	if replayingSnapshot() {
+		return errReplayingSnapshot
+	}
+	return saveConfigFile(cfg)
+}
+
+// resolveRetryConfig returns the connection retry settings: --max-retries and
+// --retry-delay when passed, then the max_retries and retry_delay config settings,
+// then the flag defaults
+func resolveRetryConfig(cfg *config.Config) api.RetryConfig {
+	retry := api.RetryConfig{MaxRetries: maxRetries, Delay: retryDelay}
+	if n, ok := cfg.Retries(); ok && !rootCmd.PersistentFlags().Changed("max-retries") {
+		retry.MaxRetries = n
+	}
+	if d, ok := cfg.RetryBackoff(); ok && !rootCmd.PersistentFlags().Changed("retry-delay") {
+		retry.Delay = d
+	}
+	return retry
+}
+
+// newAPIClient creates an API client honoring global flags such as --snapshot-dir and --record
+func newAPIClient(cfg *config.Config) *api.Client {
+	client := newClient(cfg)
+	client.MaxRetryAfter = maxRetryWait
+	client.FollowRedirects = !noFollowRedirects
+	client.SetContext(operationCtx)
+	if debug {
+		client.Debug = errOut
+	}
+	if timeout := cfg.Timeout(); timeout > 0 {
+		client.HTTPClient.Timeout = timeout
+	}
+	client.SetRetryConfig(resolveRetryConfig(cfg))
+	client.RetryStatuses = retryOn
+	client.StrictDecoding = strictAPI
+
+	resolvedURL, err := cfg.ResolveBaseURL(baseURL, instance)
+	checkError(err)
+	if resolvedURL != "" {
+		client.SetBaseURL(resolvedURL)
+	}
+
+	// The proxy transport sits beneath snapshot recording and capture
+	proxy := proxyURL
+	if proxy == "" {
+		proxy = cfg.Proxy
+	}
+	if proxy != "" {
+		checkError(client.UseProxy(proxy))
+	}
+
+	if snapshotDir != "" {
+		client.UseSnapshot(snapshotDir, recordSnapshot)
+	} else if recordSnapshot {
+		fmt.Fprintln(errOut, "⚠️  --record requires --snapshot-dir; responses will not be recorded")
+	}
+
+	// Snapshots hold full responses, so conditional requests are only made live
+	if httpCache && snapshotDir == "" {
+		client.UseCache(api.NewDiskCacheStore(httpCacheDir()))
+	}
+
+	// Capture wraps the other transports so it sees exactly what the command did
+	if captureDir != "" {
+		checkError(client.UseCapture(captureDir))
+	}
+	return client
+}
+
+// httpCacheDir is where --http-cache keeps responses, beside the config file
+func httpCacheDir() string {
+	return filepath.Join(config.GetConfigDir(), "cache", "http")
+}
+
+// stdoutIsTerminal reports whether standard output is an interactive terminal
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// stdinIsTerminal reports whether standard input is an interactive terminal. Tests may replace it.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// canPrompt reports whether hawkop may ask the user questions on standard input
+func canPrompt() bool {
+	return !noInteractive && stdinIsTerminal()
+}
+
+// graphicsEnabled reports whether charts and other graphical output may be rendered
+func graphicsEnabled() bool {
+	return !noColor && stdoutIsTerminal()
+}
+
+// startSpinner shows message with a spinner on stderr until the returned spinner is
+// stopped. The spinner only draws on a terminal, and not with --quiet or --debug,
+// whose log lines would interleave with it.
+func startSpinner(message string) *format.Spinner {
+	spinner := format.NewSpinner(errOut, !quiet && !debug && format.IsTerminal(errOut))
+	spinner.Start(message)
+	return spinner
+}
+
+// alertFetchProgress returns a progress callback that shows which scan's alerts
+// are being fetched on spinner
+func alertFetchProgress(spinner *format.Spinner) func(done, total int) {
+	return func(done, total int) {
+		spinner.Update(fmt.Sprintf("Fetching alerts for scan %d/%d...", min(done+1, total), total))
+	}
+}
+
+// emojiPrefix returns text led by emoji, or just text with --no-emoji
+func emojiPrefix(emoji, text string) string {
+	if noEmoji {
+		return text
+	}
+	return emoji + " " + text
+}
+
+// terminalWidth returns the width of the terminal, or 80 when it cannot be determined
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
+
+// resolveOrgID returns the organization to use, falling back to the configured default.
+// It prints a user-facing error and returns false if no valid organization ID is available.
+func resolveOrgID(orgID string, cfg *config.Config) (string, bool) {
+	if orgID == "" {
+		orgID = cfg.OrgID
+	}
+	if orgID == "" {
+		return autoSelectOrgID(cfg)
+	}
+	if err := api.ValidateOrgID(orgID); err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return "", false
+	}
+	return orgID, true
+}
+
+// resolveEnv returns env, or the organization's default environment when env is
+// empty, noting the substitution on errOut
+func resolveEnv(env string, orgID string, cfg *config.Config) string {
+	if env != "" {
+		return env
+	}
+	if defaultEnv := cfg.DefaultEnv(orgID); defaultEnv != "" {
+		fmt.Fprintf(errOut, "Using default environment %s for organization %s. Pass --env to override.\n", defaultEnv, orgID)
+		return defaultEnv
+	}
+	return ""
+}
+
+// listUserOrganizations fetches the organizations the user belongs to for automatic
+// org selection and org switch. Tests may replace it.
+var listUserOrganizations = func(cfg *config.Config) ([]api.Organization, error) {
+	return newAPIClient(cfg).ListOrganizations()
+}
+
+// autoSelectOrgID picks the user's organization when they belong to exactly one, or
+// lets them choose one when stdin is a terminal. Otherwise it prints the usual error,
+// listing the organizations to choose from.
+func autoSelectOrgID(cfg *config.Config) (string, bool) {
+	const noOrgMessage = "No organization specified. Use --org flag or set a default with 'hawkop org set <org-id>'"
+
+	orgs, err := listUserOrganizations(cfg)
+	if err != nil || len(orgs) == 0 {
+		failf(exitUsage, noOrgMessage)
+		return "", false
+	}
+
+	if len(orgs) == 1 {
+		fmt.Fprintf(errOut, "Using organization %s (%s), the only one you belong to. Run 'hawkop org set %s' to make it the default.\n", orgs[0].Name, orgs[0].ID, orgs[0].ID)
+		return orgs[0].ID, true
+	}
+
+	if canPrompt() {
+		return pickOrganization(cfg, orgs)
+	}
+
+	failf(exitUsage, noOrgMessage)
+	fmt.Fprintln(errOut, "Available organizations:")
+	for _, org := range orgs {
+		fmt.Fprintf(errOut, "  %s  %s\n", org.ID, org.Name)
 	}
+	return "", false
 }
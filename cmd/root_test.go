@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/format"
+)
+
+func TestReportTimeout(t *testing.T) {
+	_, stderr := captureOutput(t, func() {
+		assert.True(t, reportTimeout(fmt.Errorf("request failed: %w", context.DeadlineExceeded), "streamed 3 scans before the deadline"))
+	})
+	assert.Contains(t, stderr, "Timed out")
+	assert.Contains(t, stderr, "streamed 3 scans")
+
+	_, stderr = captureOutput(t, func() {
+		assert.False(t, reportTimeout(errors.New("boom"), "ignored"))
+		assert.False(t, reportTimeout(nil, "ignored"))
+	})
+	assert.Empty(t, stderr)
+}
+
+func TestResolveNumberGrouping(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	origLocale := localeName
+	t.Cleanup(func() { localeName = origLocale })
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", "table", "")
+
+	localeName = ""
+	assert.Equal(t, format.NumberGrouping("."), resolveNumberGrouping(cmd), "LANG applies without --locale")
+
+	t.Setenv("LC_NUMERIC", "fr_FR")
+	assert.Equal(t, format.NumberGrouping(" "), resolveNumberGrouping(cmd), "LC_NUMERIC overrides LANG")
+
+	localeName = "en_US"
+	assert.Equal(t, format.GroupComma, resolveNumberGrouping(cmd), "--locale overrides the environment")
+
+	require.NoError(t, cmd.Flags().Set("format", "TSV"))
+	assert.Equal(t, format.GroupPlain, resolveNumberGrouping(cmd), "TSV output is never grouped")
+}
+
+func TestApplyConfigDefaults_OnlySupportedFormats(t *testing.T) {
+	stubConfigFile(t, &config.Config{OutputFormat: "tsv"})
+
+	newFormatCommand := func(def string, formats []string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", def, "")
+		completeEnum(cmd, "format", formats)
+		return cmd
+	}
+
+	list := newFormatCommand("table", formatsTableJSONTSV)
+	applyConfigDefaults(list)
+	assert.Equal(t, "tsv", list.Flag("format").Value.String(), "output_format applies where supported")
+
+	version := newFormatCommand("text", formatsTextJSON)
+	applyConfigDefaults(version)
+	assert.Equal(t, "text", version.Flag("format").Value.String(), "an unsupported output_format is ignored")
+
+	passed := newFormatCommand("table", formatsTableJSONTSV)
+	require.NoError(t, passed.Flags().Set("format", "json"))
+	applyConfigDefaults(passed)
+	assert.Equal(t, "json", passed.Flag("format").Value.String(), "--format overrides output_format")
+}
+
+func TestResolveRetryConfig(t *testing.T) {
+	flags := rootCmd.PersistentFlags()
+	origRetries, origDelay := maxRetries, retryDelay
+	t.Cleanup(func() {
+		maxRetries, retryDelay = origRetries, origDelay
+		flags.Lookup("max-retries").Changed = false
+		flags.Lookup("retry-delay").Changed = false
+	})
+
+	assert.Equal(t, api.RetryConfig{MaxRetries: api.MaxConnectRetriesDefault, Delay: api.ConnectRetryBackoffDefault},
+		resolveRetryConfig(&config.Config{}), "defaults apply without flags or config")
+
+	cfg := &config.Config{MaxRetries: "7", RetryDelay: "2s"}
+	assert.Equal(t, api.RetryConfig{MaxRetries: 7, Delay: 2 * time.Second}, resolveRetryConfig(cfg), "config settings apply")
+
+	require.NoError(t, flags.Set("max-retries", "0"))
+	assert.Equal(t, api.RetryConfig{MaxRetries: 0, Delay: 2 * time.Second}, resolveRetryConfig(cfg), "--max-retries overrides max_retries")
+
+	require.NoError(t, flags.Set("retry-delay", "100ms"))
+	client := newAPIClient(cfg)
+	assert.Equal(t, 0, client.MaxConnectRetries)
+	assert.Equal(t, 100*time.Millisecond, client.ConnectRetryBackoff)
+}
+
+func TestNewAPIClient_RetryOn(t *testing.T) {
+	assert.Equal(t, api.RetryStatusesDefault, newAPIClient(&config.Config{}).RetryStatuses)
+
+	require.NoError(t, rootCmd.PersistentFlags().Set("retry-on", "502,520"))
+	t.Cleanup(func() {
+		retryOn = slices.Clone(api.RetryStatusesDefault)
+		rootCmd.PersistentFlags().Lookup("retry-on").Changed = false
+	})
+	assert.Equal(t, []int{502, 520}, newAPIClient(&config.Config{}).RetryStatuses)
+}
+
+// stubOrganizations replaces the organization lookup used by resolveOrgID. Stdin
+// is treated as non-interactive unless the test also calls stubPrompt.
+func stubOrganizations(t *testing.T, orgs []api.Organization, err error) {
+	t.Helper()
+
+	orig := listUserOrganizations
+	listUserOrganizations = func(cfg *config.Config) ([]api.Organization, error) { return orgs, err }
+	t.Cleanup(func() { listUserOrganizations = orig })
+	stubNonInteractive(t)
+}
+
+// stubNonInteractive makes stdin look like a pipe, so hawkop can't prompt
+func stubNonInteractive(t *testing.T) {
+	t.Helper()
+
+	origTerminal := stdinIsTerminal
+	stdinIsTerminal = func() bool { return false }
+	t.Cleanup(func() { stdinIsTerminal = origTerminal })
+}
+
+func TestResolveOrgID_ExplicitAndConfigured(t *testing.T) {
+	stubOrganizations(t, nil, errors.New("should not be called"))
+
+	orgID, ok := resolveOrgID("flag-org", &config.Config{OrgID: "config-org"})
+	assert.True(t, ok)
+	assert.Equal(t, "flag-org", orgID)
+
+	orgID, ok = resolveOrgID("", &config.Config{OrgID: "config-org"})
+	assert.True(t, ok)
+	assert.Equal(t, "config-org", orgID)
+}
+
+func TestResolveOrgID_SingleOrgIsSelected(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "only-org", Name: "Only Org"}}, nil)
+
+	var orgID string
+	var ok bool
+	stdout, stderr := captureOutput(t, func() { orgID, ok = resolveOrgID("", &config.Config{}) })
+	assert.True(t, ok)
+	assert.Equal(t, "only-org", orgID)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Using organization Only Org (only-org)")
+}
+
+func TestResolveOrgID_MultipleOrgsAreListed(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}, nil)
+
+	var ok bool
+	_, stderr := captureOutput(t, func() { _, ok = resolveOrgID("", &config.Config{}) })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "No organization specified")
+	assert.Contains(t, stderr, "org-a  Alpha")
+	assert.Contains(t, stderr, "org-b  Beta")
+}
+
+// stubPrompt makes stdin look like a terminal that answers with input, and records
+// configs saved by the prompt instead of writing the user's config file
+func stubPrompt(t *testing.T, input string) *[]*config.Config {
+	t.Helper()
+
+	origIn, origTerminal, origSave := in, stdinIsTerminal, saveConfigFile
+	var saved []*config.Config
+	in = strings.NewReader(input)
+	stdinIsTerminal = func() bool { return true }
+	saveConfigFile = func(cfg *config.Config) error {
+		saved = append(saved, cfg)
+		return nil
+	}
+	t.Cleanup(func() { in, stdinIsTerminal, saveConfigFile = origIn, origTerminal, origSave })
+	return &saved
+}
+
+func TestResolveOrgID_InteractivePick(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}, nil)
+	saved := stubPrompt(t, "3\n2\nn\n")
+
+	var orgID string
+	var ok bool
+	stdout, stderr := captureOutput(t, func() { orgID, ok = resolveOrgID("", &config.Config{}) })
+	assert.True(t, ok)
+	assert.Equal(t, "org-b", orgID)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "2) Beta (org-b)")
+	assert.Contains(t, stderr, "Please enter a number between 1 and 2")
+	assert.Empty(t, *saved)
+}
+
+func TestResolveOrgID_InteractivePickSavesDefault(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}, nil)
+	saved := stubPrompt(t, "1\ny\n")
+
+	cfg := &config.Config{}
+	var orgID string
+	_, stderr := captureOutput(t, func() { orgID, _ = resolveOrgID("", cfg) })
+	assert.Equal(t, "org-a", orgID)
+	assert.Equal(t, "org-a", cfg.OrgID)
+	assert.Equal(t, []*config.Config{cfg}, *saved)
+	assert.Contains(t, stderr, "Default organization ID set to: org-a")
+}
+
+func TestResolveOrgID_InteractiveCancel(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}, nil)
+	stubPrompt(t, "")
+
+	var ok bool
+	_, stderr := captureOutput(t, func() { _, ok = resolveOrgID("", &config.Config{}) })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "No organization selected")
+}
+
+func TestConfirm_Answers(t *testing.T) {
+	for input, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		stubPrompt(t, input)
+
+		var ok bool
+		stdout, stderr := captureOutput(t, func() { ok = confirm("This will delete application app-1.") })
+		assert.Equal(t, want, ok, "answer %q", input)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "This will delete application app-1. Continue? [y/N]: ")
+		if !want {
+			assert.Contains(t, stderr, "Cancelled.")
+		}
+	}
+}
+
+func TestConfirm_NonInteractiveRefuses(t *testing.T) {
+	stubNonInteractive(t)
+
+	var ok bool
+	_, stderr := captureOutput(t, func() { ok = confirm("This will delete application app-1.") })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "❌ This will delete application app-1. Re-run with --yes to proceed.")
+
+	// --no-interactive refuses even when stdin is a terminal
+	stubPrompt(t, "y\n")
+	noInteractive = true
+	t.Cleanup(func() { noInteractive = false })
+	_, stderr = captureOutput(t, func() { ok = confirm("This will create team \"Platform\".") })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "--yes")
+}
+
+func TestConfirm_YesSkipsPrompt(t *testing.T) {
+	stubNonInteractive(t)
+	assumeYes = true
+	t.Cleanup(func() { assumeYes = false })
+
+	var ok bool
+	stdout, stderr := captureOutput(t, func() { ok = confirm("This will delete application app-1.") })
+	assert.True(t, ok)
+	assert.Empty(t, stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestResolveOrgID_NoInteractive(t *testing.T) {
+	stubOrganizations(t, []api.Organization{{ID: "org-a", Name: "Alpha"}, {ID: "org-b", Name: "Beta"}}, nil)
+	stubPrompt(t, "1\n")
+	noInteractive = true
+	t.Cleanup(func() { noInteractive = false })
+
+	var ok bool
+	_, stderr := captureOutput(t, func() { _, ok = resolveOrgID("", &config.Config{}) })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "No organization specified. Use --org flag")
+	assert.Contains(t, stderr, "org-a  Alpha")
+}
+
+func TestResolveOrgID_LookupFailure(t *testing.T) {
+	stubOrganizations(t, nil, errors.New("network down"))
+
+	var ok bool
+	_, stderr := captureOutput(t, func() { _, ok = resolveOrgID("", &config.Config{}) })
+	assert.False(t, ok)
+	assert.Contains(t, stderr, "No organization specified")
+}
+
+func TestResolveEnv_Precedence(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaultEnv("org-1", "Production")
+
+	// An explicit --env wins without a note
+	var env string
+	_, stderr := captureOutput(t, func() { env = resolveEnv("Staging", "org-1", cfg) })
+	assert.Equal(t, "Staging", env)
+	assert.Empty(t, stderr)
+
+	// Otherwise the organization's default applies, with a note
+	_, stderr = captureOutput(t, func() { env = resolveEnv("", "org-1", cfg) })
+	assert.Equal(t, "Production", env)
+	assert.Contains(t, stderr, "Using default environment Production for organization org-1")
+
+	// Other organizations have no default
+	_, stderr = captureOutput(t, func() { env = resolveEnv("", "org-2", cfg) })
+	assert.Empty(t, env)
+	assert.Empty(t, stderr)
+}
+
+// stubProject makes project the project file found for the working directory
+func stubProject(t *testing.T, project *config.Project, err error) {
+	t.Helper()
+
+	orig := findProject
+	findProject = func() (*config.Project, error) { return project, err }
+	t.Cleanup(func() { findProject = orig })
+}
+
+// projectTestCommand returns a command with the flags a project file can fill in
+func projectTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringP("org", "o", "", "")
+	cmd.Flags().StringP("app", "a", "", "")
+	cmd.Flags().StringP("env", "e", "", "")
+	return cmd
+}
+
+func TestApplyProjectDefaults_Precedence(t *testing.T) {
+	stubOrganizations(t, nil, errors.New("should not be called"))
+	stubProject(t, &config.Project{Org: "org-project", App: "Billing API", Env: "Staging", Path: "/repo/.hawkop.yaml"}, nil)
+	cfg := &config.Config{OrgID: "org-config"}
+	cfg.SetDefaultEnv("org-project", "Production")
+
+	// The project file fills in what isn't passed, above the config file's defaults
+	cmd := projectTestCommand()
+	require.NoError(t, cmd.Flags().Set("app", "Checkout"))
+	_, stderr := captureOutput(t, func() { applyProjectDefaults(cmd) })
+	assert.Equal(t, "Using --org org-project, --env Staging from /repo/.hawkop.yaml. Pass the flags to override.\n", stderr)
+
+	org, _ := cmd.Flags().GetString("org")
+	app, _ := cmd.Flags().GetString("app")
+	env, _ := cmd.Flags().GetString("env")
+	orgID, ok := resolveOrgID(org, cfg)
+	require.True(t, ok)
+	assert.Equal(t, "org-project", orgID)
+	assert.Equal(t, "Checkout", app, "flags win over the project file")
+	_, stderr = captureOutput(t, func() { env = resolveEnv(env, orgID, cfg) })
+	assert.Equal(t, "Staging", env)
+	assert.Empty(t, stderr)
+}
+
+func TestApplyProjectDefaults_SkippedFlags(t *testing.T) {
+	stubProject(t, &config.Project{Org: "org-project", App: "Billing API", Env: "Staging", Path: "/repo/.hawkop.yaml"}, nil)
+
+	// Flags with their own default, or that opt out, are left alone
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("env", "Development", "")
+	cmd.Flags().String("app", "", "")
+	_ = cmd.Flags().SetAnnotation("app", noProjectDefault, []string{"true"})
+	_, stderr := captureOutput(t, func() { applyProjectDefaults(cmd) })
+	assert.Empty(t, stderr)
+	env, _ := cmd.Flags().GetString("env")
+	app, _ := cmd.Flags().GetString("app")
+	assert.Equal(t, "Development", env)
+	assert.Empty(t, app)
+}
+
+func TestApplyProjectDefaults_NoProjectOrError(t *testing.T) {
+	stubProject(t, nil, nil)
+	cmd := projectTestCommand()
+	_, stderr := captureOutput(t, func() { applyProjectDefaults(cmd) })
+	assert.Empty(t, stderr)
+	org, _ := cmd.Flags().GetString("org")
+	assert.Empty(t, org)
+
+	stubProject(t, nil, errors.New("failed to parse project file /repo/.hawkop.yaml: bad"))
+	_, stderr = captureOutput(t, func() { applyProjectDefaults(projectTestCommand()) })
+	assert.Equal(t, "⚠️  Ignoring project file: failed to parse project file /repo/.hawkop.yaml: bad\n", stderr)
+}
+
+func TestStartSpinner_SilentWhenNotTerminal(t *testing.T) {
+	_, stderr := captureOutput(t, func() {
+		spinner := startSpinner("Fetching scans...")
+		alertFetchProgress(spinner)(0, 3)
+		time.Sleep(300 * time.Millisecond)
+		spinner.Stop()
+	})
+	assert.Empty(t, stderr)
+}
+
+func TestNewAPIClient_ProxyPrecedence(t *testing.T) {
+	proxyFor := func(cfg *config.Config) string {
+		client := newAPIClient(cfg)
+		transport, ok := client.HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			return ""
+		}
+		proxy, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "https://api.stackhawk.com/", nil))
+		require.NoError(t, err)
+		return proxy.String()
+	}
+
+	assert.Empty(t, proxyFor(&config.Config{}))
+	assert.Equal(t, "http://config-proxy:3128", proxyFor(&config.Config{Proxy: "http://config-proxy:3128"}))
+
+	proxyURL = "socks5://flag-proxy:1080"
+	t.Cleanup(func() { proxyURL = "" })
+	assert.Equal(t, "socks5://flag-proxy:1080", proxyFor(&config.Config{Proxy: "http://config-proxy:3128"}))
+}
+
+func TestNewAPIClient_NoFollowRedirects(t *testing.T) {
+	assert.True(t, newAPIClient(&config.Config{}).FollowRedirects)
+
+	noFollowRedirects = true
+	t.Cleanup(func() { noFollowRedirects = false })
+	assert.False(t, newAPIClient(&config.Config{}).FollowRedirects)
+}
+
+func TestNewAPIClient_HTTPCache(t *testing.T) {
+	_, ok := newAPIClient(&config.Config{}).HTTPClient.Transport.(*api.CacheTransport)
+	assert.False(t, ok)
+
+	httpCache = true
+	t.Cleanup(func() { httpCache, snapshotDir = false, "" })
+	_, ok = newAPIClient(&config.Config{}).HTTPClient.Transport.(*api.CacheTransport)
+	assert.True(t, ok)
+
+	// Replayed snapshots never reach the API, so there is nothing to revalidate
+	snapshotDir = t.TempDir()
+	_, ok = newAPIClient(&config.Config{}).HTTPClient.Transport.(*api.CacheTransport)
+	assert.False(t, ok)
+}
+
+func TestResolveTimezone_Precedence(t *testing.T) {
+	origLoad := loadConfigFile
+	t.Cleanup(func() { loadConfigFile, timezoneName = origLoad, "" })
+
+	// Without a flag or setting, timestamps use the local zone
+	loadConfigFile = func() (*config.Config, error) { return &config.Config{}, nil }
+	loc, err := resolveTimezone()
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	// The config setting applies next
+	loadConfigFile = func() (*config.Config, error) { return &config.Config{Timezone: "America/New_York"}, nil }
+	loc, err = resolveTimezone()
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", loc.String())
+
+	// --timezone wins
+	timezoneName = "UTC"
+	loc, err = resolveTimezone()
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	timezoneName = "Nowhere/Special"
+	_, err = resolveTimezone()
+	assert.ErrorContains(t, err, "unknown timezone")
+}
+
+func TestScanTableRow_Timezone(t *testing.T) {
+	origLocation := displayLocation
+	t.Cleanup(func() { displayLocation = origLocation })
+
+	result := api.ApplicationScanResult{Scan: api.Scan{Timestamp: "1756596062834"}}
+
+	displayLocation = time.UTC
+	assert.Equal(t, "2025-08-30 23:21", scanTableRow(result)[6])
+
+	displayLocation, _ = time.LoadLocation("America/New_York")
+	assert.Equal(t, "2025-08-30 19:21", scanTableRow(result)[6])
+}
@@ -1,17 +1,27 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"hawkop/internal/api"
 	"hawkop/internal/config"
+	"hawkop/internal/cwe"
 	"hawkop/internal/format"
+	"hawkop/internal/suppress"
 )
 
 // scanCmd represents the scan command
@@ -31,15 +41,69 @@ var scanListCmd = &cobra.Command{
 	
 By default, uses your configured default organization and shows scans sorted by 
 timestamp in descending order (most recent first). You can filter by application
-name/ID and environment.`,
+name/ID and environment.
+
+--count prints the number of matching scans instead of listing them. Without
+filters it takes a single request, using the total the API reports.
+
+--org also takes a comma-separated list of organization IDs or names to list
+scans across several organizations, like --all-orgs with an ORG column. Entries
+that don't match an organization you belong to are reported after the results
+without stopping the others.`,
+	Example: `  hawkop scan list --app "Billing API" --env Production
+  hawkop scan list --org "Payments,Platform" --limit 20 --limit-scope global`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		limit, _ := cmd.Flags().GetInt("limit")
 		org, _ := cmd.Flags().GetString("org")
 		app, _ := cmd.Flags().GetString("app")
+		appMatch, _ := cmd.Flags().GetString("app-match")
 		env, _ := cmd.Flags().GetString("env")
+		excludeEnv, _ := cmd.Flags().GetString("exclude-env")
 		status, _ := cmd.Flags().GetString("status")
-		runScanList(format, limit, org, app, env, status)
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		allOrgs, _ := cmd.Flags().GetBool("all-orgs")
+		limitScope, _ := cmd.Flags().GetString("limit-scope")
+		totals, _ := cmd.Flags().GetBool("totals")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		firstPage, _ := cmd.Flags().GetBool("no-pagination")
+		useLast, _ := cmd.Flags().GetBool("use-last")
+		count, _ := cmd.Flags().GetBool("count")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		opts := scanListOptions{Limit: limit, App: app, AppMatch: appMatch, Env: env, ExcludeEnv: excludeEnv, Status: status, Totals: totals,
+			PageSize: pageSize, FirstPage: firstPage, UseLast: useLast, Count: count, GroupBy: groupBy}
+		// A list of organizations in --org is queried like --all-orgs
+		orgList := strings.Contains(org, ",")
+		if allOrgs && orgList {
+			failf(exitUsage, "--all-orgs cannot be combined with a list of organizations in --org")
+			return
+		}
+		if useLast && (allOrgs || orgList || watch) {
+			failf(exitUsage, "--use-last cannot be combined with --all-orgs, an --org list, or --watch")
+			return
+		}
+		if count && (allOrgs || orgList || watch || useLast || limit != 0) {
+			failf(exitUsage, "--count cannot be combined with --all-orgs, an --org list, --watch, --use-last, or --limit")
+			return
+		}
+		if groupBy != "" && (allOrgs || orgList || watch || count) {
+			failf(exitUsage, "--group-by cannot be combined with --all-orgs, an --org list, --watch, or --count")
+			return
+		}
+		if templating() && (watch || count) {
+			failf(exitUsage, "--template and --select cannot be combined with --watch or --count")
+			return
+		}
+		if allOrgs {
+			runScanListAllOrgs(format, "", opts, limitScope)
+			return
+		}
+		if orgList {
+			runScanListAllOrgs(format, org, opts, limitScope)
+			return
+		}
+		runScanList(format, org, opts, watch, interval)
 	},
 }
 
@@ -48,13 +112,33 @@ var scanGetCmd = &cobra.Command{
 	Use:   "get <scan-id>",
 	Short: "Get details for a specific scan",
 	Long: `Get detailed information about a specific scan including metadata,
-duration, URL count, and alert statistics.`,
-	Args: cobra.ExactArgs(1),
+duration, URL count, and alert statistics.
+
+--view findings fetches the scan's alerts and shows the --top most severe, those
+with the most URIs first, beneath the overview. --view all shows every other view.
+
+Use --ids a,b,c instead of a scan ID to fetch several scans at once. They are listed
+in the order given, and any IDs that weren't found are reported afterwards.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		scanID := args[0]
 		format, _ := cmd.Flags().GetString("format")
+		ids, _ := cmd.Flags().GetString("ids")
+		if ids != "" {
+			if len(args) > 0 {
+				failf(exitUsage, "Pass either a scan ID or --ids, not both")
+				return
+			}
+			runScanGetByIDs(splitCommaList(ids), format)
+			return
+		}
+		if len(args) == 0 {
+			failf(exitUsage, "A scan ID or --ids is required")
+			return
+		}
 		view, _ := cmd.Flags().GetString("view")
-		runScanGet(scanID, format, view)
+		chart, _ := cmd.Flags().GetBool("chart")
+		top, _ := cmd.Flags().GetInt("top")
+		runScanGet(args[0], format, view, chart, top)
 	},
 }
 
@@ -64,14 +148,91 @@ var scanAlertsCmd = &cobra.Command{
 	Short: "List alerts for a specific scan",
 	Long: `List all security alerts/findings for a specific scan.
 	
-Shows vulnerability details including severity, plugin ID, description, and URI count.`,
+Shows vulnerability details including severity, plugin ID, description, and URI count.
+
+With --fail-on, exits with status 1 when any alert left after the --severity and
+suppression filters is at or above the given severity, listing those alerts, so
+CI can gate on a scan without a separate command. Suppressed alerts never fail,
+and --limit only shortens the listing.`,
+	Example: `  hawkop scan alerts <scan-id> --fail-on High
+  hawkop scan alerts <scan-id> --summary --fail-on Medium`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		scanID := args[0]
 		format, _ := cmd.Flags().GetString("format")
 		severity, _ := cmd.Flags().GetString("severity")
 		limit, _ := cmd.Flags().GetInt("limit")
-		runScanAlerts(scanID, format, severity, limit)
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		hideSuppressed, _ := cmd.Flags().GetBool("hide-suppressed")
+		showSuppressed, _ := cmd.Flags().GetBool("show-suppressed")
+		totals, _ := cmd.Flags().GetBool("totals")
+		cweNames, _ := cmd.Flags().GetBool("cwe-names")
+		summary, _ := cmd.Flags().GetBool("summary")
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		if hideSuppressed && showSuppressed {
+			failf(exitUsage, "--hide-suppressed and --show-suppressed cannot be used together")
+			return
+		}
+		opts := scanAlertsOptions{Severity: severity, Limit: limit, GroupBy: groupBy, HideSuppressed: hideSuppressed, Totals: totals, CWENames: cweNames, Summary: summary, FailOn: failOn}
+		runScanAlerts(scanID, format, opts)
+	},
+}
+
+// scanBaselineCmd manages pinned baseline scans
+var scanBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage baseline scans used by scan compare",
+	Long: `Manage the baseline scans pinned for each application and environment.
+	
+Baselines are stored in your configuration file and used by 'hawkop scan compare'.`,
+}
+
+// scanBaselineSetCmd pins a baseline scan for an application environment
+var scanBaselineSetCmd = &cobra.Command{
+	Use:   "set <app> <env> <scan-id>",
+	Short: "Pin a baseline scan for an application environment",
+	Long: `Pin a scan as the baseline for an application (name or ID) and environment.
+	
+Any existing baseline for the same application and environment is replaced.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		runScanBaselineSet(args[0], args[1], args[2])
+	},
+}
+
+// scanCompareCmd compares a scan against its baseline
+var scanCompareCmd = &cobra.Command{
+	Use:   "compare <scan-id>",
+	Short: "Compare a scan against its pinned baseline",
+	Long: `Compare a scan's alerts against the baseline pinned for its application and
+environment, reporting new and resolved alerts. Findings are matched by fingerprint,
+so an alert found at new URIs is reported as new even if the baseline had it
+elsewhere.
+	
+Exits with status 1 if any new High severity alerts appear that are not on the
+suppression list, so it can be used to gate pull requests.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		runScanCompare(args[0], format)
+	},
+}
+
+// scanDiffCmd compares the alerts of two scans
+var scanDiffCmd = &cobra.Command{
+	Use:   "diff <base-scan-id> <scan-id>",
+	Short: "Compare the alerts of two scans",
+	Long: `Compare the alerts of a scan against those of an earlier base scan, reporting
+new, resolved, and unchanged alerts. Findings are matched by fingerprint, as in
+'hawkop scan compare'.
+
+--format markdown renders a summary line and table for pasting into a pull request
+comment. Like 'hawkop scan compare', exits with status 1 if any new High severity
+alerts appear that are not on the suppression list.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		runScanDiff(args[0], args[1], format)
 	},
 }
 
@@ -80,371 +241,1921 @@ func init() {
 	scanCmd.AddCommand(scanListCmd)
 	scanCmd.AddCommand(scanGetCmd)
 	scanCmd.AddCommand(scanAlertsCmd)
+	scanCmd.AddCommand(scanBaselineCmd)
+	scanBaselineCmd.AddCommand(scanBaselineSetCmd)
+	scanCmd.AddCommand(scanCompareCmd)
+	scanCmd.AddCommand(scanDiffCmd)
 
 	// Add flags for scan list command
-	scanListCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
+	scanListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(scanListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(scanListCmd)
 	scanListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
 	scanListCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
 	scanListCmd.Flags().StringP("app", "a", "", "Filter by application name or ID")
-	scanListCmd.Flags().StringP("env", "e", "", "Filter by environment")
-	scanListCmd.Flags().StringP("status", "s", "", "Filter by scan status (STARTED|COMPLETED|ERROR)")
+	scanListCmd.Flags().String("app-match", appMatchSubstring, enumUsage("How --app matches names and IDs", appMatchValues))
+	completeEnum(scanListCmd, "app-match", appMatchValues)
+	scanListCmd.Flags().StringP("env", "e", "", "Filter by environment (comma-separated to match any)")
+	scanListCmd.Flags().String("exclude-env", "", "Exclude environments (comma-separated), applied after --env")
+	scanListCmd.Flags().StringP("status", "s", "", enumUsage("Filter by scan status", scanStatusValues))
+	completeEnum(scanListCmd, "status", scanStatusValues)
+	scanListCmd.Flags().BoolP("watch", "w", false, "Refresh the scan list periodically until interrupted (TTY only)")
+	scanListCmd.Flags().Duration("interval", 15*time.Second, "Refresh interval for --watch")
+	scanListCmd.Flags().Bool("all-orgs", false, "List scans across all organizations you belong to")
+	scanListCmd.Flags().String("limit-scope", "per-org", enumUsage("How --limit applies with --all-orgs or an --org list", limitScopeValues))
+	completeEnum(scanListCmd, "limit-scope", limitScopeValues)
+	scanListCmd.Flags().Bool("totals", false, "Append a totals row to table output")
+	scanListCmd.Flags().Int("page-size", 0, fmt.Sprintf("Scans to request per page (1-%d, 0 = API default)", api.MaxPageSize))
+	scanListCmd.Flags().Bool("no-pagination", false, "Fetch only the first page of scans for a quick look; results may be incomplete")
+	scanListCmd.Flags().Bool("use-last", false, "Render the organization's last fetched scan list again without calling the API")
+	scanListCmd.Flags().Bool("count", false, "Print only the number of matching scans")
+	scanListCmd.Flags().StringP("group-by", "g", "", enumUsage("Group scans under a heading per value", scanGroupByValues))
+	completeEnum(scanListCmd, "group-by", scanGroupByValues)
 
 	// Add flags for scan get command
-	scanGetCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
-	scanGetCmd.Flags().StringP("view", "v", "overview", "View type (overview|stats)")
+	scanGetCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(scanGetCmd, "format", formatsTableJSON)
+	scanGetCmd.Flags().StringP("view", "v", "overview", enumUsage("View type", scanViewNames()))
+	completeEnum(scanGetCmd, "view", scanViewNames())
+	scanGetCmd.Flags().Bool("chart", false, "Render the stats view as a severity bar chart")
+	scanGetCmd.Flags().Int("top", 10, "Number of alerts the findings view shows")
+	scanGetCmd.Flags().String("ids", "", "Comma-separated scan IDs to fetch instead of a single scan")
 
 	// Add flags for scan alerts command
-	scanAlertsCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
-	scanAlertsCmd.Flags().StringP("severity", "s", "", "Filter by severity (High|Medium|Low|Info)")
+	scanAlertsCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(scanAlertsCmd, "format", formatsTableJSON)
+	scanAlertsCmd.Flags().StringP("severity", "s", "", enumUsage("Filter by severity", severityValues))
+	completeEnum(scanAlertsCmd, "severity", severityValues)
 	scanAlertsCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
+	scanAlertsCmd.Flags().StringP("group-by", "g", "", enumUsage("Group alerts", groupByValues))
+	completeEnum(scanAlertsCmd, "group-by", groupByValues)
+	scanAlertsCmd.Flags().Bool("hide-suppressed", false, "Remove alerts matching the suppression list")
+	scanAlertsCmd.Flags().Bool("show-suppressed", false, "Show suppressed alerts annotated as SUPPRESSED (default)")
+	scanAlertsCmd.Flags().Bool("totals", false, "Append a totals row to table output")
+	scanAlertsCmd.Flags().Bool("cwe-names", false, "Show CWE titles alongside IDs in table output")
+	scanAlertsCmd.Flags().Bool("summary", false, "Show one row per alert type with its total URI count")
+	scanAlertsCmd.Flags().String("fail-on", "", enumUsage("Exit with status 1 if any listed alert is this severe or more", severityValues))
+	completeEnum(scanAlertsCmd, "fail-on", severityValues)
+
+	// Add flags for scan compare command
+	scanCompareCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(scanCompareCmd, "format", formatsTableJSON)
+
+	// Add flags for scan diff command
+	scanDiffCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONMarkdown))
+	completeEnum(scanDiffCmd, "format", formatsTableJSONMarkdown)
 }
 
-func runScanList(outputFormat string, limit int, orgID string, appFilter string, envFilter string, statusFilter string) {
-	// Load configuration
-	cfg, err := config.Load()
-	checkError(err)
+// scanListOptions holds the filters applied by scan list
+type scanListOptions struct {
+	Limit      int
+	App        string
+	AppMatch   string // substring (default), exact, or regex; see compileAppMatcher
+	Env        string // comma-separated environments, any of which match
+	ExcludeEnv string // comma-separated environments removed after Env is applied
+	Status     string
+	Totals     bool
+	PageSize   int    // scans per API page; 0 uses the API default
+	FirstPage  bool   // stop after the first page (--no-pagination)
+	UseLast    bool   // render the cached scan list instead of fetching (--use-last)
+	Count      bool   // print the number of matching scans instead of listing them (--count)
+	GroupBy    string // app, env, or status; see scanGroupKey
 
-	// Validate that we have credentials
-	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
-		return
-	}
+	// appMatcher tests names and IDs against App; set by compileAppMatcher
+	appMatcher func(value string) bool
 
-	// Determine which organization to use
-	if orgID == "" {
-		orgID = cfg.OrgID
-		if orgID == "" {
-			fmt.Println("❌ No organization specified. Use --org flag or set a default with 'hawkop org set <org-id>'")
-			return
-		}
-	}
+	// fetched, when set, collects every scan fetched before filtering so the
+	// list can be saved for --use-last
+	fetched *[]api.ApplicationScanResult
+}
 
-	// Create API client
-	client := api.NewClient(cfg)
+// App match modes for --app-match
+const (
+	appMatchSubstring = "substring"
+	appMatchExact     = "exact"
+	appMatchRegex     = "regex"
+)
 
-	// Set default limit to 100 if not specified to show latest scans
-	if limit == 0 {
-		limit = 100
+// compileAppMatcher prepares the --app test for the AppMatch mode. Substring and
+// exact matches ignore case; regex patterns are used as written, so add (?i) for a
+// case-insensitive pattern.
+func (opts *scanListOptions) compileAppMatcher() error {
+	app := opts.App
+	switch strings.ToLower(opts.AppMatch) {
+	case "", appMatchSubstring:
+		appLower := strings.ToLower(app)
+		opts.appMatcher = func(value string) bool { return strings.Contains(strings.ToLower(value), appLower) }
+	case appMatchExact:
+		opts.appMatcher = func(value string) bool { return strings.EqualFold(value, app) }
+	case appMatchRegex:
+		pattern, err := regexp.Compile(app)
+		if err != nil {
+			return fmt.Errorf("invalid --app pattern %q: %w", app, err)
+		}
+		opts.appMatcher = pattern.MatchString
+	default:
+		return fmt.Errorf("unknown --app-match mode: %s. %s", opts.AppMatch, useChoices(opts.AppMatch, appMatchValues))
 	}
+	return nil
+}
 
-	// Get organization scans (API returns sorted by timestamp desc by default)
-	scanResults, err := client.ListOrganizationScans(orgID)
-	if err != nil {
-		fmt.Printf("❌ Failed to list scans: %v\n", err)
+func runScanList(outputFormat string, orgID string, opts scanListOptions, watch bool, interval time.Duration) {
+	if err := opts.compileAppMatcher(); err != nil {
+		failf(exitUsage, "%v", err)
 		return
 	}
-
-	// Apply limit FIRST to get the latest N scans before filtering
-	if len(scanResults) > limit {
-		scanResults = scanResults[:limit]
+	if opts.PageSize < 0 || opts.PageSize > api.MaxPageSize {
+		failf(exitUsage, "--page-size must be between 1 and %d", api.MaxPageSize)
+		return
+	}
+	if opts.GroupBy != "" && !containsString(scanGroupByValues, strings.ToLower(opts.GroupBy)) {
+		failf(exitUsage, "Unknown grouping: %s. %s", opts.GroupBy, useChoices(opts.GroupBy, scanGroupByValues))
+		return
 	}
 
-	// Apply filters to the latest scans
-	filteredResults := []api.ApplicationScanResult{}
-	for _, result := range scanResults {
-		// App filter
-		if appFilter != "" {
-			appFilterLower := strings.ToLower(appFilter)
-			if !strings.Contains(strings.ToLower(result.Scan.ApplicationName), appFilterLower) &&
-				!strings.Contains(strings.ToLower(result.Scan.ApplicationID), appFilterLower) {
-				continue
-			}
-		}
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
 
-		// Environment filter
-		if envFilter != "" && !strings.EqualFold(result.Scan.Env, envFilter) {
-			continue
-		}
+	// Validate that we have credentials; cached scans are rendered without them
+	if !opts.UseLast && !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
 
-		// Status filter
-		if statusFilter != "" && !strings.EqualFold(result.Scan.Status, statusFilter) {
-			continue
-		}
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+	opts.Env = resolveEnv(opts.Env, orgID, cfg)
 
-		filteredResults = append(filteredResults, result)
+	// Set default limit to 100 if not specified to show latest scans
+	if opts.Limit == 0 {
+		opts.Limit = 100
 	}
 
-	// Output based on format
-	switch strings.ToLower(outputFormat) {
-	case "json":
-		outputScansJSON(filteredResults)
-	case "table":
-		outputScansTable(filteredResults)
-	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+	if opts.UseLast {
+		runScanListFromCache(outputFormat, orgID, opts)
 		return
 	}
-}
 
-func runScanGet(scanID string, outputFormat string, view string) {
-	// This will need the specific scan details - for now we'll search through all scans
-	cfg, err := config.Load()
-	checkError(err)
+	// Create API client
+	client := newAPIClient(cfg)
 
-	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+	if watch {
+		runScanListWatch(client, orgID, opts, outputFormat, interval)
 		return
 	}
 
-	orgID := cfg.OrgID
-	if orgID == "" {
-		fmt.Println("❌ No organization configured. Set a default with 'hawkop org set <org-id>'")
+	if opts.Count {
+		runScanCount(client, orgID, opts, outputFormat)
 		return
 	}
 
-	client := api.NewClient(cfg)
-	scanResults, err := client.ListOrganizationScans(orgID)
-	if err != nil {
-		fmt.Printf("❌ Failed to get scan: %v\n", err)
+	// Keep what was fetched so --use-last can render it again later
+	fetched := []api.ApplicationScanResult{}
+	opts.fetched = &fetched
+
+	// JSON streams page by page so output starts immediately, unless it is grouped
+	// or rendered with --template
+	if strings.EqualFold(outputFormat, "json") && opts.GroupBy == "" && !templating() {
+		streamed, err := streamScansJSON(client, orgID, opts)
+		if err != nil {
+			if !reportTimeout(err, fmt.Sprintf("streamed %d scans before the deadline", streamed)) {
+				failf(exitCodeFor(err), "Failed to list scans: %v", err)
+			}
+			return
+		}
+		// A cache that can't be written only costs --use-last its data
+		_ = saveLastScanList(orgID, fetched)
 		return
 	}
 
-	// Find the specific scan
-	var targetScan *api.ApplicationScanResult
-	for _, result := range scanResults {
-		if result.Scan.ID == scanID {
-			targetScan = &result
-			break
-		}
+	filteredResults, err := fetchScanList(client, orgID, opts)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list scans: %v", err)
+		return
 	}
+	_ = saveLastScanList(orgID, fetched)
 
-	if targetScan == nil {
-		fmt.Printf("❌ Scan not found: %s\n", scanID)
+	outputScanList(outputFormat, filteredResults, opts)
+}
+
+// outputScanList renders scan list results in the requested format, grouped by
+// opts.GroupBy when it is set
+func outputScanList(outputFormat string, scanResults []api.ApplicationScanResult, opts scanListOptions) {
+	grouped := opts.GroupBy != ""
+	groups := format.GroupBy(scanResults, scanGroupKey(opts.GroupBy))
+
+	// Like TSV, a template gets one list with each group's scans kept together
+	ordered := make([]api.ApplicationScanResult, 0, len(scanResults))
+	for _, group := range groups {
+		ordered = append(ordered, group.Items...)
+	}
+	if outputTemplated(ordered) {
 		return
 	}
 
-	// Output based on format and view
 	switch strings.ToLower(outputFormat) {
 	case "json":
-		data, err := json.MarshalIndent(targetScan, "", "  ")
+		var v interface{} = scanResults
+		if grouped {
+			v = format.GroupMap(groups)
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
 		if err != nil {
-			fmt.Printf("❌ Failed to format JSON: %v\n", err)
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 			return
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(out, string(data))
 	case "table":
-		outputScanDetailsTable(*targetScan, view)
+		if grouped && len(scanResults) > 0 {
+			outputGroupedTable(groups, "scan", "scans", func(scans []api.ApplicationScanResult) *format.TableWriter {
+				return scansTable(scans, opts.Totals)
+			})
+			return
+		}
+		outputScansTable(scanResults, opts.Totals)
+	case "tsv":
+		// TSV stays one table; grouping just keeps each group's rows together
+		outputTSV(scansTable(ordered, false))
 	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
 	}
 }
 
-func runScanAlerts(scanID string, outputFormat string, severityFilter string, limit int) {
-	cfg, err := config.Load()
-	checkError(err)
-
-	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
-		return
+// scanGroupKey returns the --group-by key of a scan: its application (by name,
+// or ID if unnamed), environment, or status. No grouping puts every scan in one group.
+func scanGroupKey(groupBy string) func(api.ApplicationScanResult) string {
+	switch strings.ToLower(groupBy) {
+	case "app":
+		return func(result api.ApplicationScanResult) string {
+			if result.Scan.ApplicationName != "" {
+				return result.Scan.ApplicationName
+			}
+			return orNA(result.Scan.ApplicationID)
+		}
+	case "env":
+		return func(result api.ApplicationScanResult) string { return orNA(result.Scan.Env) }
+	case "status":
+		return func(result api.ApplicationScanResult) string { return orNA(result.Scan.Status) }
+	default:
+		return func(api.ApplicationScanResult) string { return "" }
 	}
+}
 
-	client := api.NewClient(cfg)
-	alerts, err := client.GetScanAlerts(scanID)
+// fetchScanList retrieves the latest scans for an organization and applies the scan list filters.
+// Pages are followed until the limit is reached, unless opts.FirstPage is set.
+func fetchScanList(client *api.Client, orgID string, opts scanListOptions) ([]api.ApplicationScanResult, error) {
+	// Get organization scans (API returns sorted by timestamp desc by default)
+	scanResults := []api.ApplicationScanResult{}
+	spinner := startSpinner("Fetching scans...")
+	err := client.ForEachOrganizationScanPage(orgID, opts.PageSize, func(page []api.ApplicationScanResult) error {
+		scanResults = append(scanResults, page...)
+		if opts.FirstPage {
+			spinner.Stop()
+			noteFirstPageOnly(len(page), opts.PageSize)
+			return api.ErrStopPaging
+		}
+		if len(scanResults) >= opts.Limit {
+			return api.ErrStopPaging
+		}
+		spinner.Update(fmt.Sprintf("Fetching scans... %d so far", len(scanResults)))
+		return nil
+	})
+	spinner.Stop()
 	if err != nil {
-		fmt.Printf("❌ Failed to get scan alerts: %v\n", err)
+		return nil, err
+	}
+
+	// Apply limit FIRST to get the latest N scans before filtering
+	if len(scanResults) > opts.Limit {
+		scanResults = scanResults[:opts.Limit]
+	}
+	if opts.fetched != nil {
+		*opts.fetched = append(*opts.fetched, scanResults...)
+	}
+
+	return filterScans(scanResults, opts), nil
+}
+
+// runScanCount prints how many scans match the scan list filters. Without filters
+// the API's totalCount answers in one request; filters apply to each scan, so then
+// every page is walked and the matches counted.
+func runScanCount(client *api.Client, orgID string, opts scanListOptions, outputFormat string) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "table" && outputFormat != "json" && outputFormat != "tsv" {
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
 		return
 	}
 
-	// Apply severity filter if specified
-	if severityFilter != "" {
-		filteredAlerts := []api.ScanAlert{}
-		for _, alert := range alerts {
-			if strings.EqualFold(alert.Severity, severityFilter) {
-				filteredAlerts = append(filteredAlerts, alert)
-			}
+	count, ok := 0, false
+	if opts.App == "" && opts.Env == "" && opts.ExcludeEnv == "" && opts.Status == "" {
+		var err error
+		count, ok, err = client.CountOrganizationScans(orgID)
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to count scans: %v", err)
+			return
 		}
-		alerts = filteredAlerts
 	}
 
-	// Apply limit if specified
-	if limit > 0 && len(alerts) > limit {
-		alerts = alerts[:limit]
+	if !ok {
+		err := client.ForEachOrganizationScanPage(orgID, opts.PageSize, func(page []api.ApplicationScanResult) error {
+			for _, result := range page {
+				if scanMatches(result, opts) {
+					count++
+				}
+			}
+			if opts.FirstPage {
+				noteFirstPageOnly(len(page), opts.PageSize)
+				return api.ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to count scans: %v", err)
+			return
+		}
 	}
 
-	// Output based on format
-	switch strings.ToLower(outputFormat) {
-	case "json":
-		outputAlertsJSON(alerts)
-	case "table":
-		outputAlertsTable(alerts)
-	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(struct {
+			Count int `json:"count"`
+		}{count}, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
 	}
+	fmt.Fprintln(out, count)
 }
 
-func outputScansJSON(scanResults []api.ApplicationScanResult) {
-	data, err := json.MarshalIndent(scanResults, "", "  ")
-	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
-		return
+// noteFirstPageOnly warns that --no-pagination may have left scans unlisted when
+// the single page fetched was full
+func noteFirstPageOnly(pageLen int, pageSize int) {
+	if pageSize == 0 {
+		pageSize = api.DefaultPageSize
+	}
+	if pageLen >= pageSize {
+		fmt.Fprintf(errOut, "⚠️  --no-pagination: showing the first page only (%d scans); results may be incomplete\n", pageLen)
 	}
-	fmt.Println(string(data))
 }
 
-func outputScansTable(scanResults []api.ApplicationScanResult) {
-	if len(scanResults) == 0 {
-		fmt.Println("No scans found.")
-		return
+// filterScans applies the app, environment, and status filters to scan results
+func filterScans(scanResults []api.ApplicationScanResult, opts scanListOptions) []api.ApplicationScanResult {
+	if opts.appMatcher == nil {
+		_ = opts.compileAppMatcher()
 	}
 
-	table := format.NewTable("SCAN ID", "APPLICATION", "ENV", "STATUS", "DURATION", "ALERTS", "TIMESTAMP")
-
+	filteredResults := []api.ApplicationScanResult{}
 	for _, result := range scanResults {
-		// Format duration
-		duration := ""
-		if result.ScanDuration != nil {
-			switch v := result.ScanDuration.(type) {
-			case float64:
-				duration = fmt.Sprintf("%.0fs", v)
-			case string:
-				if d, err := strconv.ParseFloat(v, 64); err == nil {
-					duration = fmt.Sprintf("%.0fs", d)
-				} else {
-					duration = v
-				}
-			}
+		if scanMatches(result, opts) {
+			filteredResults = append(filteredResults, result)
 		}
+	}
 
-		// Format alert count
-		alertCount := ""
-		if result.AlertStats != nil {
-			alertCount = fmt.Sprintf("%d", result.AlertStats.Total)
-		}
+	return filteredResults
+}
 
-		// Format timestamp
-		timestamp := ""
-		if result.Scan.Timestamp != "" {
-			if ts, err := strconv.ParseInt(result.Scan.Timestamp, 10, 64); err == nil {
-				timestamp = time.Unix(ts/1000, 0).Format("2006-01-02 15:04")
-			}
+// scanMatches reports whether a scan result passes the app, environment, and status filters
+func scanMatches(result api.ApplicationScanResult, opts scanListOptions) bool {
+	// App filter
+	if opts.App != "" {
+		// Options built without compileAppMatcher are compiled here; an invalid
+		// pattern matches nothing
+		if opts.appMatcher == nil {
+			_ = opts.compileAppMatcher()
 		}
-
-		// Clean up values
-		appName := result.Scan.ApplicationName
-		if appName == "" {
-			appName = "N/A"
+		if opts.appMatcher == nil ||
+			(!opts.appMatcher(result.Scan.ApplicationName) && !opts.appMatcher(result.Scan.ApplicationID)) {
+			return false
 		}
+	}
 
-		env := result.Scan.Env
-		if env == "" {
-			env = "N/A"
-		}
+	// Environment filters: include any listed env, then drop excluded ones
+	if opts.Env != "" && !envListContains(opts.Env, result.Scan.Env) {
+		return false
+	}
+	if opts.ExcludeEnv != "" && envListContains(opts.ExcludeEnv, result.Scan.Env) {
+		return false
+	}
 
-		status := result.Scan.Status
-		if status == "" {
-			status = "N/A"
-		}
+	// Status filter
+	if opts.Status != "" && !strings.EqualFold(result.Scan.Status, opts.Status) {
+		return false
+	}
+
+	return true
+}
 
-		table.AddRow(result.Scan.ID, appName, env, status, duration, alertCount, timestamp)
+// envListContains reports whether env case-insensitively matches an entry in a
+// comma-separated list. Blank entries are ignored.
+func envListContains(list string, env string) bool {
+	for _, entry := range splitCommaList(list) {
+		if strings.EqualFold(entry, env) {
+			return true
+		}
 	}
+	return false
+}
 
-	fmt.Print(table.Render())
+// splitCommaList splits a comma-separated flag value into its trimmed entries,
+// dropping blank ones
+func splitCommaList(list string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
 }
 
-func outputScanDetailsTable(scanResult api.ApplicationScanResult, view string) {
-	switch view {
-	case "overview":
-		table := format.NewTable("FIELD", "VALUE")
-		table.AddRow("Scan ID", scanResult.Scan.ID)
-		table.AddRow("Application", scanResult.Scan.ApplicationName)
-		table.AddRow("Environment", scanResult.Scan.Env)
-		table.AddRow("Status", scanResult.Scan.Status)
+// streamScansJSON writes scans as a JSON array, encoding each page as it is fetched,
+// and returns how many scans were written. Like fetchScanList, the limit applies to
+// the latest scans before filtering.
+func streamScansJSON(client *api.Client, orgID string, opts scanListOptions) (int, error) {
+	writer := format.NewJSONArrayWriter(out)
+	seen := 0
 
-		if scanResult.ScanDuration != nil {
-			switch v := scanResult.ScanDuration.(type) {
-			case float64:
-				table.AddRow("Duration", fmt.Sprintf("%.0fs", v))
-			case string:
-				if d, err := strconv.ParseFloat(v, 64); err == nil {
-					table.AddRow("Duration", fmt.Sprintf("%.0fs", d))
-				} else {
-					table.AddRow("Duration", v)
-				}
+	err := client.ForEachOrganizationScanPage(orgID, opts.PageSize, func(page []api.ApplicationScanResult) error {
+		for _, result := range page {
+			if seen >= opts.Limit {
+				return api.ErrStopPaging
 			}
-		}
-		if scanResult.URLCount != nil {
-			switch v := scanResult.URLCount.(type) {
-			case float64:
-				table.AddRow("URLs Scanned", fmt.Sprintf("%.0f", v))
-			case string:
-				table.AddRow("URLs Scanned", v)
+			seen++
+			if opts.fetched != nil {
+				*opts.fetched = append(*opts.fetched, result)
 			}
-		}
-		if scanResult.PolicyName != "" {
-			table.AddRow("Policy", scanResult.PolicyName)
-		}
 
-		// Format timestamp
-		if scanResult.Scan.Timestamp != "" {
-			if ts, err := strconv.ParseInt(scanResult.Scan.Timestamp, 10, 64); err == nil {
-				timestamp := time.Unix(ts/1000, 0).Format("2006-01-02 15:04:05")
-				table.AddRow("Timestamp", timestamp)
+			if !scanMatches(result, opts) {
+				continue
+			}
+			if err := writer.Write(result); err != nil {
+				return err
 			}
 		}
+		if opts.FirstPage {
+			noteFirstPageOnly(len(page), opts.PageSize)
+			return api.ErrStopPaging
+		}
+		if seen >= opts.Limit {
+			return api.ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		// Leave a partial array unterminated so consumers can't mistake it for a full result
+		return writer.Count(), err
+	}
 
-		fmt.Print(table.Render())
+	return writer.Count(), writer.Close()
+}
 
-	case "stats":
-		if scanResult.AlertStats != nil {
-			table := format.NewTable("SEVERITY", "COUNT")
-			table.AddRow("High", fmt.Sprintf("%d", scanResult.AlertStats.High))
-			table.AddRow("Medium", fmt.Sprintf("%d", scanResult.AlertStats.Medium))
-			table.AddRow("Low", fmt.Sprintf("%d", scanResult.AlertStats.Low))
-			table.AddRow("Info", fmt.Sprintf("%d", scanResult.AlertStats.Info))
-			table.AddRow("Total", fmt.Sprintf("%d", scanResult.AlertStats.Total))
-			fmt.Print(table.Render())
-		} else {
-			fmt.Println("No alert statistics available for this scan.")
-		}
+// orgScanResult is a scan result annotated with the organization it belongs to
+type orgScanResult struct {
+	OrgID   string `json:"orgId"`
+	OrgName string `json:"orgName"`
+	api.ApplicationScanResult
+}
 
-	default:
-		fmt.Printf("❌ Unknown view: %s. Use 'overview' or 'stats'\n", view)
+// runScanListAllOrgs lists scans for every organization the user belongs to, or
+// those orgList names when it isn't empty
+func runScanListAllOrgs(outputFormat string, orgList string, opts scanListOptions, limitScope string) {
+	limitScope = strings.ToLower(limitScope)
+	if limitScope != "per-org" && limitScope != "global" {
+		failf(exitUsage, "Unknown limit scope: %s. %s", limitScope, useChoices(limitScope, limitScopeValues))
+		return
+	}
+	if err := opts.compileAppMatcher(); err != nil {
+		failf(exitUsage, "%v", err)
+		return
 	}
-}
 
-func outputAlertsJSON(alerts []api.ScanAlert) {
-	data, err := json.MarshalIndent(alerts, "", "  ")
-	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
 		return
 	}
-	fmt.Println(string(data))
-}
 
-func outputAlertsTable(alerts []api.ScanAlert) {
-	if len(alerts) == 0 {
-		fmt.Println("No alerts found.")
+	// Set default limit to 100 if not specified to show latest scans
+	if opts.Limit == 0 {
+		opts.Limit = 100
+	}
+
+	// Create API client; all organizations share its rate limiter
+	client := newAPIClient(cfg)
+
+	orgs, err := client.ListOrganizations()
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list organizations: %v", err)
 		return
 	}
 
-	table := format.NewTable("PLUGIN ID", "NAME", "SEVERITY", "URIS", "CWE")
+	var unresolved []itemFailure
+	if orgList != "" {
+		orgs, unresolved = resolveOrgList(orgs, orgList)
+	}
 
-	for _, alert := range alerts {
-		// Clean up values
-		name := alert.Name
-		if name == "" {
-			name = "N/A"
-		}
+	// Organizations that failed, or that --org entries didn't name, are summarized
+	// after the results
+	combined, err := fetchAllOrgScans(client, cfg, orgs, opts)
+	failures := newPartialError("organizations", len(orgs))
+	errors.As(err, &failures)
+	failures.Total += len(unresolved)
+	failures.Failures = append(unresolved, failures.Failures...)
 
-		severity := alert.Severity
-		if severity == "" {
-			severity = "N/A"
-		}
+	// Merge organizations into a single timeline, most recent first
+	sort.SliceStable(combined, func(i, j int) bool {
+		ti, _ := strconv.ParseInt(combined[i].Scan.Timestamp, 10, 64)
+		tj, _ := strconv.ParseInt(combined[j].Scan.Timestamp, 10, 64)
+		return ti > tj
+	})
 
-		uriCount := ""
-		if alert.URICount > 0 {
-			uriCount = fmt.Sprintf("%d", alert.URICount)
-		} else {
-			uriCount = "0"
-		}
+	if limitScope == "global" && len(combined) > opts.Limit {
+		combined = combined[:opts.Limit]
+	}
 
-		cwe := alert.CWEID
-		if cwe == "" {
-			cwe = "N/A"
-		}
+	if outputTemplated(combined) {
+		return
+	}
 
-		table.AddRow(alert.PluginID, name, severity, uriCount, cwe)
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		data, err := json.MarshalIndent(combined, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "table":
+		outputOrgScansTable(combined, opts.Totals)
+	case "tsv":
+		outputTSV(orgScansTable(combined, false))
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
+		return
+	}
+
+	reportPartialFailures(failures, "list scans")
+}
+
+// fetchAllOrgScans lists scans in each organization. An organization that fails,
+// e.g. with 403 where the user lacks access, doesn't stop the others: its error is
+// collected in the returned *partialError alongside the scans that were listed.
+// Organizations skipped after a timeout don't count toward the total.
+func fetchAllOrgScans(client *api.Client, cfg *config.Config, orgs []api.Organization, opts scanListOptions) ([]orgScanResult, error) {
+	failures := newPartialError("organizations", len(orgs))
+	combined := []orgScanResult{}
+	for i, org := range orgs {
+		// Each organization's default environment applies to its own scans
+		orgOpts := opts
+		orgOpts.Env = resolveEnv(opts.Env, org.ID, cfg)
+
+		scanResults, err := fetchScanList(client, org.ID, orgOpts)
+		if reportTimeout(err, fmt.Sprintf("showing scans from %d of %d organizations", i, len(orgs))) {
+			failures.Total = i
+			break
+		}
+		if err != nil {
+			failures.add(org.ID, org.Name, err)
+			continue
+		}
+		for _, result := range scanResults {
+			combined = append(combined, orgScanResult{OrgID: org.ID, OrgName: org.Name, ApplicationScanResult: result})
+		}
+	}
+	return combined, failures.errOrNil()
+}
+
+func outputOrgScansTable(scanResults []orgScanResult, totals bool) {
+	if len(scanResults) == 0 {
+		fmt.Fprintln(errOut, "No scans found.")
+		return
+	}
+
+	fmt.Fprint(out, renderTable(orgScansTable(scanResults, totals)))
+}
+
+// orgScansTable is scansTable with a leading ORG column for --all-orgs
+func orgScansTable(scanResults []orgScanResult, totals bool) *format.TableWriter {
+	table := format.NewTable("ORG", "SCAN ID", "APPLICATION", "ENV", "STATUS", "DURATION", "ALERTS", "TIMESTAMP")
+
+	for _, result := range scanResults {
+		orgName := result.OrgName
+		if orgName == "" {
+			orgName = result.OrgID
+		}
+		table.AddRow(append([]string{orgName}, scanTableRow(result.ApplicationScanResult)...)...)
+	}
+	if totals {
+		table.AddFooter(table.Totals("TOTAL", "ALERTS")...)
+	}
+
+	return table
+}
+
+// runScanListWatch re-renders the scan list table every interval until interrupted
+func runScanListWatch(client *api.Client, orgID string, opts scanListOptions, outputFormat string, interval time.Duration) {
+	if !stdoutIsTerminal() {
+		failf(exitUsage, "--watch requires an interactive terminal")
+		return
+	}
+	if strings.ToLower(outputFormat) != "table" {
+		failf(exitUsage, "--watch only supports table format")
+		return
+	}
+	if interval <= 0 {
+		failf(exitUsage, "--interval must be greater than zero")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(operationCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Bind the client to ctx as well, so Ctrl-C also ends a fetch or retry wait
+	client.SetContext(ctx)
+	watchScanList(ctx, client, orgID, opts, interval)
+}
+
+// watchScanList renders the scan list every interval until ctx is done. A failed
+// refresh is reported in place of the table without setting the exit code, since
+// the next one may succeed.
+func watchScanList(ctx context.Context, client *api.Client, orgID string, opts scanListOptions, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Each cycle goes through the same client, so the rate limiter still applies.
+		// Clear its list cache so every refresh fetches new scans.
+		client.ClearListCache()
+		filteredResults, err := fetchScanList(client, orgID, opts)
+		if ctx.Err() != nil {
+			fmt.Fprintln(out)
+			return
+		}
+
+		fmt.Fprint(out, "\033[H\033[2J")
+		fmt.Fprintf(out, "Every %s: hawkop scan list    Last refresh: %s    (Ctrl-C to exit)\n\n",
+			interval, format.TimeIn(time.Now().UnixMilli(), displayLocation).Format("2006-01-02 15:04:05"))
+		if err != nil {
+			fmt.Fprintf(errOut, "⚠️  Failed to list scans: %v\n", err)
+		} else {
+			outputScansTable(filteredResults, opts.Totals)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runScanGet(scanID string, outputFormat string, view string, chart bool, top int) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+	findingsView := strings.EqualFold(view, "findings")
+	if findingsView && top < 1 {
+		failf(exitUsage, "--top must be at least 1")
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	orgID, ok := resolveOrgID("", cfg)
+	if !ok {
+		return
+	}
+
+	client := newAPIClient(cfg)
+	targetScan, err := findScan(client, orgID, scanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan: %v", err)
+		return
+	}
+
+	if targetScan == nil {
+		failf(exitNotFound, "Scan not found: %s", scanID)
+		return
+	}
+
+	if findingsView {
+		outputScanWithTopFindings(client, *targetScan, outputFormat, top)
+		return
+	}
+
+	// Output based on format and view
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		data, err := json.MarshalIndent(targetScan, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "table":
+		outputScanDetailsTable(*targetScan, view, chart)
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+// runScanGetByIDs fetches the listed scans, in order, and reports the IDs that weren't found
+func runScanGetByIDs(ids []string, outputFormat string) {
+	if len(ids) == 0 {
+		failf(exitUsage, "--ids needs at least one scan ID")
+		return
+	}
+	if !validIDs("scan", ids...) {
+		return
+	}
+
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "table" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	orgID, ok := resolveOrgID("", cfg)
+	if !ok {
+		return
+	}
+
+	client := newAPIClient(cfg)
+	scanResults, err := client.GetScansByIDs(orgID, ids)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scans: %v", err)
+		return
+	}
+
+	if outputFormat == "json" {
+		outputScansJSON(scanResults)
+	} else {
+		outputScansTable(scanResults, false)
+	}
+
+	if missing := missingScanIDs(ids, scanResults); len(missing) > 0 {
+		fmt.Fprintf(errOut, "⚠️  Scans not found: %s\n", strings.Join(missing, ", "))
+	}
+}
+
+// missingScanIDs returns the requested IDs that have no matching scan, in request order
+func missingScanIDs(ids []string, scanResults []api.ApplicationScanResult) []string {
+	found := make(map[string]bool, len(scanResults))
+	for _, result := range scanResults {
+		found[result.Scan.ID] = true
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+			found[id] = true
+		}
+	}
+	return missing
+}
+
+// findScan fetches a scan by ID, falling back to searching the organization's scan
+// list when the direct lookup 404s. It returns nil if the scan isn't found.
+func findScan(client *api.Client, orgID string, scanID string) (*api.ApplicationScanResult, error) {
+	scan, err := client.GetScan(scanID)
+	if err == nil {
+		return scan, nil
+	}
+	if !errors.Is(err, api.ErrNotFound) {
+		return nil, err
+	}
+
+	scanResults, err := client.ListOrganizationScans(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range scanResults {
+		if scanResults[i].Scan.ID == scanID {
+			return &scanResults[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// scanAlertsOptions holds the filters and grouping applied by scan alerts
+type scanAlertsOptions struct {
+	Severity       string
+	Limit          int
+	GroupBy        string
+	HideSuppressed bool
+	Totals         bool
+	CWENames       bool
+	Summary        bool
+	// FailOn is the severity at or above which listed alerts fail the command
+	FailOn string
+}
+
+// alertSummary collapses the alerts of a scan that share a plugin
+type alertSummary struct {
+	PluginID string `json:"pluginId"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	URICount int    `json:"uriCount"`
+	// Suppressed is set when every alert for the plugin is suppressed
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// cweGroup aggregates the alerts of a scan that share a CWE
+type cweGroup struct {
+	CWEID       string   `json:"cweId"`
+	URICount    int      `json:"uriCount"`
+	PluginCount int      `json:"pluginCount"`
+	PluginIDs   []string `json:"pluginIds"`
+}
+
+// uncategorizedCWE is the bucket for alerts without a CWE
+const uncategorizedCWE = "uncategorized"
+
+func runScanAlerts(scanID string, outputFormat string, opts scanAlertsOptions) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+	groupBy := strings.ToLower(opts.GroupBy)
+	if groupBy != "" && groupBy != "cwe" {
+		failf(exitUsage, "Unknown grouping: %s. %s", opts.GroupBy, useChoices(opts.GroupBy, groupByValues))
+		return
+	}
+	if opts.Summary && groupBy != "" {
+		failf(exitUsage, "--summary and --group-by cannot be used together")
+		return
+	}
+	if !validSeverityFilter(opts.Severity) || !validSeverityFilter(opts.FailOn) {
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Load the suppression list before fetching so a bad file fails fast
+	suppressions, err := suppress.Load()
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	client := newAPIClient(cfg)
+	scan, alerts, err := client.GetScanWithAlerts(scanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan alerts: %v", err)
+		return
+	}
+
+	// Annotate or remove suppressed alerts
+	alerts = applySuppressions(alerts, scan, suppressions, opts.HideSuppressed)
+
+	// Apply severity filter if specified
+	alerts = filterAlertsBySeverity(alerts, opts.Severity)
+
+	// Checked once the alerts are listed, so the failure is the last thing printed
+	if opts.FailOn != "" {
+		defer failOnSeverity(alerts, opts.FailOn)
+	}
+
+	if groupBy == "cwe" {
+		groups := groupAlertsByCWE(alerts)
+
+		// Apply limit to the groups if specified
+		if opts.Limit > 0 && len(groups) > opts.Limit {
+			groups = groups[:opts.Limit]
+		}
+
+		switch strings.ToLower(outputFormat) {
+		case "json":
+			outputCWEGroupsJSON(groups)
+		case "table":
+			outputCWEGroupsTable(groups, opts)
+		default:
+			failUnknownFormat(outputFormat, formatsTableJSON)
+		}
+		return
+	}
+
+	if opts.Summary {
+		summaries := summarizeAlerts(alerts)
+
+		// Apply limit to the summary rows if specified
+		if opts.Limit > 0 && len(summaries) > opts.Limit {
+			summaries = summaries[:opts.Limit]
+		}
+
+		switch strings.ToLower(outputFormat) {
+		case "json":
+			outputAlertSummariesJSON(summaries)
+		case "table":
+			outputAlertSummariesTable(summaries, opts)
+		default:
+			failUnknownFormat(outputFormat, formatsTableJSON)
+		}
+		return
+	}
+
+	// Apply limit if specified
+	if opts.Limit > 0 && len(alerts) > opts.Limit {
+		alerts = alerts[:opts.Limit]
+	}
+
+	// Output based on format
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		outputAlertsJSON(alerts)
+	case "table":
+		outputAlertsTable(alerts, opts)
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSON)
+	}
+}
+
+// failOnSeverity fails the command when any alert that isn't suppressed is at least
+// as severe as threshold, listing those alerts most severe first
+func failOnSeverity(alerts []api.ScanAlert, threshold string) {
+	var triggered []api.ScanAlert
+	for _, alert := range filterAlertsByMinSeverity(alerts, threshold) {
+		if !alert.Suppressed {
+			triggered = append(triggered, alert)
+		}
+	}
+	if len(triggered) == 0 {
+		return
+	}
+
+	failf(exitError, "%d alert(s) at or above %s severity (--fail-on):", len(triggered), api.NormalizeSeverity(threshold))
+	for _, alert := range sortedBySeverity(triggered) {
+		fmt.Fprintf(errOut, "  %s  %s  %s\n", api.NormalizeSeverity(alert.Severity), alert.PluginID, alert.Name)
+	}
+}
+
+// filterAlertsBySeverity keeps the alerts with the given severity, in any case; an
+// empty severity keeps all
+func filterAlertsBySeverity(alerts []api.ScanAlert, severity string) []api.ScanAlert {
+	if severity == "" {
+		return alerts
+	}
+
+	severity = api.NormalizeSeverity(severity)
+	filtered := []api.ScanAlert{}
+	for _, alert := range alerts {
+		if api.NormalizeSeverity(alert.Severity) == severity {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered
+}
+
+// applySuppressions marks alerts matching the suppression list, removing them when hide is set
+func applySuppressions(alerts []api.ScanAlert, scan *api.Scan, suppressions *suppress.List, hide bool) []api.ScanAlert {
+	result := make([]api.ScanAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		if _, ok := suppressions.Match(alert.PluginID, scan.ApplicationID, scan.ApplicationName, scan.Env); ok {
+			if hide {
+				continue
+			}
+			alert.Suppressed = true
+		}
+		result = append(result, alert)
+	}
+	return result
+}
+
+func runScanBaselineSet(app string, env string, scanID string) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+
+	// Load existing config
+	cfg, err := loadConfig()
+	checkError(err)
+
+	cfg.SetBaseline(app, env, scanID)
+
+	// Save configuration
+	if err := saveConfig(cfg); err != nil {
+		failf(exitCodeFor(err), "Failed to save baseline: %v", err)
+		return
+	}
+
+	fmt.Fprintf(errOut, "✅ Baseline for %s (%s) set to scan: %s\n", app, env, scanID)
+}
+
+// alertDiff is the result of comparing a scan's alerts against its baseline
+type alertDiff struct {
+	BaselineScanID string          `json:"baselineScanId"`
+	ScanID         string          `json:"scanId"`
+	New            []api.ScanAlert `json:"new"`
+	Resolved       []api.ScanAlert `json:"resolved"`
+	Unchanged      []api.ScanAlert `json:"unchanged"`
+}
+
+func runScanCompare(scanID string, outputFormat string) {
+	if !validIDs("scan", scanID) {
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	suppressions, err := suppress.Load()
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	client := newAPIClient(cfg)
+	scan, alerts, err := client.GetScanWithAlerts(scanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan alerts: %v", err)
+		return
+	}
+
+	baseline, ok := cfg.FindBaseline(scan.ApplicationID, scan.ApplicationName, scan.Env)
+	if !ok {
+		failf(exitNotFound, "No baseline set for %s (%s). Use 'hawkop scan baseline set <app> <env> <scan-id>'", scan.ApplicationName, scan.Env)
+		return
+	}
+
+	_, baselineAlerts, err := client.GetScanWithAlerts(baseline.ScanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get baseline scan alerts: %v", err)
+		return
+	}
+
+	baselineFindings := fetchDiffFindings(client, baseline.ScanID, applySuppressions(baselineAlerts, scan, suppressions, false))
+	findings := fetchDiffFindings(client, scanID, applySuppressions(alerts, scan, suppressions, false))
+	diff := diffAlerts(baselineFindings, findings)
+	diff.BaselineScanID = baseline.ScanID
+	diff.ScanID = scanID
+	outputAlertDiff(diff, outputFormat, formatsTableJSON)
+}
+
+func runScanDiff(baseScanID, scanID string, outputFormat string) {
+	if !validIDs("scan", baseScanID, scanID) {
+		return
+	}
+	if !slices.Contains(formatsTableJSONMarkdown, strings.ToLower(outputFormat)) {
+		failUnknownFormat(outputFormat, formatsTableJSONMarkdown)
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	suppressions, err := suppress.Load()
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	client := newAPIClient(cfg)
+	baseScan, baseAlerts, err := client.GetScanWithAlerts(baseScanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get base scan alerts: %v", err)
+		return
+	}
+	scan, alerts, err := client.GetScanWithAlerts(scanID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan alerts: %v", err)
+		return
+	}
+
+	baseFindings := fetchDiffFindings(client, baseScanID, applySuppressions(baseAlerts, baseScan, suppressions, false))
+	findings := fetchDiffFindings(client, scanID, applySuppressions(alerts, scan, suppressions, false))
+	diff := diffAlerts(baseFindings, findings)
+	diff.BaselineScanID = baseScanID
+	diff.ScanID = scanID
+	outputAlertDiff(diff, outputFormat, formatsTableJSONMarkdown)
+}
+
+// outputAlertDiff prints diff in one of formats, then fails if it has new High
+// alerts that aren't suppressed
+func outputAlertDiff(diff alertDiff, outputFormat string, formats []string) {
+	if !slices.Contains(formats, strings.ToLower(outputFormat)) {
+		failUnknownFormat(outputFormat, formats)
+		return
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "markdown":
+		fmt.Fprint(out, alertDiffMarkdown(diff))
+	case "table":
+		outputAlertDiffTable(diff)
+	}
+
+	if highs := countNewHighAlerts(diff); highs > 0 {
+		failf(exitError, "%d new High alert(s) since baseline scan %s", highs, diff.BaselineScanID)
+	}
+}
+
+// fetchDiffFindings fetches the findings of each of a scan's alerts for diffAlerts.
+// An alert whose findings can't be fetched is kept without them, with a warning.
+func fetchDiffFindings(client *api.Client, scanID string, alerts []api.ScanAlert) []api.AlertFindings {
+	spinner := startSpinner(fmt.Sprintf("Fetching findings for scan %s...", scanID))
+	defer spinner.Stop()
+
+	results := client.FetchAlertFindings(scanID, alerts, api.DefaultAlertConcurrency)
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(spinner, "⚠️  Comparing plugin %s of scan %s by plugin ID: %v\n", result.Alert.PluginID, scanID, result.Err)
+		}
+	}
+	return results
+}
+
+// diffAlerts compares two scans' alerts by the fingerprints of their findings, so
+// one plugin reported at different URIs isn't taken for the same finding. An
+// alert is listed as new for its findings only in current, as resolved for those
+// only in baseline, and as unchanged for those in both, with its URI count set to
+// the number of findings in that list, so it can be listed more than once. Alerts
+// without findings on either side are compared by plugin ID alone.
+func diffAlerts(baseline []api.AlertFindings, current []api.AlertFindings) alertDiff {
+	baselineByPlugin := make(map[string]api.AlertFindings, len(baseline))
+	for _, entry := range baseline {
+		baselineByPlugin[entry.Alert.PluginID] = entry
+	}
+	currentByPlugin := make(map[string]api.AlertFindings, len(current))
+	for _, entry := range current {
+		currentByPlugin[entry.Alert.PluginID] = entry
+	}
+
+	diff := alertDiff{New: []api.ScanAlert{}, Resolved: []api.ScanAlert{}, Unchanged: []api.ScanAlert{}}
+	for _, entry := range current {
+		base, ok := baselineByPlugin[entry.Alert.PluginID]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, entry.Alert)
+		case len(entry.Findings) == 0 || len(base.Findings) == 0:
+			diff.Unchanged = append(diff.Unchanged, entry.Alert)
+		default:
+			added, kept := splitFindings(entry.Findings, base.Findings)
+			if added > 0 {
+				alert := entry.Alert
+				alert.URICount = added
+				diff.New = append(diff.New, alert)
+			}
+			if kept > 0 {
+				alert := entry.Alert
+				alert.URICount = kept
+				diff.Unchanged = append(diff.Unchanged, alert)
+			}
+		}
+	}
+	for _, entry := range baseline {
+		cur, ok := currentByPlugin[entry.Alert.PluginID]
+		switch {
+		case !ok:
+			diff.Resolved = append(diff.Resolved, entry.Alert)
+		case len(entry.Findings) > 0 && len(cur.Findings) > 0:
+			if removed, _ := splitFindings(entry.Findings, cur.Findings); removed > 0 {
+				alert := entry.Alert
+				alert.URICount = removed
+				diff.Resolved = append(diff.Resolved, alert)
+			}
+		}
+	}
+	return diff
+}
+
+// splitFindings counts the distinct findings, by fingerprint, that are missing
+// from others and that others also has
+func splitFindings(findings []api.ScanAlertFinding, others []api.ScanAlertFinding) (missing int, shared int) {
+	known := make(map[string]bool, len(others))
+	for _, finding := range others {
+		known[finding.Fingerprint()] = true
+	}
+	seen := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		fingerprint := finding.Fingerprint()
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+		if known[fingerprint] {
+			shared++
+		} else {
+			missing++
+		}
+	}
+	return missing, shared
+}
+
+// countNewHighAlerts counts new High severity alerts that are not suppressed
+func countNewHighAlerts(diff alertDiff) int {
+	count := 0
+	for _, alert := range diff.New {
+		if api.NormalizeSeverity(alert.Severity) == api.SeverityHigh && !alert.Suppressed {
+			count++
+		}
+	}
+	return count
+}
+
+func outputAlertDiffTable(diff alertDiff) {
+	fmt.Fprintf(errOut, "Comparing scan %s against baseline %s: %d new, %d resolved\n", diff.ScanID, diff.BaselineScanID, len(diff.New), len(diff.Resolved))
+	if len(diff.New) == 0 && len(diff.Resolved) == 0 {
+		fmt.Fprintln(errOut, "No alert changes since baseline.")
+		return
+	}
+
+	table := format.NewTable("CHANGE", "PLUGIN ID", "NAME", "SEVERITY", "URIS")
+
+	addRows := func(change string, alerts []api.ScanAlert) {
+		for _, alert := range alerts {
+			name := alert.Name
+			if name == "" {
+				name = "N/A"
+			}
+			if alert.Suppressed {
+				name += " (SUPPRESSED)"
+			}
+
+			severity := alert.Severity
+			if severity == "" {
+				severity = "N/A"
+			}
+
+			table.AddRow(change, alert.PluginID, name, severity, format.Number(int64(alert.URICount)))
+		}
+	}
+	addRows("NEW", diff.New)
+	addRows("RESOLVED", diff.Resolved)
+
+	fmt.Fprint(out, renderTable(table))
+}
+
+// alertDiffMarkdown renders diff as a Markdown heading, summary line, and table of
+// every alert, for a pull request comment
+func alertDiffMarkdown(diff alertDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Scan `%s` compared to `%s`\n\n", diff.ScanID, diff.BaselineScanID)
+	fmt.Fprintf(&b, "%s\n", alertDiffSummary(diff))
+	if len(diff.New) == 0 && len(diff.Resolved) == 0 && len(diff.Unchanged) == 0 {
+		return b.String()
+	}
+
+	table := format.NewTable("CHANGE", "PLUGIN ID", "NAME", "SEVERITY", "URIS")
+	addRows := func(change string, alerts []api.ScanAlert) {
+		for _, alert := range alerts {
+			name := alert.Name
+			if name == "" {
+				name = "N/A"
+			}
+			if alert.Suppressed {
+				name += " (suppressed)"
+			}
+			severity := api.NormalizeSeverity(alert.Severity)
+			if severity == "" {
+				severity = "N/A"
+			}
+			table.AddRow(change, alert.PluginID, name, severity, format.Number(int64(alert.URICount)))
+		}
+	}
+	addRows(emojiPrefix("🔺", "New"), sortedBySeverity(diff.New))
+	addRows(emojiPrefix("✅", "Resolved"), sortedBySeverity(diff.Resolved))
+	addRows("Unchanged", sortedBySeverity(diff.Unchanged))
+
+	b.WriteString("\n")
+	b.WriteString(table.Markdown())
+	return b.String()
+}
+
+// alertDiffSummary returns a one-line summary of diff, such as
+// "🔺 2 new High, 1 new Low, ✅ 1 resolved, 3 unchanged". Suppressed new alerts
+// are counted apart so they don't read as regressions.
+func alertDiffSummary(diff alertDiff) string {
+	if len(diff.New) == 0 && len(diff.Resolved) == 0 {
+		return emojiPrefix("✅", fmt.Sprintf("No alert changes, %d unchanged", len(diff.Unchanged)))
+	}
+
+	var active []api.ScanAlert
+	for _, alert := range diff.New {
+		if !alert.Suppressed {
+			active = append(active, alert)
+		}
+	}
+	counts := countSeverities(active)
+
+	var parts []string
+	for _, c := range []struct {
+		n        int
+		severity string
+	}{
+		{counts.High, api.SeverityHigh},
+		{counts.Medium, api.SeverityMedium},
+		{counts.Low, api.SeverityLow},
+		{counts.Info, api.SeverityInfo},
+		{counts.Total - counts.High - counts.Medium - counts.Low - counts.Info, "of other severity"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d new %s", c.n, c.severity))
+		}
+	}
+	if len(parts) > 0 {
+		parts[0] = emojiPrefix("🔺", parts[0])
+	}
+	if suppressed := len(diff.New) - len(active); suppressed > 0 {
+		parts = append(parts, fmt.Sprintf("%d new suppressed", suppressed))
+	}
+	if len(diff.Resolved) > 0 {
+		parts = append(parts, emojiPrefix("✅", fmt.Sprintf("%d resolved", len(diff.Resolved))))
+	}
+	parts = append(parts, fmt.Sprintf("%d unchanged", len(diff.Unchanged)))
+	return strings.Join(parts, ", ")
+}
+
+// sortedBySeverity returns a copy of alerts ordered most severe first, keeping the
+// API's order within a severity
+func sortedBySeverity(alerts []api.ScanAlert) []api.ScanAlert {
+	sorted := slices.Clone(alerts)
+	slices.SortStableFunc(sorted, func(a, b api.ScanAlert) int {
+		return severityRank(a.Severity) - severityRank(b.Severity)
+	})
+	return sorted
+}
+
+// groupAlertsByCWE aggregates alerts by CWE, summing URI counts and collecting plugin IDs.
+// Groups are ordered by total URI count, largest first.
+func groupAlertsByCWE(alerts []api.ScanAlert) []cweGroup {
+	index := make(map[string]int)
+	groups := []cweGroup{}
+
+	for _, alert := range alerts {
+		cwe := strings.TrimSpace(alert.CWEID)
+		if cwe == "" {
+			cwe = uncategorizedCWE
+		}
+
+		i, ok := index[cwe]
+		if !ok {
+			i = len(groups)
+			index[cwe] = i
+			groups = append(groups, cweGroup{CWEID: cwe, PluginIDs: []string{}})
+		}
+
+		groups[i].URICount += alert.URICount
+		if !containsString(groups[i].PluginIDs, alert.PluginID) {
+			groups[i].PluginIDs = append(groups[i].PluginIDs, alert.PluginID)
+			groups[i].PluginCount++
+		}
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		if groups[a].URICount != groups[b].URICount {
+			return groups[a].URICount > groups[b].URICount
+		}
+		return groups[a].CWEID < groups[b].CWEID
+	})
+
+	return groups
+}
+
+// severityCounts tallies a set of alerts by severity. Total also counts alerts
+// whose severity is missing or unrecognized.
+type severityCounts struct {
+	High   int
+	Medium int
+	Low    int
+	Info   int
+	Total  int
+}
+
+// countSeverities tallies the given alerts, so the counts match what was displayed
+// rather than the scan's overall AlertStats
+func countSeverities(alerts []api.ScanAlert) severityCounts {
+	var counts severityCounts
+	for _, alert := range alerts {
+		switch severityRank(alert.Severity) {
+		case 0:
+			counts.High++
+		case 1:
+			counts.Medium++
+		case 2:
+			counts.Low++
+		case 3:
+			counts.Info++
+		}
+		counts.Total++
+	}
+	return counts
+}
+
+func (c severityCounts) String() string {
+	return fmt.Sprintf("Total: %d (High: %d, Medium: %d, Low: %d, Info: %d)", c.Total, c.High, c.Medium, c.Low, c.Info)
+}
+
+// severityRank orders severities from most to least severe; unknown values sort last
+func severityRank(severity string) int {
+	switch api.NormalizeSeverity(severity) {
+	case api.SeverityHigh:
+		return 0
+	case api.SeverityMedium:
+		return 1
+	case api.SeverityLow:
+		return 2
+	case api.SeverityInfo:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// validSeverityFilter reports whether a --severity value names a known severity in
+// any case, reporting it otherwise. An empty filter is valid.
+func validSeverityFilter(severity string) bool {
+	if severity == "" || severityRank(severity) < len(api.Severities) {
+		return true
+	}
+	failf(exitUsage, "Unknown severity: %s. %s", severity, useChoices(severity, severityValues))
+	return false
+}
+
+// summarizeAlerts collapses alerts by plugin, summing URI counts and keeping the
+// most severe rating. Summaries are ordered by severity, then URI count, largest first.
+func summarizeAlerts(alerts []api.ScanAlert) []alertSummary {
+	index := make(map[string]int)
+	summaries := []alertSummary{}
+
+	for _, alert := range alerts {
+		i, ok := index[alert.PluginID]
+		if !ok {
+			i = len(summaries)
+			index[alert.PluginID] = i
+			summaries = append(summaries, alertSummary{
+				PluginID:   alert.PluginID,
+				Name:       alert.Name,
+				Severity:   alert.Severity,
+				Suppressed: true,
+			})
+		}
+
+		summary := &summaries[i]
+		summary.URICount += alert.URICount
+		summary.Suppressed = summary.Suppressed && alert.Suppressed
+		if severityRank(alert.Severity) < severityRank(summary.Severity) {
+			summary.Severity = alert.Severity
+		}
+		if summary.Name == "" {
+			summary.Name = alert.Name
+		}
+	}
+
+	sort.SliceStable(summaries, func(a, b int) bool {
+		rankA, rankB := severityRank(summaries[a].Severity), severityRank(summaries[b].Severity)
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		if summaries[a].URICount != summaries[b].URICount {
+			return summaries[a].URICount > summaries[b].URICount
+		}
+		return summaries[a].Name < summaries[b].Name
+	})
+
+	return summaries
+}
+
+func outputAlertSummariesJSON(summaries []alertSummary) {
+	data, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputAlertSummariesTable(summaries []alertSummary, opts scanAlertsOptions) {
+	if len(summaries) == 0 {
+		fmt.Fprintln(errOut, "No alerts found.")
+		return
+	}
+
+	table := format.NewTable("NAME", "SEVERITY", "URIS")
+
+	for _, summary := range summaries {
+		name := summary.Name
+		if name == "" {
+			name = "N/A"
+		}
+		if summary.Suppressed {
+			name += " (SUPPRESSED)"
+		}
+
+		severity := summary.Severity
+		if severity == "" {
+			severity = "N/A"
+		}
+
+		table.AddRow(name, severity, format.Number(int64(summary.URICount)))
+	}
+	if opts.Totals {
+		table.AddFooter(table.Totals("TOTAL", "URIS")...)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func outputCWEGroupsJSON(groups []cweGroup) {
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputCWEGroupsTable(groups []cweGroup, opts scanAlertsOptions) {
+	if len(groups) == 0 {
+		fmt.Fprintln(errOut, "No alerts found.")
+		return
+	}
+
+	table := format.NewTable("CWE", "URIS", "PLUGINS", "PLUGIN IDS")
+
+	for _, group := range groups {
+		cweID := group.CWEID
+		if opts.CWENames {
+			cweID = cwe.Label(cweID)
+		}
+		table.AddRow(cweID, format.Number(int64(group.URICount)), format.Number(int64(group.PluginCount)), strings.Join(group.PluginIDs, ", "))
+	}
+	if opts.Totals {
+		table.AddFooter(table.Totals("TOTAL", "URIS", "PLUGINS")...)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+}
+
+func outputScansJSON(scanResults []api.ApplicationScanResult) {
+	data, err := json.MarshalIndent(scanResults, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputScansTable(scanResults []api.ApplicationScanResult, totals bool) {
+	if len(scanResults) == 0 {
+		fmt.Fprintln(errOut, "No scans found.")
+		return
+	}
+
+	fmt.Fprint(out, renderTable(scansTable(scanResults, totals)))
+}
+
+// scansTable lays out scans for table and tsv output, with an ALERTS total when totals is set
+func scansTable(scanResults []api.ApplicationScanResult, totals bool) *format.TableWriter {
+	table := format.NewTable("SCAN ID", "APPLICATION", "ENV", "STATUS", "DURATION", "ALERTS", "TIMESTAMP")
+
+	for _, result := range scanResults {
+		table.AddRow(scanTableRow(result)...)
+	}
+	if totals {
+		table.AddFooter(table.Totals("TOTAL", "ALERTS")...)
+	}
+
+	return table
+}
+
+// scanTableRow formats a scan result as the SCAN ID through TIMESTAMP columns
+func scanTableRow(result api.ApplicationScanResult) []string {
+	// Format duration
+	duration := result.ScanDuration.String()
+	if d, ok := format.ParseDuration(result.ScanDuration); ok {
+		duration = format.FormatDuration(d)
+	}
+
+	// Format alert count
+	alertCount := ""
+	if result.AlertStats != nil {
+		alertCount = format.Number(int64(result.AlertStats.Total))
+	}
+
+	// Format timestamp
+	timestamp := ""
+	if result.Scan.Timestamp != "" {
+		if ts, err := strconv.ParseInt(result.Scan.Timestamp, 10, 64); err == nil {
+			timestamp = format.FormatTimestamp(ts, displayLocation)
+		}
+	}
+
+	// Clean up values
+	appName := result.Scan.ApplicationName
+	if appName == "" {
+		appName = "N/A"
+	}
+
+	env := result.Scan.Env
+	if env == "" {
+		env = "N/A"
+	}
+
+	status := result.Scan.Status
+	if status == "" {
+		status = "N/A"
+	}
+
+	return []string{result.Scan.ID, appName, env, status, duration, alertCount, timestamp}
+}
+
+// scanSection renders one independent section of the scan details view.
+// render returns false when the scan has no data for the section.
+type scanSection struct {
+	name    string
+	title   string
+	missing string
+	render  func(scanResult api.ApplicationScanResult, chart bool) (string, bool)
+}
+
+// scanSections lists the scan detail sections in the order --view all prints them
+var scanSections = []scanSection{
+	{name: "overview", title: "Overview", missing: "No overview available for this scan.", render: renderScanOverview},
+	{name: "stats", title: "Alert Statistics", missing: "No alert statistics available for this scan.", render: renderScanStats},
+	{name: "tags", title: "Tags", missing: "No tags for this scan.", render: renderScanTags},
+}
+
+// scanViewNames returns the accepted --view values. The findings view isn't a
+// section because it needs another request; see outputScanWithTopFindings.
+func scanViewNames() []string {
+	names := make([]string, 0, len(scanSections)+2)
+	for _, section := range scanSections {
+		names = append(names, section.name)
+	}
+	return append(names, "findings", "all")
+}
+
+// scanWithTopFindings is what scan get --view findings prints as JSON
+type scanWithTopFindings struct {
+	Scan        api.ApplicationScanResult `json:"scan"`
+	TotalAlerts int                       `json:"totalAlerts"`
+	TopFindings []api.ScanAlert           `json:"topFindings"`
+}
+
+// topAlerts returns the n most severe alerts, those with the most URIs first
+func topAlerts(alerts []api.ScanAlert, n int) []api.ScanAlert {
+	sorted := append([]api.ScanAlert(nil), alerts...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		if rankA, rankB := severityRank(sorted[a].Severity), severityRank(sorted[b].Severity); rankA != rankB {
+			return rankA < rankB
+		}
+		return sorted[a].URICount > sorted[b].URICount
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// outputScanWithTopFindings prints the scan overview followed by its top n alerts
+func outputScanWithTopFindings(client *api.Client, scanResult api.ApplicationScanResult, outputFormat string, n int) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "table" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+
+	alerts, err := client.GetScanAlerts(scanResult.Scan.ID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to get scan alerts: %v", err)
+		return
+	}
+	top := topAlerts(alerts, n)
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(scanWithTopFindings{Scan: scanResult, TotalAlerts: len(alerts), TopFindings: top}, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	overview, _ := renderScanOverview(scanResult, false)
+	fmt.Fprint(out, overview)
+	fmt.Fprintln(out)
+	title := fmt.Sprintf("Top %d Findings", len(top))
+	fmt.Fprintf(out, "%s\n%s\n", title, strings.Repeat("=", len(title)))
+	outputAlertsTable(top, scanAlertsOptions{})
+	if len(alerts) > len(top) {
+		fmt.Fprintf(errOut, "Showing %d of %d alerts; see all with 'hawkop scan alerts %s'\n", len(top), len(alerts), scanResult.Scan.ID)
+	}
+}
+
+func outputScanDetailsTable(scanResult api.ApplicationScanResult, view string, chart bool) {
+	view = strings.ToLower(view)
+
+	if view == "all" {
+		for i, section := range scanSections {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "%s\n%s\n", section.title, strings.Repeat("=", len(section.title)))
+
+			// Note missing sections inline so the remaining sections still print
+			if rendered, ok := section.render(scanResult, chart); ok {
+				fmt.Fprint(out, rendered)
+			} else {
+				fmt.Fprintln(out, section.missing)
+			}
+		}
+		return
+	}
+
+	for _, section := range scanSections {
+		if section.name != view {
+			continue
+		}
+		if rendered, ok := section.render(scanResult, chart); ok {
+			fmt.Fprint(out, rendered)
+		} else {
+			fmt.Fprintln(errOut, section.missing)
+		}
+		return
+	}
+
+	failf(exitUsage, "Unknown view: %s. %s", view, useChoices(view, scanViewNames()))
+}
+
+func renderScanOverview(scanResult api.ApplicationScanResult, chart bool) (string, bool) {
+	table := format.NewTable("FIELD", "VALUE")
+	table.AddRow("Scan ID", scanResult.Scan.ID)
+	table.AddRow("Application", scanResult.Scan.ApplicationName)
+	table.AddRow("Environment", scanResult.Scan.Env)
+	table.AddRow("Status", scanResult.Scan.Status)
+
+	if d, ok := format.ParseDuration(scanResult.ScanDuration); ok {
+		table.AddRow("Duration", format.FormatDuration(d))
+	} else if scanResult.ScanDuration != "" {
+		table.AddRow("Duration", scanResult.ScanDuration.String())
+	}
+	if n, ok := scanResult.URLCount.Count(); ok {
+		table.AddRow("URLs Scanned", format.Number(n))
+	} else if scanResult.URLCount != "" {
+		table.AddRow("URLs Scanned", scanResult.URLCount.String())
+	}
+	if scanResult.PolicyName != "" {
+		table.AddRow("Policy", scanResult.PolicyName)
+	}
+
+	// Format timestamp
+	if scanResult.Scan.Timestamp != "" {
+		if ts, err := strconv.ParseInt(scanResult.Scan.Timestamp, 10, 64); err == nil {
+			timestamp := format.TimeIn(ts, displayLocation).Format("2006-01-02 15:04:05")
+			table.AddRow("Timestamp", timestamp)
+		}
+	}
+
+	return renderTable(table), true
+}
+
+func renderScanStats(scanResult api.ApplicationScanResult, chart bool) (string, bool) {
+	stats := scanResult.AlertStats
+	if stats == nil {
+		return "", false
+	}
+
+	if chart && graphicsEnabled() {
+		barChart := format.NewBarChart(terminalWidth())
+		barChart.AddBar("High", stats.High)
+		barChart.AddBar("Medium", stats.Medium)
+		barChart.AddBar("Low", stats.Low)
+		barChart.AddBar("Info", stats.Info)
+		return barChart.Render() + fmt.Sprintf("Total: %d\n", stats.Total), true
+	}
+
+	table := format.NewTable("SEVERITY", "COUNT")
+	table.AddRow("High", format.Number(int64(stats.High)))
+	table.AddRow("Medium", format.Number(int64(stats.Medium)))
+	table.AddRow("Low", format.Number(int64(stats.Low)))
+	table.AddRow("Info", format.Number(int64(stats.Info)))
+	table.AddRow("Total", format.Number(int64(stats.Total)))
+	return renderTable(table), true
+}
+
+func renderScanTags(scanResult api.ApplicationScanResult, chart bool) (string, bool) {
+	if len(scanResult.Tags) == 0 {
+		return "", false
+	}
+
+	table := format.NewTable("NAME", "VALUE")
+	for _, tag := range scanResult.Tags {
+		table.AddRow(tag.Name, tag.Value.String())
+	}
+	return renderTable(table), true
+}
+
+func outputAlertsJSON(alerts []api.ScanAlert) {
+	data, err := json.MarshalIndent(alerts, "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func outputAlertsTable(alerts []api.ScanAlert, opts scanAlertsOptions) {
+	if len(alerts) == 0 {
+		fmt.Fprintln(errOut, "No alerts found.")
+		return
+	}
+
+	table := format.NewTable("PLUGIN ID", "NAME", "SEVERITY", "URIS", "CWE")
+
+	for _, alert := range alerts {
+		// Clean up values
+		name := alert.Name
+		if name == "" {
+			name = "N/A"
+		}
+		if alert.Suppressed {
+			name += " (SUPPRESSED)"
+		}
+
+		severity := alert.Severity
+		if severity == "" {
+			severity = "N/A"
+		}
+
+		uriCount := ""
+		if alert.URICount > 0 {
+			uriCount = format.Number(int64(alert.URICount))
+		} else {
+			uriCount = "0"
+		}
+
+		cweID := alert.CWEID
+		if cweID == "" {
+			cweID = "N/A"
+		} else if opts.CWENames {
+			cweID = cwe.Label(cweID)
+		}
+
+		table.AddRow(alert.PluginID, name, severity, uriCount, cweID)
+	}
+	if opts.Totals {
+		table.AddFooter(table.Totals("TOTAL", "URIS")...)
 	}
 
-	fmt.Print(table.Render())
+	fmt.Fprint(out, renderTable(table))
+	fmt.Fprintln(errOut, countSeverities(alerts))
 }
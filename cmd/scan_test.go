@@ -2,14 +2,27 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/suppress"
+	"hawkop/internal/testutil"
 )
 
 type ScanCommandTestSuite struct {
@@ -117,6 +130,8 @@ func (suite *ScanCommandTestSuite) TestScanCommand_Structure() {
 	assert.Contains(suite.T(), subcommands, "list")
 	assert.Contains(suite.T(), subcommands, "get <scan-id>")
 	assert.Contains(suite.T(), subcommands, "alerts <scan-id>")
+	assert.Contains(suite.T(), subcommands, "baseline")
+	assert.Contains(suite.T(), subcommands, "compare <scan-id>")
 }
 
 func (suite *ScanCommandTestSuite) TestScanListFlags() {
@@ -142,6 +157,155 @@ func (suite *ScanCommandTestSuite) TestScanListFlags() {
 
 	statusFlag := cmd.Flags().Lookup("status")
 	assert.NotNil(suite.T(), statusFlag)
+
+	watchFlag := cmd.Flags().Lookup("watch")
+	assert.NotNil(suite.T(), watchFlag)
+	assert.Equal(suite.T(), "false", watchFlag.DefValue)
+
+	intervalFlag := cmd.Flags().Lookup("interval")
+	assert.NotNil(suite.T(), intervalFlag)
+	assert.Equal(suite.T(), "15s", intervalFlag.DefValue)
+
+	allOrgsFlag := cmd.Flags().Lookup("all-orgs")
+	assert.NotNil(suite.T(), allOrgsFlag)
+
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("exclude-env"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("page-size"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("no-pagination"))
+
+	limitScopeFlag := cmd.Flags().Lookup("limit-scope")
+	assert.NotNil(suite.T(), limitScopeFlag)
+	assert.Equal(suite.T(), "per-org", limitScopeFlag.DefValue)
+}
+
+// A failed refresh is reported without failing the run, the next refresh still
+// renders, and the refresh time is shown in the --timezone zone
+func (suite *ScanCommandTestSuite) TestWatchScanList() {
+	resetExitCode(suite.T())
+	origLocation := displayLocation
+	displayLocation = time.FixedZone("UTC+14", 14*60*60)
+	suite.T().Cleanup(func() { displayLocation = origLocation })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 2:
+			_, _ = w.Write([]byte(`{"applicationScanResults":[{"scan":{"id":"scan-42","applicationName":"Watch App","status":"COMPLETED"}}]}`))
+		default:
+			// Ctrl-C during the third fetch ends it and the watch
+			cancel()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(testutil.NewConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.SetContext(ctx)
+	client.MinRequestInterval = 0
+
+	before := time.Now().In(displayLocation).Format("2006-01-02 15:04")
+	stdout, stderr := captureOutput(suite.T(), func() {
+		watchScanList(ctx, client, testutil.MockOrgID, scanListOptions{Limit: 10}, time.Millisecond)
+	})
+	after := time.Now().In(displayLocation).Format("2006-01-02 15:04")
+
+	assert.Contains(suite.T(), stderr, "Failed to list scans")
+	assert.Contains(suite.T(), stdout, "scan-42")
+	assert.True(suite.T(), strings.Contains(stdout, "Last refresh: "+before) || strings.Contains(stdout, "Last refresh: "+after), stdout)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+	assert.Equal(suite.T(), int32(3), requests.Load())
+}
+
+func (suite *ScanCommandTestSuite) TestFilterScans() {
+	scans := []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "scan-1", ApplicationName: "Billing API", Env: "Production", Status: "COMPLETED"}},
+		{Scan: api.Scan{ID: "scan-2", ApplicationName: "Billing API", Env: "Staging", Status: "STARTED"}},
+		{Scan: api.Scan{ID: "scan-3", ApplicationName: "Web Frontend", Env: "Production", Status: "COMPLETED"}},
+	}
+
+	assert.Len(suite.T(), filterScans(scans, scanListOptions{}), 3)
+	assert.Len(suite.T(), filterScans(scans, scanListOptions{App: "billing"}), 2)
+	assert.Len(suite.T(), filterScans(scans, scanListOptions{Env: "production"}), 2)
+
+	filtered := filterScans(scans, scanListOptions{App: "billing", Status: "completed"})
+	assert.Len(suite.T(), filtered, 1)
+	assert.Equal(suite.T(), "scan-1", filtered[0].Scan.ID)
+}
+
+func (suite *ScanCommandTestSuite) TestFilterScans_AppMatch() {
+	scans := []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "scan-1", ApplicationID: "app-1", ApplicationName: "API"}},
+		{Scan: api.Scan{ID: "scan-2", ApplicationID: "app-2", ApplicationName: "API Gateway"}},
+		{Scan: api.Scan{ID: "scan-3", ApplicationID: "app-13", ApplicationName: "Web Frontend"}},
+	}
+
+	match := func(app, mode string) []string {
+		opts := scanListOptions{App: app, AppMatch: mode}
+		require.NoError(suite.T(), opts.compileAppMatcher())
+		ids := []string{}
+		for _, result := range filterScans(scans, opts) {
+			ids = append(ids, result.Scan.ID)
+		}
+		return ids
+	}
+
+	// Substring is the default and ignores case
+	assert.Equal(suite.T(), []string{"scan-1", "scan-2"}, match("api", ""))
+	assert.Equal(suite.T(), []string{"scan-1", "scan-3"}, match("app-1", "substring"))
+
+	// Exact matches the whole name or ID, ignoring case
+	assert.Equal(suite.T(), []string{"scan-1"}, match("api", "exact"))
+	assert.Equal(suite.T(), []string{"scan-1"}, match("app-1", "EXACT"))
+
+	// Regex applies to names and IDs as written
+	assert.Equal(suite.T(), []string{"scan-2", "scan-3"}, match(`^app-(2|13)$`, "regex"))
+	assert.Equal(suite.T(), []string{"scan-1", "scan-2"}, match(`^API`, "regex"))
+	assert.Empty(suite.T(), match(`^api`, "regex"))
+}
+
+func (suite *ScanCommandTestSuite) TestScanList_AppMatchValidation() {
+	opts := scanListOptions{App: "billing(", AppMatch: "regex"}
+	err := opts.compileAppMatcher()
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), `invalid --app pattern "billing("`)
+
+	_, stderr := captureOutput(suite.T(), func() {
+		runScanList("table", "", scanListOptions{App: "billing", AppMatch: "fuzzy"}, false, 0)
+	})
+	assert.Contains(suite.T(), stderr, "unknown --app-match mode: fuzzy")
+}
+
+func (suite *ScanCommandTestSuite) TestFilterScans_MultiEnv() {
+	scans := []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "scan-1", Env: "Production"}},
+		{Scan: api.Scan{ID: "scan-2", Env: "Staging"}},
+		{Scan: api.Scan{ID: "scan-3", Env: "Development"}},
+	}
+
+	ids := func(results []api.ApplicationScanResult) []string {
+		var got []string
+		for _, result := range results {
+			got = append(got, result.Scan.ID)
+		}
+		return got
+	}
+
+	// Comma-separated --env matches any listed environment
+	assert.Equal(suite.T(), []string{"scan-1", "scan-2"}, ids(filterScans(scans, scanListOptions{Env: "production, staging"})))
+
+	// --exclude-env alone removes matching environments
+	assert.Equal(suite.T(), []string{"scan-2", "scan-3"}, ids(filterScans(scans, scanListOptions{ExcludeEnv: "PRODUCTION"})))
+
+	// Exclude is applied after include
+	assert.Equal(suite.T(), []string{"scan-2"}, ids(filterScans(scans, scanListOptions{Env: "production,staging", ExcludeEnv: "production"})))
+
+	// Blank entries are ignored rather than matching scans without an env
+	assert.Len(suite.T(), filterScans(scans, scanListOptions{Env: ",", ExcludeEnv: ","}), 0)
 }
 
 func (suite *ScanCommandTestSuite) TestScanGetFlags() {
@@ -154,6 +318,14 @@ func (suite *ScanCommandTestSuite) TestScanGetFlags() {
 	viewFlag := cmd.Flags().Lookup("view")
 	assert.NotNil(suite.T(), viewFlag)
 	assert.Equal(suite.T(), "overview", viewFlag.DefValue)
+
+	chartFlag := cmd.Flags().Lookup("chart")
+	assert.NotNil(suite.T(), chartFlag)
+	assert.Equal(suite.T(), "false", chartFlag.DefValue)
+
+	topFlag := cmd.Flags().Lookup("top")
+	assert.NotNil(suite.T(), topFlag)
+	assert.Equal(suite.T(), "10", topFlag.DefValue)
 }
 
 func (suite *ScanCommandTestSuite) TestScanAlertsFlags() {
@@ -169,8 +341,928 @@ func (suite *ScanCommandTestSuite) TestScanAlertsFlags() {
 	limitFlag := cmd.Flags().Lookup("limit")
 	assert.NotNil(suite.T(), limitFlag)
 	assert.Equal(suite.T(), "0", limitFlag.DefValue)
+
+	groupByFlag := cmd.Flags().Lookup("group-by")
+	assert.NotNil(suite.T(), groupByFlag)
+
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("hide-suppressed"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("show-suppressed"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("totals"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("cwe-names"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("summary"))
+}
+
+func (suite *ScanCommandTestSuite) TestApplySuppressions() {
+	alerts := []api.ScanAlert{
+		{PluginID: "10020", Name: "Missing Header"},
+		{PluginID: "40012", Name: "Cross Site Scripting"},
+	}
+	scan := &api.Scan{ID: "scan-1", ApplicationID: "app-1", ApplicationName: "Billing API", Env: "Production"}
+	suppressions := &suppress.List{Rules: []suppress.Rule{{PluginID: "10020", Env: "production"}}}
+
+	annotated := applySuppressions(alerts, scan, suppressions, false)
+	assert.Len(suite.T(), annotated, 2)
+	assert.True(suite.T(), annotated[0].Suppressed)
+	assert.False(suite.T(), annotated[1].Suppressed)
+
+	hidden := applySuppressions(alerts, scan, suppressions, true)
+	assert.Len(suite.T(), hidden, 1)
+	assert.Equal(suite.T(), "40012", hidden[0].PluginID)
+}
+
+func (suite *ScanCommandTestSuite) TestScanDetails_MissingAlertStats() {
+	result := api.ApplicationScanResult{
+		Scan: api.Scan{ID: "scan-1", ApplicationName: "Test App", Env: "production", Status: "COMPLETED"},
+		Tags: []api.ScanTag{{Name: "branch", Value: "main"}},
+	}
+
+	// --view all keeps printing after the missing stats section
+	stdout, stderr := captureOutput(suite.T(), func() { outputScanDetailsTable(result, "all", false) })
+	assert.Empty(suite.T(), stderr)
+	assert.Contains(suite.T(), stdout, "Overview")
+	assert.Contains(suite.T(), stdout, "scan-1")
+	assert.Contains(suite.T(), stdout, "No alert statistics available for this scan.")
+	assert.Contains(suite.T(), stdout, "branch")
+	assert.Less(suite.T(), strings.Index(stdout, "Alert Statistics"), strings.Index(stdout, "Tags"))
+
+	// A single missing view reports it without output data
+	stdout, stderr = captureOutput(suite.T(), func() { outputScanDetailsTable(result, "stats", false) })
+	assert.Empty(suite.T(), stdout)
+	assert.Contains(suite.T(), stderr, "No alert statistics available")
+
+	_, stderr = captureOutput(suite.T(), func() { outputScanDetailsTable(result, "bogus", false) })
+	assert.Contains(suite.T(), stderr, "Unknown view")
+}
+
+func (suite *ScanCommandTestSuite) TestDiffAlerts() {
+	baseline := []api.ScanAlert{
+		{PluginID: "10020", Name: "Missing Header", Severity: "Low"},
+		{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High"},
+	}
+	current := []api.ScanAlert{
+		{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High"},
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High"},
+		{PluginID: "10096", Name: "Timestamp Disclosure", Severity: "Low"},
+		{PluginID: "90019", Name: "Server Side Include", Severity: "High", Suppressed: true},
+	}
+
+	diff := diffAlerts(withoutFindings(baseline), withoutFindings(current))
+	assert.Len(suite.T(), diff.New, 3)
+	assert.Equal(suite.T(), "40018", diff.New[0].PluginID)
+	assert.Len(suite.T(), diff.Resolved, 1)
+	assert.Equal(suite.T(), "10020", diff.Resolved[0].PluginID)
+
+	// Suppressed alerts don't fail the gate
+	assert.Equal(suite.T(), 1, countNewHighAlerts(diff))
+
+	// Identical scans produce empty, non-nil lists
+	same := diffAlerts(withoutFindings(baseline), withoutFindings(baseline))
+	assert.NotNil(suite.T(), same.New)
+	assert.Empty(suite.T(), same.New)
+	assert.Empty(suite.T(), same.Resolved)
+	assert.Equal(suite.T(), 0, countNewHighAlerts(same))
+}
+
+// withoutFindings pairs each alert with no findings, so diffAlerts compares them
+// by plugin ID
+func withoutFindings(alerts []api.ScanAlert) []api.AlertFindings {
+	entries := make([]api.AlertFindings, len(alerts))
+	for i, alert := range alerts {
+		entries[i] = api.AlertFindings{Alert: alert}
+	}
+	return entries
+}
+
+// Findings of one plugin at different URIs are different findings
+func (suite *ScanCommandTestSuite) TestDiffAlerts_Fingerprints() {
+	xss := api.ScanAlert{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High"}
+	finding := func(uri string) api.ScanAlertFinding {
+		return api.ScanAlertFinding{PluginID: "40012", URI: uri, RequestMethod: "GET"}
+	}
+	baseline := []api.AlertFindings{{Alert: xss, Findings: []api.ScanAlertFinding{finding("/search?q=a"), finding("/login")}}}
+	current := []api.AlertFindings{{Alert: xss, Findings: []api.ScanAlertFinding{
+		finding("/search?q=b"), finding("/account"), finding("/admin"),
+	}}}
+
+	diff := diffAlerts(baseline, current)
+	if assert.Len(suite.T(), diff.New, 1) {
+		assert.Equal(suite.T(), 2, diff.New[0].URICount, "/account and /admin")
+	}
+	if assert.Len(suite.T(), diff.Resolved, 1) {
+		assert.Equal(suite.T(), 1, diff.Resolved[0].URICount, "/login")
+	}
+	if assert.Len(suite.T(), diff.Unchanged, 1) {
+		assert.Equal(suite.T(), 1, diff.Unchanged[0].URICount, "/search")
+	}
+	// A High alert at a new URI is a regression even though its plugin isn't new
+	assert.Equal(suite.T(), 1, countNewHighAlerts(diff))
+
+	same := diffAlerts(baseline, baseline)
+	assert.Empty(suite.T(), same.New)
+	assert.Empty(suite.T(), same.Resolved)
+	assert.Equal(suite.T(), 2, same.Unchanged[0].URICount)
+}
+
+func (suite *ScanCommandTestSuite) TestScanBaselineSet() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{APIKey: "hawk.real"})
+
+	_, stderr := captureOutput(suite.T(), func() { runScanBaselineSet("Billing API", "Production", "scan-1") })
+	assert.Contains(suite.T(), stderr, "✅ Baseline for Billing API (Production) set to scan: scan-1")
+	require.Len(suite.T(), *saved, 1)
+	baseline, ok := (*saved)[0].FindBaseline("", "Billing API", "Production")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "scan-1", baseline.ScanID)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+// Replaying a snapshot must not save the config, which holds placeholder credentials
+func (suite *ScanCommandTestSuite) TestScanBaselineSet_NotSavedWhileReplaying() {
+	resetExitCode(suite.T())
+	saved := stubConfigFile(suite.T(), &config.Config{})
+	snapshotDir = suite.T().TempDir()
+	suite.T().Cleanup(func() { snapshotDir = "" })
+
+	_, stderr := captureOutput(suite.T(), func() { runScanBaselineSet("Billing API", "Production", "scan-1") })
+	assert.Contains(suite.T(), stderr, "isn't saved while replaying a snapshot")
+	assert.Empty(suite.T(), *saved)
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestScanCompare_ExitCodes() {
+	mockAPI := useMockAPI(suite.T())
+
+	// Every failure exits non-zero so CI can gate on the command
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runScanCompare("scan-9", "table") })
+	assert.Contains(suite.T(), stderr, "❌ Failed to get scan alerts")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+
+	resetExitCode(suite.T())
+	_, stderr = captureOutput(suite.T(), func() { runScanCompare("scan-1", "table") })
+	assert.Contains(suite.T(), stderr, "❌ No baseline set for Mock App (production)")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+
+	resetExitCode(suite.T())
+	mockAPI.Config.SetBaseline("Mock App", "production", "scan-9")
+	_, stderr = captureOutput(suite.T(), func() { runScanCompare("scan-1", "table") })
+	assert.Contains(suite.T(), stderr, "❌ Failed to get baseline scan alerts")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+
+	// A scan compared with itself has nothing new
+	resetExitCode(suite.T())
+	mockAPI.Config.SetBaseline("Mock App", "production", "scan-1")
+	_, stderr = captureOutput(suite.T(), func() { runScanCompare("scan-1", "table") })
+	assert.Contains(suite.T(), stderr, "0 new, 0 resolved")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestAlertDiffMarkdown() {
+	diff := diffAlerts(
+		withoutFindings([]api.ScanAlert{
+			{PluginID: "10020", Name: "Missing Header", Severity: "Low", URICount: 2},
+			{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High", URICount: 1},
+		}),
+		withoutFindings([]api.ScanAlert{
+			{PluginID: "10096", Name: "Timestamp Disclosure", Severity: "LOW", URICount: 4},
+			{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High", URICount: 3},
+			{PluginID: "40018", Name: "SQL Injection", Severity: "High", URICount: 1},
+			{PluginID: "40019", Name: "SQL | Injection", Severity: "High", URICount: 1},
+			{PluginID: "90019", Name: "Server Side Include", Severity: "High", Suppressed: true},
+		}),
+	)
+	diff.BaselineScanID, diff.ScanID = "scan-a", "scan-b"
+
+	lines := strings.Split(alertDiffMarkdown(diff), "\n")
+	assert.Equal(suite.T(), []string{
+		"### Scan `scan-b` compared to `scan-a`",
+		"",
+		"🔺 2 new High, 1 new Low, 1 new suppressed, ✅ 1 resolved, 1 unchanged",
+		"",
+		"| CHANGE | PLUGIN ID | NAME | SEVERITY | URIS |",
+		"| --- | ---: | --- | --- | ---: |",
+		"| 🔺 New | 40018 | SQL Injection | High | 1 |",
+		"| 🔺 New | 40019 | SQL \\| Injection | High | 1 |",
+		"| 🔺 New | 90019 | Server Side Include (suppressed) | High | 0 |",
+		"| 🔺 New | 10096 | Timestamp Disclosure | Low | 4 |",
+		"| ✅ Resolved | 10020 | Missing Header | Low | 2 |",
+		"| Unchanged | 40012 | Cross Site Scripting | High | 3 |",
+		"",
+	}, lines)
+}
+
+func (suite *ScanCommandTestSuite) TestAlertDiffSummary_NoEmoji() {
+	origNoEmoji := noEmoji
+	noEmoji = true
+	defer func() { noEmoji = origNoEmoji }()
+
+	diff := alertDiff{
+		New:      []api.ScanAlert{{PluginID: "1", Severity: "Medium"}},
+		Resolved: []api.ScanAlert{{PluginID: "2", Severity: "High"}},
+	}
+	assert.Equal(suite.T(), "1 new Medium, 1 resolved, 0 unchanged", alertDiffSummary(diff))
+
+	same := alertDiff{Unchanged: []api.ScanAlert{{PluginID: "3"}}}
+	assert.Equal(suite.T(), "No alert changes, 1 unchanged", alertDiffSummary(same))
+	assert.NotContains(suite.T(), alertDiffMarkdown(same), "✅")
+}
+
+func (suite *ScanCommandTestSuite) TestRunScanDiff_Markdown() {
+	resetExitCode(suite.T())
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runScanDiff("scan-1", "scan-1", "markdown") })
+	assert.Contains(suite.T(), stdout, "✅ No alert changes, 4 unchanged")
+	assert.Contains(suite.T(), stdout, "| Unchanged | 40012 |")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runScanDiff("scan-1", "scan-1", "tsv") })
+	assert.Contains(suite.T(), stderr, "Unknown format: tsv")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestGroupAlertsByCWE() {
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", CWEID: "89", URICount: 3},
+		{PluginID: "40019", Name: "SQL Injection - MySQL", CWEID: "89", URICount: 2},
+		{PluginID: "40012", Name: "Cross Site Scripting", CWEID: "79", URICount: 7},
+		{PluginID: "10020", Name: "Missing Header", URICount: 1},
+		{PluginID: "10021", Name: "Another Missing Header", CWEID: " ", URICount: 1},
+	}
+
+	groups := groupAlertsByCWE(alerts)
+
+	assert.Len(suite.T(), groups, 3)
+	assert.Equal(suite.T(), "79", groups[0].CWEID)
+	assert.Equal(suite.T(), 7, groups[0].URICount)
+
+	assert.Equal(suite.T(), "89", groups[1].CWEID)
+	assert.Equal(suite.T(), 5, groups[1].URICount)
+	assert.Equal(suite.T(), 2, groups[1].PluginCount)
+	assert.Equal(suite.T(), []string{"40018", "40019"}, groups[1].PluginIDs)
+
+	assert.Equal(suite.T(), uncategorizedCWE, groups[2].CWEID)
+	assert.Equal(suite.T(), 2, groups[2].URICount)
+}
+
+func (suite *ScanCommandTestSuite) TestSummarizeAlerts() {
+	alerts := []api.ScanAlert{
+		{PluginID: "10020", Name: "Missing Header", Severity: "Low", URICount: 9},
+		{PluginID: "40012", Name: "Cross Site Scripting", Severity: "Medium", URICount: 2},
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", URICount: 1},
+		{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High", URICount: 3},
+		{PluginID: "10021", Name: "Content Type", Severity: "Low", URICount: 9},
+		{PluginID: "10096", Name: "Timestamp Disclosure", Severity: "Info", URICount: 20, Suppressed: true},
+	}
+
+	summaries := summarizeAlerts(alerts)
+
+	// Duplicate plugins collapse, keeping the most severe rating
+	require.Len(suite.T(), summaries, 5)
+	assert.Equal(suite.T(), alertSummary{PluginID: "40012", Name: "Cross Site Scripting", Severity: "High", URICount: 5}, summaries[0])
+
+	// Severity first, then URI count descending, then name
+	var order []string
+	for _, summary := range summaries {
+		order = append(order, summary.PluginID)
+	}
+	assert.Equal(suite.T(), []string{"40012", "40018", "10021", "10020", "10096"}, order)
+	assert.True(suite.T(), summaries[4].Suppressed)
+}
+
+func (suite *ScanCommandTestSuite) TestOutputAlertSummaries() {
+	summaries := summarizeAlerts([]api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", URICount: 3},
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", URICount: 4},
+	})
+
+	stdout, _ := captureOutput(suite.T(), func() { outputAlertSummariesTable(summaries, scanAlertsOptions{Totals: true}) })
+	assert.Contains(suite.T(), stdout, "NAME")
+	assert.NotContains(suite.T(), stdout, "PLUGIN ID")
+	assert.Regexp(suite.T(), `SQL Injection\s+High\s+7`, stdout)
+	assert.Regexp(suite.T(), `TOTAL\s+7`, stdout)
+
+	stdout, _ = captureOutput(suite.T(), func() { outputAlertSummariesJSON(summaries) })
+	var decoded []alertSummary
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &decoded))
+	assert.Equal(suite.T(), summaries, decoded)
+}
+
+func (suite *ScanCommandTestSuite) TestScanAlerts_SummaryWithGroupBy() {
+	_, stderr := captureOutput(suite.T(), func() {
+		runScanAlerts("scan-1", "table", scanAlertsOptions{Summary: true, GroupBy: "cwe"})
+	})
+	assert.Contains(suite.T(), stderr, "--summary and --group-by cannot be used together")
+}
+
+func (suite *ScanCommandTestSuite) TestScanAlerts_FailOn() {
+	useMockAPI(suite.T())
+	resetExitCode(suite.T())
+
+	// Only the listed alerts count, so the High alert filtered out doesn't fail
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runScanAlerts("scan-1", "table", scanAlertsOptions{Severity: "Medium", FailOn: "High"})
+	})
+	assert.Contains(suite.T(), stdout, "Missing Anti-clickjacking Header")
+	assert.NotContains(suite.T(), stderr, "--fail-on")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+
+	stdout, stderr = captureOutput(suite.T(), func() {
+		runScanAlerts("scan-1", "json", scanAlertsOptions{Limit: 1, FailOn: "medium"})
+	})
+	assert.Contains(suite.T(), stdout, `"pluginId"`)
+	assert.Contains(suite.T(), stderr, "❌ 3 alert(s) at or above Medium severity (--fail-on):\n"+
+		"  High  40012  Cross Site Scripting (Reflected)\n"+
+		"  Medium  10020  Missing Anti-clickjacking Header\n"+
+		"  Medium  10038  Content Security Policy Header Not Set\n")
+	assert.Equal(suite.T(), exitError, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestScanAlerts_FailOnUnknownSeverity() {
+	resetExitCode(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() {
+		runScanAlerts("scan-1", "table", scanAlertsOptions{FailOn: "critical"})
+	})
+	assert.Contains(suite.T(), stderr, "Unknown severity: critical")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestFailOnSeverity_IgnoresSuppressed() {
+	resetExitCode(suite.T())
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", Suppressed: true},
+		{PluginID: "10020", Name: "Missing Header", Severity: "Low"},
+	}
+
+	_, stderr := captureOutput(suite.T(), func() { failOnSeverity(alerts, "High") })
+	assert.Empty(suite.T(), stderr)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+
+	_, stderr = captureOutput(suite.T(), func() { failOnSeverity(alerts, "Low") })
+	assert.Contains(suite.T(), stderr, "1 alert(s) at or above Low severity")
+	assert.NotContains(suite.T(), stderr, "SQL Injection")
+	assert.Equal(suite.T(), exitError, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestOutputAlertsTable_Totals() {
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", CWEID: "89", URICount: 3},
+		{PluginID: "10020", Name: "Missing Header", Severity: "Low", URICount: 4},
+	}
+
+	stdout, _ := captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{}) })
+	assert.NotContains(suite.T(), stdout, "TOTAL")
+
+	stdout, _ = captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{Totals: true}) })
+	lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+	footer := lines[len(lines)-1]
+	assert.True(suite.T(), strings.HasPrefix(footer, "TOTAL"))
+	assert.Contains(suite.T(), footer, "7")
+	assert.NotContains(suite.T(), footer, "50038", "plugin IDs must not be summed")
+	assert.Equal(suite.T(), lines[1], lines[len(lines)-2], "footer is separated by the header rule")
+}
+
+func (suite *ScanCommandTestSuite) TestCountSeverities() {
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Severity: "High"},
+		{PluginID: "40012", Severity: "high"},
+		{PluginID: "10020", Severity: "Medium"},
+		{PluginID: "10021", Severity: "Low"},
+		{PluginID: "10096", Severity: "Informational"},
+		{PluginID: "90001", Severity: ""},
+	}
+
+	// Unfiltered counts cover every alert, including ones without a known severity
+	counts := countSeverities(alerts)
+	assert.Equal(suite.T(), severityCounts{High: 2, Medium: 1, Low: 1, Info: 1, Total: 6}, counts)
+	assert.Equal(suite.T(), "Total: 6 (High: 2, Medium: 1, Low: 1, Info: 1)", counts.String())
+
+	// Filtered counts reflect only the alerts left after --severity
+	counts = countSeverities(filterAlertsBySeverity(alerts, "HIGH"))
+	assert.Equal(suite.T(), "Total: 2 (High: 2, Medium: 0, Low: 0, Info: 0)", counts.String())
+
+	assert.Equal(suite.T(), severityCounts{}, countSeverities(filterAlertsBySeverity(alerts, "Critical")))
+}
+
+func (suite *ScanCommandTestSuite) TestScanAlerts_MixedCaseSeverities() {
+	// Severities decoded from the API are canonical however the API cased them
+	var resp api.ScanAlertsResponse
+	require.NoError(suite.T(), json.Unmarshal([]byte(`{"applicationScanResults": [{"applicationAlerts": [
+		{"pluginId": "40018", "name": "SQL Injection", "severity": "HIGH", "uriCount": 1},
+		{"pluginId": "10020", "name": "Missing Header", "severity": "low", "uriCount": 1},
+		{"pluginId": "40012", "name": "XSS", "severity": "High", "uriCount": 5}
+	]}]}`), &resp))
+	alerts := resp.ApplicationScanResults[0].ApplicationAlerts
+
+	for _, filter := range []string{"High", "HIGH", "high"} {
+		filtered := filterAlertsBySeverity(alerts, filter)
+		assert.Len(suite.T(), filtered, 2, "filter %q", filter)
+	}
+
+	stdout, _ := captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{}) })
+	assert.NotContains(suite.T(), stdout, "HIGH")
+	assert.NotContains(suite.T(), stdout, "low")
+
+	// Ordering treats every casing alike
+	summaries := summarizeAlerts(alerts)
+	require.Len(suite.T(), summaries, 3)
+	assert.Equal(suite.T(), []string{"High", "High", "Low"}, []string{summaries[0].Severity, summaries[1].Severity, summaries[2].Severity})
+}
+
+func (suite *ScanCommandTestSuite) TestValidSeverityFilter() {
+	resetExitCode(suite.T())
+	assert.True(suite.T(), validSeverityFilter(""))
+	assert.True(suite.T(), validSeverityFilter("informational"))
+
+	var valid bool
+	_, stderr := captureOutput(suite.T(), func() { valid = validSeverityFilter("Hgih") })
+	assert.False(suite.T(), valid)
+	assert.Contains(suite.T(), stderr, "Unknown severity: Hgih. Did you mean 'High'?")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestOutputAlertsTable_SeverityCounts() {
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", URICount: 3},
+		{PluginID: "10020", Name: "Missing Header", Severity: "Low", URICount: 4},
+	}
+
+	stdout, stderr := captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{}) })
+	assert.NotContains(suite.T(), stdout, "Total:")
+	assert.Equal(suite.T(), "Total: 2 (High: 1, Medium: 0, Low: 1, Info: 0)\n", stderr)
+}
+
+func (suite *ScanCommandTestSuite) TestOutputAlertsTable_CWENames() {
+	alerts := []api.ScanAlert{
+		{PluginID: "40018", Name: "SQL Injection", Severity: "High", CWEID: "89", URICount: 3},
+		{PluginID: "90001", Name: "Custom Check", Severity: "Low", CWEID: "99999", URICount: 1},
+	}
+
+	stdout, _ := captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{}) })
+	assert.NotContains(suite.T(), stdout, "SQL Injection)")
+
+	stdout, _ = captureOutput(suite.T(), func() { outputAlertsTable(alerts, scanAlertsOptions{CWENames: true}) })
+	assert.Contains(suite.T(), stdout, "CWE-89 (SQL Injection)")
+	assert.Contains(suite.T(), stdout, "99999")
+	assert.NotContains(suite.T(), stdout, "CWE-99999")
+}
+
+func (suite *ScanCommandTestSuite) TestScanTableRow_Duration() {
+	for input, want := range map[api.FlexString]string{"45": "45s", "90.4": "1m30s", "1m30s": "1m30s", "unknown": "unknown", "": ""} {
+		row := scanTableRow(api.ApplicationScanResult{ScanDuration: input})
+		assert.Equal(suite.T(), want, row[4], "input %q", input)
+	}
+}
+
+// pagedScansServer serves three full pages of scans sized by the pageSize parameter
+func pagedScansServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+
+		resp := api.OrganizationScansResponse{}
+		for i := 0; i < pageSize; i++ {
+			resp.ApplicationScanResults = append(resp.ApplicationScanResults, api.ApplicationScanResult{
+				Scan: api.Scan{ID: fmt.Sprintf("scan-%d-%d", page, i)},
+			})
+		}
+		if page < 2 {
+			resp.NextPageToken = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (suite *ScanCommandTestSuite) TestFetchScanList_Pagination() {
+	var requests int
+	server := pagedScansServer(suite.T(), &requests)
+	cfg := testutil.NewConfig(server.URL)
+
+	newPagedClient := func() *api.Client {
+		client := api.NewClient(cfg)
+		client.SetBaseURL(server.URL)
+		return client
+	}
+
+	// Pages are followed until the limit is reached
+	results, err := fetchScanList(newPagedClient(), testutil.MockOrgID, scanListOptions{Limit: 25, PageSize: 10})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 25)
+	assert.Equal(suite.T(), 3, requests)
+
+	// --no-pagination fetches exactly one page and notes it may be incomplete
+	requests = 0
+	_, stderr := captureOutput(suite.T(), func() {
+		results, err = fetchScanList(newPagedClient(), testutil.MockOrgID, scanListOptions{Limit: 25, PageSize: 10, FirstPage: true})
+	})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 10)
+	assert.Equal(suite.T(), 1, requests)
+	assert.Contains(suite.T(), stderr, "may be incomplete")
+}
+
+func (suite *ScanCommandTestSuite) TestScanList_PageSizeValidation() {
+	_, stderr := captureOutput(suite.T(), func() {
+		runScanList("table", "", scanListOptions{PageSize: api.MaxPageSize + 1}, false, 0)
+	})
+	assert.Contains(suite.T(), stderr, "--page-size must be between 1 and")
+}
+
+// countedScansServer serves three pages of two scans, alternating production and
+// staging, and reports a totalCount of 6. It records the query of each request.
+func countedScansServer(t *testing.T, queries *[]string) *api.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+
+		resp := api.OrganizationScansResponse{TotalCount: "6"}
+		for i, env := range []string{"production", "staging"} {
+			resp.ApplicationScanResults = append(resp.ApplicationScanResults, api.ApplicationScanResult{
+				Scan: api.Scan{ID: fmt.Sprintf("scan-%d-%d", page, i), Env: env},
+			})
+		}
+		if page < 2 {
+			resp.NextPageToken = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client := api.NewClient(testutil.NewConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+func (suite *ScanCommandTestSuite) TestScanCount_TotalCountFastPath() {
+	var queries []string
+	client := countedScansServer(suite.T(), &queries)
+
+	stdout, _ := captureOutput(suite.T(), func() { runScanCount(client, testutil.MockOrgID, scanListOptions{}, "table") })
+	assert.Equal(suite.T(), "6\n", stdout)
+	require.Len(suite.T(), queries, 1, "an unfiltered count is a single request")
+	assert.Contains(suite.T(), queries[0], "pageSize=1")
+
+	stdout, _ = captureOutput(suite.T(), func() { runScanCount(client, testutil.MockOrgID, scanListOptions{}, "json") })
+	assert.JSONEq(suite.T(), `{"count": 6}`, stdout)
+}
+
+func (suite *ScanCommandTestSuite) TestScanCount_FiltersWalkPages() {
+	var queries []string
+	client := countedScansServer(suite.T(), &queries)
+
+	stdout, _ := captureOutput(suite.T(), func() {
+		runScanCount(client, testutil.MockOrgID, scanListOptions{Env: "production"}, "table")
+	})
+	assert.Equal(suite.T(), "3\n", stdout, "totalCount is unfiltered, so matches are counted")
+	assert.Len(suite.T(), queries, 3)
+}
+
+func (suite *ScanCommandTestSuite) TestFindScan_Direct() {
+	var listRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/scan/scan-9/summary":
+			_ = json.NewEncoder(w).Encode(api.ApplicationScanResult{Scan: api.Scan{ID: "scan-9"}})
+		default:
+			listRequests++
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(testutil.NewConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	scan, err := findScan(client, testutil.MockOrgID, "scan-9")
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), scan)
+	assert.Equal(suite.T(), "scan-9", scan.Scan.ID)
+	assert.Zero(suite.T(), listRequests, "direct hit should not search the scan list")
+}
+
+func (suite *ScanCommandTestSuite) TestFindScan_FallsBackToList() {
+	// The mock API has no summary endpoint, so lookups 404 and search the list
+	mockAPI := testutil.NewMockAPI(suite.T())
+	client := mockAPI.NewClient(mockAPI.Config)
+
+	scan, err := findScan(client, testutil.MockOrgID, "scan-1")
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), scan)
+	assert.Equal(suite.T(), "scan-1", scan.Scan.ID)
+
+	scan, err = findScan(client, testutil.MockOrgID, "missing-scan")
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), scan)
+}
+
+func (suite *ScanCommandTestSuite) TestScanGetByIDs_PartialMatch() {
+	useMockAPI(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runScanGetByIDs([]string{"missing-1", "scan-1", "missing-2"}, "json")
+	})
+
+	var scans []api.ApplicationScanResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &scans))
+	require.Len(suite.T(), scans, 1)
+	assert.Equal(suite.T(), "scan-1", scans[0].Scan.ID)
+	assert.Contains(suite.T(), stderr, "Scans not found: missing-1, missing-2")
+}
+
+func (suite *ScanCommandTestSuite) TestTopAlerts() {
+	alerts := []api.ScanAlert{
+		{PluginID: "1", Severity: "Low", URICount: 50},
+		{PluginID: "2", Severity: "Medium", URICount: 2},
+		{PluginID: "3", Severity: "High", URICount: 1},
+		{PluginID: "4", Severity: "Medium", URICount: 9},
+	}
+
+	var ids []string
+	for _, alert := range topAlerts(alerts, 3) {
+		ids = append(ids, alert.PluginID)
+	}
+	assert.Equal(suite.T(), []string{"3", "4", "2"}, ids)
+	assert.Equal(suite.T(), "1", alerts[0].PluginID, "the input order is kept")
+	assert.Len(suite.T(), topAlerts(alerts, 10), 4)
+}
+
+func (suite *ScanCommandTestSuite) TestScanGet_FindingsView() {
+	resetExitCode(suite.T())
+	useMockAPI(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() { runScanGet("scan-1", "table", "findings", false, 2) })
+	assert.Contains(suite.T(), stdout, "Mock App")
+	assert.Contains(suite.T(), stdout, "Top 2 Findings")
+	assert.Less(suite.T(), strings.Index(stdout, "Cross Site Scripting"), strings.Index(stdout, "Content Security Policy"))
+	assert.NotContains(suite.T(), stdout, "Missing Anti-clickjacking Header", "only the top 2 are shown")
+	assert.Contains(suite.T(), stderr, "Showing 2 of 4 alerts")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+
+	stdout, _ = captureOutput(suite.T(), func() { runScanGet("scan-1", "json", "findings", false, 10) })
+	var result scanWithTopFindings
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(suite.T(), "scan-1", result.Scan.Scan.ID)
+	assert.Equal(suite.T(), 4, result.TotalAlerts)
+	require.Len(suite.T(), result.TopFindings, 4)
+	assert.Equal(suite.T(), "40012", result.TopFindings[0].PluginID)
+
+	_, stderr = captureOutput(suite.T(), func() { runScanGet("scan-1", "table", "findings", false, 0) })
+	assert.Contains(suite.T(), stderr, "--top must be at least 1")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestMissingScanIDs() {
+	scans := []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "scan-2"}},
+		{Scan: api.Scan{ID: "scan-1"}},
+	}
+
+	assert.Empty(suite.T(), missingScanIDs([]string{"scan-1", "scan-2"}, scans))
+	assert.Equal(suite.T(), []string{"scan-4", "scan-3"},
+		missingScanIDs([]string{"scan-4", "scan-1", "scan-3", "scan-4"}, scans))
+}
+
+func (suite *ScanCommandTestSuite) TestScanListAllOrgs_PartialFailure() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T(), "/api/v1/scan/org-b")
+
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runScanListAllOrgs("json", "", scanListOptions{}, "per-org")
+	})
+
+	// Team A's scans are listed despite Team B's 403
+	var scans []orgScanResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &scans))
+	require.Len(suite.T(), scans, 2)
+	for _, scan := range scans {
+		assert.Equal(suite.T(), "org-a", scan.OrgID)
+	}
+	assert.Contains(suite.T(), stderr, "⚠️  Failed to list scans for 1 of 2 organizations:")
+	assert.Contains(suite.T(), stderr, "Team B (org-b)")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestScanListAllOrgs_AllFailed() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T(), "/api/v1/scan/org-a", "/api/v1/scan/org-b")
+
+	_, stderr := captureOutput(suite.T(), func() {
+		runScanListAllOrgs("table", "", scanListOptions{}, "per-org")
+	})
+
+	assert.Contains(suite.T(), stderr, "❌ Failed to list scans for all 2 organizations:")
+	assert.Contains(suite.T(), stderr, "Team A (org-a)")
+	assert.Contains(suite.T(), stderr, "Team B (org-b)")
+	assert.Equal(suite.T(), exitAuth, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestScanListOrgList_TwoOrgs() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T())
+
+	// Entries may be IDs or names
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runScanListAllOrgs("tsv", "org-a, team b", scanListOptions{}, "per-org")
+	})
+	assert.Empty(suite.T(), stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(suite.T(), lines, 5)
+	assert.True(suite.T(), strings.HasPrefix(lines[0], "ORG\tSCAN ID"))
+	assert.Contains(suite.T(), stdout, "Team A\torg-a-app-1")
+	assert.Contains(suite.T(), stdout, "Team B\torg-b-app-2")
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+}
+
+func (suite *ScanCommandTestSuite) TestScanListOrgList_UnresolvedEntries() {
+	resetExitCode(suite.T())
+	useMultiOrgServer(suite.T())
+
+	stdout, stderr := captureOutput(suite.T(), func() {
+		runScanListAllOrgs("json", "org-b,Team Z,team", scanListOptions{}, "per-org")
+	})
+	var scans []orgScanResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &scans))
+	require.Len(suite.T(), scans, 2)
+	for _, scan := range scans {
+		assert.Equal(suite.T(), "org-b", scan.OrgID)
+	}
+	assert.Contains(suite.T(), stderr, "⚠️  Failed to list scans for 2 of 3 organizations:")
+	assert.Contains(suite.T(), stderr, `- Team Z: organization not found: "Team Z"`)
+	assert.Contains(suite.T(), stderr, `- team: "team" matches 2 organizations: Team A (org-a), Team B (org-b)`)
+	assert.Equal(suite.T(), exitOK, commandExitCode)
+
+	// When no entry resolves, the command fails
+	_, stderr = captureOutput(suite.T(), func() {
+		runScanListAllOrgs("json", "org-x,org-y", scanListOptions{}, "per-org")
+	})
+	assert.Contains(suite.T(), stderr, "❌ Failed to list scans for all 2 organizations:")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
 }
 
 func TestScanCommandTestSuite(t *testing.T) {
 	suite.Run(t, new(ScanCommandTestSuite))
 }
+
+// groupedScans are scans for exercising scan list --group-by
+var groupedScans = []api.ApplicationScanResult{
+	{Scan: api.Scan{ID: "scan-4", ApplicationName: "billing", Env: "production", Status: "COMPLETED"}},
+	{Scan: api.Scan{ID: "scan-3", ApplicationName: "payments", Env: "staging", Status: "ERROR"}},
+	{Scan: api.Scan{ID: "scan-2", ApplicationName: "billing", Env: "staging", Status: "COMPLETED"}},
+	{Scan: api.Scan{ID: "scan-1", ApplicationID: "app-9", Env: "production", Status: "STARTED"}},
+}
+
+func TestOutputScanList_GroupByKeys(t *testing.T) {
+	tests := []struct {
+		groupBy  string
+		headings []string
+		groups   map[string][]string
+	}{
+		{"app", []string{"billing (2 scans)", "payments (1 scan)", "app-9 (1 scan)"},
+			map[string][]string{"billing": {"scan-4", "scan-2"}, "payments": {"scan-3"}, "app-9": {"scan-1"}}},
+		{"env", []string{"production (2 scans)", "staging (2 scans)"},
+			map[string][]string{"production": {"scan-4", "scan-1"}, "staging": {"scan-3", "scan-2"}}},
+		{"status", []string{"COMPLETED (2 scans)", "ERROR (1 scan)", "STARTED (1 scan)"},
+			map[string][]string{"COMPLETED": {"scan-4", "scan-2"}, "ERROR": {"scan-3"}, "STARTED": {"scan-1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			opts := scanListOptions{GroupBy: tt.groupBy}
+
+			// Groups appear in the order of their most recent scan
+			table, _ := captureOutput(t, func() { outputScanList("table", groupedScans, opts) })
+			last := -1
+			for _, heading := range tt.headings {
+				at := strings.Index(table, heading+"\n"+strings.Repeat("=", len(heading)))
+				require.GreaterOrEqual(t, at, 0, "missing heading %q in:\n%s", heading, table)
+				assert.Greater(t, at, last)
+				last = at
+			}
+
+			stdout, _ := captureOutput(t, func() { outputScanList("json", groupedScans, opts) })
+			var grouped map[string][]api.ApplicationScanResult
+			require.NoError(t, json.Unmarshal([]byte(stdout), &grouped))
+			ids := map[string][]string{}
+			for key, scans := range grouped {
+				for _, scan := range scans {
+					ids[key] = append(ids[key], scan.Scan.ID)
+				}
+			}
+			assert.Equal(t, tt.groups, ids)
+		})
+	}
+}
+
+func TestOutputScanList_GroupedTSVKeepsGroupsTogether(t *testing.T) {
+	stdout, _ := captureOutput(t, func() { outputScanList("tsv", groupedScans, scanListOptions{GroupBy: "env"}) })
+
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n")[1:] {
+		ids = append(ids, strings.Split(line, "\t")[0])
+	}
+	assert.Equal(t, []string{"scan-4", "scan-1", "scan-3", "scan-2"}, ids)
+}
+
+func TestScanList_GroupByValidation(t *testing.T) {
+	resetExitCode(t)
+	refuseAPI(t)
+
+	_, stderr := captureOutput(t, func() { runScanList("table", "", scanListOptions{GroupBy: "envs"}, false, 0) })
+	assert.Contains(t, stderr, "Unknown grouping: envs. Did you mean 'env'?")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
+
+func TestScanList_GroupByFromCache(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	refuseAPI(t)
+	require.NoError(t, saveLastScanList(testutil.MockOrgID, groupedScans))
+
+	stdout, _ := captureOutput(t, func() {
+		runScanList("json", "", scanListOptions{UseLast: true, GroupBy: "app", Env: "staging"}, false, 0)
+	})
+	var grouped map[string][]api.ApplicationScanResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &grouped))
+	assert.Len(t, grouped["billing"], 1)
+	assert.Len(t, grouped["payments"], 1)
+	assert.NotContains(t, grouped, "app-9")
+}
+
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr string
+	}{
+		{"uuid", "058b994a-b95e-4562-ad0a-de8175164c60", ""},
+		{"slug", "scan-1", ""},
+		{"underscore", "scan_1", ""},
+		{"empty", "", "scan ID is empty"},
+		{"url", "https://app.stackhawk.com/scans/scan-1", `invalid scan ID "https://app.stackhawk.com/scans/scan-1"`},
+		{"path", "../scan-1", `invalid scan ID "../scan-1"`},
+		{"space", "scan 1", `invalid scan ID "scan 1"`},
+		{"quoted", `"scan-1"`, `invalid scan ID "\"scan-1\""`},
+		{"leading dash", "-scan", `invalid scan ID "-scan"`},
+		{"too long", strings.Repeat("a", 65), "invalid scan ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateID("scan", tt.id)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, exitUsage, exitCodeFor(err))
+		})
+	}
+}
+
+func TestScanCommands_MalformedIDFailsBeforeRequest(t *testing.T) {
+	runs := map[string]func(){
+		"get":             func() { runScanGet("scan 1", "table", "", false, 10) },
+		"get --ids":       func() { runScanGetByIDs([]string{"scan-1", "scan/2"}, "table") },
+		"alerts":          func() { runScanAlerts("scan 1", "table", scanAlertsOptions{}) },
+		"compare":         func() { runScanCompare("scan 1", "table") },
+		"diff":            func() { runScanDiff("scan-1", "scan 1", "table") },
+		"findings":        func() { runScanFindings("scan 1", "40012", "table", scanFindingsOptions{}) },
+		"findings-export": func() { runScanFindingsExport("scan 1", findingsExportOptions{Concurrency: 1}) },
+	}
+	for name, run := range runs {
+		t.Run(name, func(t *testing.T) {
+			resetExitCode(t)
+			refuseAPI(t)
+
+			_, stderr := captureOutput(t, run)
+			assert.Contains(t, stderr, "invalid scan ID")
+			assert.Contains(t, stderr, "--no-id-validation")
+			assert.Equal(t, exitUsage, commandExitCode)
+		})
+	}
+}
+
+func TestScanGet_NoIDValidationSendsIDAsGiven(t *testing.T) {
+	resetExitCode(t)
+	mockAPI := useMockAPI(t)
+	noIDValidation = true
+	t.Cleanup(func() { noIDValidation = false })
+
+	var requested bool
+	newClient = func(cfg *config.Config) *api.Client {
+		requested = true
+		return mockAPI.NewClient(cfg)
+	}
+
+	_, stderr := captureOutput(t, func() { runScanGet("scan 1", "json", "", false, 10) })
+	assert.True(t, requested)
+	assert.NotContains(t, stderr, "invalid scan ID")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/format"
+)
+
+// lastScanListMaxAge is how old a cached scan list can get before --use-last warns
+// that it may be out of date
+const lastScanListMaxAge = time.Hour
+
+// errNoLastScanList means scan list hasn't saved any scans for the organization yet
+var errNoLastScanList = errors.New("no cached scan list")
+
+// lastScanList is the most recent scan list fetched for an organization, before
+// filtering, so --use-last can render it again without calling the API
+type lastScanList struct {
+	OrgID     string                      `json:"orgId"`
+	FetchedAt time.Time                   `json:"fetchedAt"`
+	Scans     []api.ApplicationScanResult `json:"scans"`
+}
+
+// scanCacheDir is where scan list keeps one file per organization, beside the
+// config file. Tests may replace it.
+var scanCacheDir = func() string {
+	return filepath.Join(config.GetConfigDir(), "cache", "scans")
+}
+
+// lastScanListPath returns the cache file for an organization. Org IDs are
+// validated before use, so they are safe as file names.
+func lastScanListPath(orgID string) string {
+	return filepath.Join(scanCacheDir(), orgID+".json")
+}
+
+// saveLastScanList records scans as the organization's latest fetch. The file is
+// readable only by the current user and replaced atomically.
+func saveLastScanList(orgID string, scans []api.ApplicationScanResult) error {
+	dir := scanCacheDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create scan cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(lastScanList{OrgID: orgID, FetchedAt: time.Now().UTC(), Scans: scans})
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".scans-*")
+	if err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), lastScanListPath(orgID)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	return nil
+}
+
+// loadLastScanList reads the organization's latest saved fetch, returning
+// errNoLastScanList if there is none
+func loadLastScanList(orgID string) (*lastScanList, error) {
+	data, err := os.ReadFile(lastScanListPath(orgID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNoLastScanList
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan cache: %w", err)
+	}
+
+	var cached lastScanList
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse scan cache %s: %w", lastScanListPath(orgID), err)
+	}
+	return &cached, nil
+}
+
+// runScanListFromCache renders the organization's last fetched scan list with the
+// current format and filters, without calling the API
+func runScanListFromCache(outputFormat string, orgID string, opts scanListOptions) {
+	if err := api.ValidateOrgID(orgID); err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	cached, err := loadLastScanList(orgID)
+	if errors.Is(err, errNoLastScanList) {
+		failf(exitNotFound, "No cached scan list for organization %s. Run 'hawkop scan list' without --use-last first.", orgID)
+		return
+	}
+	if err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	age := time.Since(cached.FetchedAt).Truncate(time.Second)
+	if age > lastScanListMaxAge {
+		fmt.Fprintf(errOut, "⚠️  Cached scan list is %s old and may be out of date; run without --use-last to refresh.\n", format.FormatDuration(age))
+	} else {
+		fmt.Fprintf(errOut, "Showing scans cached %s ago.\n", format.FormatDuration(age))
+	}
+
+	// The limit picks the latest scans before filtering, as it does when fetching
+	scans := cached.Scans
+	if len(scans) > opts.Limit {
+		scans = scans[:opts.Limit]
+	}
+	outputScanList(outputFormat, filterScans(scans, opts), opts)
+}
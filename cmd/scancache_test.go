@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+	"hawkop/internal/testutil"
+)
+
+// stubScanCacheDir keeps the scans saved for --use-last in a temporary directory
+func stubScanCacheDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	orig := scanCacheDir
+	scanCacheDir = func() string { return dir }
+	t.Cleanup(func() { scanCacheDir = orig })
+	return dir
+}
+
+// refuseAPI fails the test if a command creates an API client
+func refuseAPI(t *testing.T) {
+	t.Helper()
+
+	orig := newClient
+	newClient = func(cfg *config.Config) *api.Client {
+		t.Error("this command must not call the API")
+		return orig(cfg)
+	}
+	t.Cleanup(func() { newClient = orig })
+}
+
+func TestScanListUseLast_RendersLastFetch(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+
+	// A live fetch saves the scans it listed
+	fetched, _ := captureOutput(t, func() { runScanList("json", "", scanListOptions{}, false, 0) })
+	_, err := os.Stat(lastScanListPath(testutil.MockOrgID))
+	require.NoError(t, err)
+
+	refuseAPI(t)
+	cached, stderr := captureOutput(t, func() { runScanList("json", "", scanListOptions{UseLast: true}, false, 0) })
+	assert.JSONEq(t, fetched, cached)
+	assert.Contains(t, stderr, "Showing scans cached")
+	assert.Equal(t, exitOK, commandExitCode)
+
+	// Other formats render the same data
+	table, _ := captureOutput(t, func() { runScanList("table", "", scanListOptions{UseLast: true}, false, 0) })
+	assert.Contains(t, table, "scan-1")
+	assert.Contains(t, table, "Mock App")
+}
+
+func TestScanListUseLast_AppliesFilters(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	refuseAPI(t)
+
+	require.NoError(t, saveLastScanList(testutil.MockOrgID, []api.ApplicationScanResult{
+		{Scan: api.Scan{ID: "scan-3", ApplicationName: "billing", Env: "production", Status: "COMPLETED"}},
+		{Scan: api.Scan{ID: "scan-2", ApplicationName: "payments", Env: "staging", Status: "COMPLETED"}},
+		{Scan: api.Scan{ID: "scan-1", ApplicationName: "billing", Env: "staging", Status: "ERROR"}},
+	}))
+
+	stdout, _ := captureOutput(t, func() {
+		runScanList("json", "", scanListOptions{UseLast: true, App: "billing", Env: "staging"}, false, 0)
+	})
+	var scans []api.ApplicationScanResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &scans))
+	require.Len(t, scans, 1)
+	assert.Equal(t, "scan-1", scans[0].Scan.ID)
+
+	// As when fetching, the limit picks the latest scans before filtering
+	stdout, _ = captureOutput(t, func() {
+		runScanList("json", "", scanListOptions{UseLast: true, Limit: 2, Status: "ERROR"}, false, 0)
+	})
+	assert.JSONEq(t, "[]", stdout)
+}
+
+func TestScanListUseLast_NoCache(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	refuseAPI(t)
+
+	_, stderr := captureOutput(t, func() { runScanList("table", "", scanListOptions{UseLast: true}, false, 0) })
+	assert.Contains(t, stderr, "No cached scan list for organization "+testutil.MockOrgID)
+	assert.Equal(t, exitNotFound, commandExitCode)
+}
+
+func TestScanListUseLast_StaleWarning(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	refuseAPI(t)
+
+	data, err := json.Marshal(lastScanList{
+		OrgID:     testutil.MockOrgID,
+		FetchedAt: time.Now().Add(-2 * time.Hour),
+		Scans:     []api.ApplicationScanResult{{Scan: api.Scan{ID: "scan-1"}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lastScanListPath(testutil.MockOrgID), data, 0600))
+
+	stdout, stderr := captureOutput(t, func() { runScanList("tsv", "", scanListOptions{UseLast: true}, false, 0) })
+	assert.Contains(t, stdout, "scan-1")
+	assert.Contains(t, stderr, "⚠️  Cached scan list is 2h old and may be out of date")
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestSaveLastScanList_Permissions(t *testing.T) {
+	stubScanCacheDir(t)
+
+	require.NoError(t, saveLastScanList("org-a", []api.ApplicationScanResult{{Scan: api.Scan{ID: "scan-1"}}}))
+	info, err := os.Stat(lastScanListPath("org-a"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cached, err := loadLastScanList("org-a")
+	require.NoError(t, err)
+	assert.Equal(t, "org-a", cached.OrgID)
+	assert.Len(t, cached.Scans, 1)
+
+	_, err = loadLastScanList("org-b")
+	assert.ErrorIs(t, err, errNoLastScanList)
+}
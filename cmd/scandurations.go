@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/format"
+)
+
+// scanDurationsCmd summarizes how long scans take
+var scanDurationsCmd = &cobra.Command{
+	Use:   "durations",
+	Short: "Show the distribution of scan durations",
+	Long: `Collect the durations of recent scans and print summary statistics (min, median,
+90th percentile, max, and mean) with a histogram, to spot scans getting slower.
+
+The latest --limit scans are examined, then filtered by --app, --env, and the
+--after/--before window on the scan's start time. Scans that don't report a
+duration are left out and counted separately. On a terminal the histogram is
+drawn as a bar chart; otherwise, or with --no-color, it is a table.`,
+	Example: `  hawkop scan durations --app "Billing API" --env Production
+  hawkop scan durations --after 30d --buckets 5 --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		limit, _ := cmd.Flags().GetInt("limit")
+		app, _ := cmd.Flags().GetString("app")
+		env, _ := cmd.Flags().GetString("env")
+		after, _ := cmd.Flags().GetString("after")
+		before, _ := cmd.Flags().GetString("before")
+		buckets, _ := cmd.Flags().GetInt("buckets")
+		window, err := newTimeRange(after, before, time.Now())
+		if err != nil {
+			failf(exitUsage, "%v", err)
+			return
+		}
+		runScanDurations(format, org, scanListOptions{Limit: limit, App: app, Env: env}, window, buckets)
+	},
+}
+
+func init() {
+	scanCmd.AddCommand(scanDurationsCmd)
+
+	scanDurationsCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(scanDurationsCmd, "format", formatsTableJSON)
+	scanDurationsCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	scanDurationsCmd.Flags().IntP("limit", "l", 500, "Number of latest scans to examine")
+	scanDurationsCmd.Flags().StringP("app", "a", "", "Filter by application name or ID")
+	scanDurationsCmd.Flags().StringP("env", "e", "", "Filter by environment (comma-separated for several)")
+	scanDurationsCmd.Flags().String("after", "", "Only include scans started at or after this date or duration ago (e.g. 2025-01-31, 7d)")
+	scanDurationsCmd.Flags().String("before", "", "Only include scans started before this date or duration ago (e.g. 2025-02-01, 24h)")
+	scanDurationsCmd.Flags().Int("buckets", 10, "Number of histogram buckets")
+}
+
+// durationStats summarizes a set of scan durations, in seconds for JSON output
+type durationStats struct {
+	Scans    int              `json:"scans"`
+	Excluded int              `json:"excluded"`
+	Min      float64          `json:"minSeconds"`
+	Median   float64          `json:"medianSeconds"`
+	P90      float64          `json:"p90Seconds"`
+	Max      float64          `json:"maxSeconds"`
+	Mean     float64          `json:"meanSeconds"`
+	Buckets  []durationBucket `json:"buckets"`
+}
+
+// durationBucket counts the durations in [From, To); the last bucket includes To
+type durationBucket struct {
+	From  float64 `json:"fromSeconds"`
+	To    float64 `json:"toSeconds"`
+	Count int     `json:"count"`
+}
+
+func runScanDurations(outputFormat string, orgID string, opts scanListOptions, window timeRange, buckets int) {
+	outputFormat = strings.ToLower(outputFormat)
+	if !slices.Contains(formatsTableJSON, outputFormat) {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+	if opts.Limit < 1 {
+		failf(exitUsage, "--limit must be at least 1")
+		return
+	}
+	if buckets < 1 {
+		failf(exitUsage, "--buckets must be at least 1")
+		return
+	}
+	if err := opts.compileAppMatcher(); err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+	opts.Env = resolveEnv(opts.Env, orgID, cfg)
+
+	client := newAPIClient(cfg)
+	results, err := fetchScanList(client, orgID, opts)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list scans: %v", err)
+		return
+	}
+
+	var durations []time.Duration
+	excluded := 0
+	for _, result := range results {
+		if !window.ContainsTimestamp(result.Scan.Timestamp) {
+			continue
+		}
+		if d, ok := format.ParseDuration(result.ScanDuration); ok && d >= 0 {
+			durations = append(durations, d)
+		} else {
+			excluded++
+		}
+	}
+
+	stats := summarizeDurations(durations, buckets)
+	stats.Excluded = excluded
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	outputDurationStats(stats)
+}
+
+// summarizeDurations computes the statistics of durations and splits the range
+// from the shortest to the longest into equal-width buckets. Percentiles use the
+// nearest rank.
+func summarizeDurations(durations []time.Duration, buckets int) durationStats {
+	stats := durationStats{Scans: len(durations), Buckets: []durationBucket{}}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(0, i)]
+	}
+
+	low, high := sorted[0], sorted[len(sorted)-1]
+	stats.Min, stats.Max = low.Seconds(), high.Seconds()
+	stats.Median, stats.P90 = rank(0.5).Seconds(), rank(0.9).Seconds()
+	stats.Mean = (total / time.Duration(len(sorted))).Seconds()
+
+	// A single duration, or a span too short to split, gets one bucket
+	width := (high - low) / time.Duration(buckets)
+	if width <= 0 {
+		width, buckets = high-low, 1
+		if width <= 0 {
+			width = time.Second
+		}
+	}
+	for i := 0; i < buckets; i++ {
+		from := low + time.Duration(i)*width
+		to := from + width
+		if i == buckets-1 && high > to {
+			to = high
+		}
+		stats.Buckets = append(stats.Buckets, durationBucket{From: from.Seconds(), To: to.Seconds()})
+	}
+	for _, d := range sorted {
+		i := min(int((d-low)/width), buckets-1)
+		stats.Buckets[i].Count++
+	}
+	return stats
+}
+
+// outputDurationStats prints the summary table and the histogram, as a bar chart
+// when graphics are enabled
+func outputDurationStats(stats durationStats) {
+	if stats.Excluded > 0 {
+		fmt.Fprintf(errOut, "Left out %d scans without a duration\n", stats.Excluded)
+	}
+	if stats.Scans == 0 {
+		fmt.Fprintln(errOut, "No scan durations found.")
+		return
+	}
+
+	seconds := func(s float64) string {
+		return format.FormatDuration(time.Duration(s * float64(time.Second)))
+	}
+	summary := format.NewTable("SCANS", "MIN", "MEDIAN", "P90", "MAX", "MEAN")
+	summary.AddRow(format.Number(int64(stats.Scans)), seconds(stats.Min), seconds(stats.Median), seconds(stats.P90), seconds(stats.Max), seconds(stats.Mean))
+	fmt.Fprint(out, renderTable(summary))
+	fmt.Fprintln(out)
+
+	if graphicsEnabled() {
+		chart := format.NewBarChart(terminalWidth())
+		for _, bucket := range stats.Buckets {
+			chart.AddBar(seconds(bucket.From)+" - "+seconds(bucket.To), bucket.Count)
+		}
+		fmt.Fprint(out, chart.Render())
+		return
+	}
+
+	histogram := format.NewTable("FROM", "TO", "SCANS")
+	for _, bucket := range stats.Buckets {
+		histogram.AddRow(seconds(bucket.From), seconds(bucket.To), format.Number(int64(bucket.Count)))
+	}
+	fmt.Fprint(out, renderTable(histogram))
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeDurations(t *testing.T) {
+	var durations []time.Duration
+	for _, s := range []int{90, 10, 30, 20, 50, 40, 60, 70, 80, 100} {
+		durations = append(durations, time.Duration(s)*time.Second)
+	}
+
+	stats := summarizeDurations(durations, 3)
+	assert.Equal(t, 10, stats.Scans)
+	assert.Equal(t, 10.0, stats.Min)
+	assert.Equal(t, 50.0, stats.Median, "nearest rank")
+	assert.Equal(t, 90.0, stats.P90)
+	assert.Equal(t, 100.0, stats.Max)
+	assert.Equal(t, 55.0, stats.Mean)
+	assert.Equal(t, []durationBucket{
+		{From: 10, To: 40, Count: 3},
+		{From: 40, To: 70, Count: 3},
+		{From: 70, To: 100, Count: 4},
+	}, stats.Buckets, "the longest duration falls in the last bucket")
+	assert.Equal(t, 90*time.Second, durations[0], "the input is left unsorted")
+}
+
+func TestSummarizeDurations_EdgeCases(t *testing.T) {
+	empty := summarizeDurations(nil, 10)
+	assert.Zero(t, empty.Scans)
+	assert.Equal(t, []durationBucket{}, empty.Buckets)
+
+	same := summarizeDurations([]time.Duration{45 * time.Second, 45 * time.Second}, 10)
+	assert.Equal(t, []durationBucket{{From: 45, To: 46, Count: 2}}, same.Buckets, "identical durations share one bucket")
+	assert.Equal(t, 45.0, same.P90)
+}
+
+func TestRunScanDurations(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+
+	stdout, _ := captureOutput(t, func() { runScanDurations("json", "", scanListOptions{Limit: 10}, timeRange{}, 5) })
+	var stats durationStats
+	require.NoError(t, json.Unmarshal([]byte(stdout), &stats))
+	assert.Equal(t, 1, stats.Scans)
+	assert.Equal(t, 45.0, stats.Median)
+	require.Len(t, stats.Buckets, 1)
+	assert.Equal(t, 1, stats.Buckets[0].Count)
+
+	stdout, _ = captureOutput(t, func() { runScanDurations("table", "", scanListOptions{Limit: 10}, timeRange{}, 5) })
+	assert.Contains(t, stdout, "MEDIAN")
+	assert.Contains(t, stdout, "45s")
+
+	// Scans outside the window are not counted, nor reported as missing a duration
+	future := timeRange{After: time.Now().Add(time.Hour)}
+	stdout, stderr := captureOutput(t, func() { runScanDurations("table", "", scanListOptions{Limit: 10}, future, 5) })
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "No scan durations found.")
+	assert.NotContains(t, stderr, "Left out")
+	assert.Equal(t, exitOK, commandExitCode)
+
+	_, stderr = captureOutput(t, func() { runScanDurations("table", "", scanListOptions{Limit: 10}, timeRange{}, 0) })
+	assert.Contains(t, stderr, "--buckets must be at least 1")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/api"
+	"hawkop/internal/format"
+	"hawkop/internal/schema"
+)
+
+// schemaType is an output type whose JSON Schema can be exported
+type schemaType struct {
+	name        string
+	description string
+	value       interface{}
+}
+
+// schemaTypes lists the exportable types; each describes one element of the
+// corresponding --format json list output
+var schemaTypes = []schemaType{
+	{name: "scan", description: "Scan results from 'scan list' and 'scan get'", value: api.ApplicationScanResult{}},
+	{name: "alert", description: "Alerts from 'scan alerts'", value: api.ScanAlert{}},
+	{name: "app", description: "Applications from 'app list'", value: api.AppApplication{}},
+	{name: "team", description: "Teams from 'team list'", value: api.Team{}},
+	{name: "member", description: "Organization members from 'user list'", value: api.OrganizationMember{}},
+	{name: "org", description: "Organizations from 'org list'", value: api.Organization{}},
+}
+
+// schemaCmd exports JSON Schemas for the JSON output types
+var schemaCmd = &cobra.Command{
+	Use:   "schema [type]",
+	Short: "Print the JSON Schema for a JSON output type",
+	Long: `Print a JSON Schema describing the --format json output for a type.
+	
+Schemas are generated from the same Go types used to produce the output, so they
+document the contract of the JSON output. List commands print an array of the type.
+Run without a type to list the available types.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		if len(args) == 0 {
+			outputSchemaTypesTable()
+			return
+		}
+		runSchema(args[0], output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().String("output", "", "Write the schema to this file instead of stdout")
+}
+
+func runSchema(typeName string, outputPath string) {
+	st, ok := findSchemaType(typeName)
+	if !ok {
+		failf(exitUsage, "Unknown schema type: %s. Use one of: %s", typeName, strings.Join(schemaTypeNames(), ", "))
+		return
+	}
+
+	data, err := json.MarshalIndent(schema.Generate(st.value), "", "  ")
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+		return
+	}
+
+	if outputPath == "" {
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	err = os.WriteFile(outputPath, append(data, '\n'), 0644)
+	checkError(err)
+
+	fmt.Fprintf(errOut, "✅ Wrote %s schema to %s\n", st.name, outputPath)
+}
+
+// findSchemaType looks up a type by short name or Go type name, case-insensitively
+func findSchemaType(name string) (schemaType, bool) {
+	for _, st := range schemaTypes {
+		if strings.EqualFold(name, st.name) || strings.EqualFold(name, reflect.TypeOf(st.value).Name()) {
+			return st, true
+		}
+	}
+	return schemaType{}, false
+}
+
+func schemaTypeNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for _, st := range schemaTypes {
+		names = append(names, st.name)
+	}
+	return names
+}
+
+func outputSchemaTypesTable() {
+	table := format.NewTable("TYPE", "GO TYPE", "DESCRIPTION")
+	for _, st := range schemaTypes {
+		table.AddRow(st.name, reflect.TypeOf(st.value).Name(), st.description)
+	}
+	fmt.Fprint(out, renderTable(table))
+}
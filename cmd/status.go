@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"hawkop/internal/api"
 	"hawkop/internal/config"
 )
 
@@ -29,65 +30,70 @@ func init() {
 }
 
 func runStatus() {
-	fmt.Println("🦅 HawkOp Status")
-	fmt.Println("================")
-	fmt.Println()
+	fmt.Fprintln(out, "🦅 HawkOp Status")
+	fmt.Fprintln(out, "================")
+	fmt.Fprintln(out)
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("❌ Configuration Error: %v\n", err)
+		failf(exitCodeFor(err), "Configuration Error: %v", err)
 		return
 	}
 
 	// Display configuration file location
-	fmt.Printf("📁 Config file: %s\n", config.GetConfigFile())
-	fmt.Println()
+	fmt.Fprintf(out, "📁 Config file: %s\n", config.GetConfigFile())
+	fmt.Fprintln(out)
 
 	// Check API key status
 	if cfg.APIKey == "" {
-		fmt.Println("🔑 API Key: ❌ Not configured")
-		fmt.Println("   Run 'hawkop init' to set up your API key")
+		fmt.Fprintln(out, "🔑 API Key: ❌ Not configured")
+		fmt.Fprintln(out, "   Run 'hawkop init' to set up your API key")
 	} else {
-		fmt.Println("🔑 API Key: ✅ Configured")
-		fmt.Printf("   Key: %s...%s\n",
+		fmt.Fprintln(out, "🔑 API Key: ✅ Configured")
+		fmt.Fprintf(out, "   Key: %s...%s\n",
 			cfg.APIKey[:min(8, len(cfg.APIKey))],
 			strings.Repeat("*", max(0, len(cfg.APIKey)-8)))
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 
 	// Check organization status
 	if cfg.OrgID == "" {
-		fmt.Println("🏢 Default Org: ❌ Not set")
-		fmt.Println("   Use 'hawkop org set <org-id>' to set a default organization")
+		fmt.Fprintln(out, "🏢 Default Org: ❌ Not set")
+		fmt.Fprintln(out, "   Use 'hawkop org set <org-id>' to set a default organization")
 	} else {
-		fmt.Println("🏢 Default Org: ✅ Set")
-		fmt.Printf("   Organization ID: %s\n", cfg.OrgID)
+		fmt.Fprintln(out, "🏢 Default Org: ✅ Set")
+		fmt.Fprintf(out, "   Organization ID: %s\n", cfg.OrgID)
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 
-	// Check JWT status
-	if cfg.JWT == nil {
-		fmt.Println("🎫 JWT Token: ❌ None")
+	// Check JWT status for the API commands would use
+	apiURL, err := cfg.ResolveBaseURL(baseURL, instance)
+	if err != nil || apiURL == "" {
+		apiURL = api.DefaultBaseURL
+	}
+	if jwt := cfg.JWTFor(apiURL); jwt == nil {
+		fmt.Fprintln(out, "🎫 JWT Token: ❌ None")
 		if cfg.HasValidCredentials() {
-			fmt.Println("   A token will be automatically obtained when needed")
+			fmt.Fprintln(out, "   A token will be automatically obtained when needed")
 		}
-	} else if cfg.JWT.IsExpired() {
-		fmt.Println("🎫 JWT Token: ⏰ Expired")
-		fmt.Printf("   Expired at: %s\n", cfg.JWT.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
-		fmt.Println("   A fresh token will be obtained automatically")
+	} else if jwt.IsExpired() {
+		fmt.Fprintln(out, "🎫 JWT Token: ⏰ Expired")
+		fmt.Fprintf(out, "   Expired at: %s\n", jwt.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+		fmt.Fprintln(out, "   A fresh token will be obtained automatically")
 	} else {
-		fmt.Println("🎫 JWT Token: ✅ Valid")
-		fmt.Printf("   Expires at: %s\n", cfg.JWT.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+		fmt.Fprintln(out, "🎫 JWT Token: ✅ Valid")
+		fmt.Fprintf(out, "   Expires at: %s\n", jwt.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
 	}
-	fmt.Println()
+	fmt.Fprintf(out, "   API: %s\n", apiURL)
+	fmt.Fprintln(out)
 
 	// Overall status
 	if !cfg.HasValidCredentials() {
-		fmt.Println("🔗 Overall Status: ❌ Not ready")
-		fmt.Println("   Please run 'hawkop init' to configure your API key")
+		fmt.Fprintln(out, "🔗 Overall Status: ❌ Not ready")
+		fmt.Fprintln(out, "   Please run 'hawkop init' to configure your API key")
 	} else {
-		fmt.Println("🔗 Overall Status: ✅ Ready")
-		fmt.Println("   You can now use hawkop commands")
+		fmt.Fprintln(out, "🔗 Overall Status: ✅ Ready")
+		fmt.Fprintln(out, "   You can now use hawkop commands")
 	}
 }
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/format"
+	"hawkop/internal/suppress"
+)
+
+// suppressCmd represents the suppress command
+var suppressCmd = &cobra.Command{
+	Use:   "suppress",
+	Short: "Manage alert suppressions",
+	Long: `Manage the alert suppression list used to hide or annotate accepted-risk findings.
+	
+Suppressions are stored in ~/.config/hawkop/suppressions.yaml and apply to 'hawkop scan alerts'.
+Each entry suppresses a plugin ID, optionally scoped to an application and environment.`,
+}
+
+// suppressAddCmd adds a suppression entry
+var suppressAddCmd = &cobra.Command{
+	Use:   "add <plugin-id>",
+	Short: "Suppress alerts for a plugin",
+	Long: `Add a plugin ID to the suppression list.
+	
+Use --app (application name or ID) and --env to limit the suppression to a specific
+application or environment. Without them, the plugin is suppressed everywhere.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app, _ := cmd.Flags().GetString("app")
+		env, _ := cmd.Flags().GetString("env")
+		reason, _ := cmd.Flags().GetString("reason")
+		runSuppressAdd(args[0], app, env, reason)
+	},
+}
+
+// suppressListCmd lists suppression entries
+var suppressListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List alert suppressions",
+	Long:  `List all entries in the alert suppression list.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		runSuppressList(format)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(suppressCmd)
+	suppressCmd.AddCommand(suppressAddCmd)
+	suppressCmd.AddCommand(suppressListCmd)
+
+	// Add flags for suppress add command
+	suppressAddCmd.Flags().StringP("app", "a", "", "Limit the suppression to an application name or ID")
+	suppressAddCmd.Flags().StringP("env", "e", "", "Limit the suppression to an environment")
+	// A suppression is only narrowed when asked to
+	_ = suppressAddCmd.Flags().SetAnnotation("app", noProjectDefault, []string{"true"})
+	_ = suppressAddCmd.Flags().SetAnnotation("env", noProjectDefault, []string{"true"})
+	suppressAddCmd.Flags().StringP("reason", "r", "", "Reason for suppressing, e.g. accepted risk")
+
+	// Add flags for suppress list command
+	suppressListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(suppressListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(suppressListCmd)
+}
+
+func runSuppressAdd(pluginID string, app string, env string, reason string) {
+	list, err := suppress.Load()
+	checkError(err)
+
+	if err := list.Add(suppress.Rule{PluginID: pluginID, App: app, Env: env, Reason: reason}); err != nil {
+		failf(exitCodeFor(err), "%v", err)
+		return
+	}
+
+	err = list.Save()
+	checkError(err)
+
+	fmt.Fprintf(errOut, "✅ Suppressed plugin %s (%s)\n", pluginID, describeSuppressionScope(app, env))
+}
+
+func runSuppressList(outputFormat string) {
+	list, err := suppress.Load()
+	checkError(err)
+
+	if outputTemplated(list.Rules) {
+		return
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		rules := list.Rules
+		if rules == nil {
+			rules = []suppress.Rule{}
+		}
+		data, err := json.MarshalIndent(rules, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "table":
+		outputSuppressionsTable(list.Rules)
+	case "tsv":
+		outputTSV(suppressionsTable(list.Rules))
+	default:
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
+	}
+}
+
+func outputSuppressionsTable(rules []suppress.Rule) {
+	if len(rules) == 0 {
+		fmt.Fprintln(errOut, "No suppressions found.")
+		return
+	}
+
+	fmt.Fprint(out, renderTable(suppressionsTable(rules)))
+}
+
+// suppressionsTable lays out suppression rules for table and tsv output
+func suppressionsTable(rules []suppress.Rule) *format.TableWriter {
+	table := format.NewTable("PLUGIN ID", "APP", "ENV", "REASON", "CREATED")
+
+	for _, rule := range rules {
+		app := rule.App
+		if app == "" {
+			app = "*"
+		}
+
+		env := rule.Env
+		if env == "" {
+			env = "*"
+		}
+
+		reason := rule.Reason
+		if reason == "" {
+			reason = "N/A"
+		}
+
+		created := rule.CreatedAt
+		if len(created) >= 10 {
+			created = created[:10]
+		}
+
+		table.AddRow(rule.PluginID, app, env, reason, created)
+	}
+
+	return table
+}
+
+// describeSuppressionScope returns a human description of where a suppression applies
+func describeSuppressionScope(app string, env string) string {
+	switch {
+	case app != "" && env != "":
+		return fmt.Sprintf("app %s, env %s", app, env)
+	case app != "":
+		return fmt.Sprintf("app %s, all environments", app)
+	case env != "":
+		return fmt.Sprintf("all apps, env %s", env)
+	default:
+		return "all apps and environments"
+	}
+}
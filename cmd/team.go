@@ -1,16 +1,18 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"hawkop/internal/api"
-	"hawkop/internal/config"
 	"hawkop/internal/format"
 )
 
@@ -35,94 +37,377 @@ organization using the --org flag. This command requires ADMIN or OWNER role.`,
 		format, _ := cmd.Flags().GetString("format")
 		limit, _ := cmd.Flags().GetInt("limit")
 		org, _ := cmd.Flags().GetString("org")
-		runTeamList(format, limit, org)
+		created, err := createdRangeFromFlags(cmd)
+		if err != nil {
+			failf(exitUsage, "%v", err)
+			return
+		}
+		runTeamList(format, limit, org, created)
+	},
+}
+
+// teamCreateCmd creates a team in an organization
+var teamCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a team in an organization",
+	Long: `Create a new, empty team in the specified organization.
+	
+You are asked to confirm the change; pass --yes to skip the prompt. This command
+requires ADMIN or OWNER role.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		yes, _ := cmd.Flags().GetBool("confirm")
+		runTeamCreate(args[0], format, org, yes)
+	},
+}
+
+// teamAddMemberCmd adds a user to a team
+var teamAddMemberCmd = &cobra.Command{
+	Use:   "add-member <team-id> <user-id>",
+	Short: "Add a user to a team",
+	Long: `Add an organization member to a team by StackHawk user ID.
+	
+Use 'hawkop user list --format json' to find user IDs. You are asked to confirm the
+change; pass --yes to skip the prompt. This command requires ADMIN or OWNER role.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		yes, _ := cmd.Flags().GetBool("confirm")
+		runTeamMemberChange(args[0], args[1], true, format, org, yes)
+	},
+}
+
+// teamRemoveMemberCmd removes a user from a team
+var teamRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <team-id> <user-id>",
+	Short: "Remove a user from a team",
+	Long: `Remove a member from a team by StackHawk user ID.
+	
+You are asked to confirm the change; pass --yes to skip the prompt. This command
+requires ADMIN or OWNER role.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		yes, _ := cmd.Flags().GetBool("confirm")
+		runTeamMemberChange(args[0], args[1], false, format, org, yes)
+	},
+}
+
+// teamAppsCmd reports which teams own which applications
+var teamAppsCmd = &cobra.Command{
+	Use:   "apps",
+	Short: "Show which applications each team owns",
+	Long: `List each team with the applications assigned to it, for routing findings to
+their owners. With --by-app, list each application with the teams it is assigned
+to instead, including applications no team owns.
+
+By default, uses your configured default organization. You can specify a different
+organization using the --org flag. This command requires ADMIN or OWNER role.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		byApp, _ := cmd.Flags().GetBool("by-app")
+		runTeamApps(format, org, byApp)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(teamCmd)
 	teamCmd.AddCommand(teamListCmd)
+	teamCmd.AddCommand(teamCreateCmd)
+	teamCmd.AddCommand(teamAddMemberCmd)
+	teamCmd.AddCommand(teamRemoveMemberCmd)
+	teamCmd.AddCommand(teamAppsCmd)
 
 	// Add flags for team list command
-	teamListCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
+	teamListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSV))
+	completeEnum(teamListCmd, "format", formatsTableJSONTSV)
+	addTemplateFlag(teamListCmd)
 	teamListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
 	teamListCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	addCreatedRangeFlags(teamListCmd)
+
+	// Add flags for team apps command
+	teamAppsCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSVCSV))
+	completeEnum(teamAppsCmd, "format", formatsTableJSONTSVCSV)
+	teamAppsCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+	teamAppsCmd.Flags().Bool("by-app", false, "List each application with its owning teams")
+
+	// Add flags for team mutation commands
+	for _, cmd := range []*cobra.Command{teamCreateCmd, teamAddMemberCmd, teamRemoveMemberCmd} {
+		cmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+		completeEnum(cmd, "format", formatsTableJSON)
+		cmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
+		cmd.Flags().Bool("confirm", false, "Confirm the change")
+		_ = cmd.Flags().MarkDeprecated("confirm", "use --yes instead")
+	}
 }
 
-func runTeamList(outputFormat string, limit int, orgID string) {
+func runTeamList(outputFormat string, limit int, orgID string, created timeRange) {
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	checkError(err)
 
 	// Validate that we have credentials
 	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
 		return
 	}
 
 	// Determine which organization to use
-	if orgID == "" {
-		orgID = cfg.OrgID
-		if orgID == "" {
-			fmt.Println("❌ No organization specified. Use --org flag or set a default with 'hawkop org set <org-id>'")
-			return
-		}
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
 	}
 
 	// Create API client
-	client := api.NewClient(cfg)
+	client := newAPIClient(cfg)
 
 	// Get organization teams
 	teams, err := client.ListOrganizationTeams(orgID)
 	if err != nil {
-		fmt.Printf("❌ Failed to list teams: %v\n", err)
+		failf(exitCodeFor(err), "Failed to list teams: %v", err)
 		return
 	}
 
+	// Apply created date filter if specified
+	teams = filterTeamsByCreated(teams, created)
+
 	// Apply limit if specified
 	if limit > 0 && len(teams) > limit {
 		teams = teams[:limit]
 	}
 
+	if outputTemplated(teams) {
+		return
+	}
+
 	// Output based on format
 	switch strings.ToLower(outputFormat) {
 	case "json":
 		outputTeamsJSON(teams)
 	case "table":
 		outputTeamsTable(teams)
+	case "tsv":
+		outputTSV(teamsTable(teams))
 	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+		failUnknownFormat(outputFormat, formatsTableJSONTSV)
+		return
+	}
+}
+
+func runTeamCreate(name string, outputFormat string, orgID string, yes bool) {
+	outputFormat = strings.ToLower(outputFormat)
+	if !slices.Contains(formatsTableJSON, outputFormat) {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+	if strings.TrimSpace(name) == "" {
+		failf(exitUsage, "Team name is required.")
+		return
+	}
+	if !yes && !confirm(fmt.Sprintf("This will create team %q.", name)) {
+		return
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
 		return
 	}
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	team, err := client.CreateTeam(orgID, name)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to create team: %v%s", err, teamErrorHint(err))
+		return
+	}
+
+	// Output based on format
+	switch outputFormat {
+	case "json":
+		outputTeamsJSON([]api.Team{*team})
+	case "table":
+		fmt.Fprintf(errOut, "✅ Team created: %s (%s)\n", team.Name, team.ID)
+		outputTeamsTable([]api.Team{*team})
+	}
+}
+
+// runTeamMemberChange adds or removes a team member and prints the resulting membership
+func runTeamMemberChange(teamID string, userID string, add bool, outputFormat string, orgID string, yes bool) {
+	action, verb := "remove", "removed from"
+	if add {
+		action, verb = "add", "added to"
+	}
+
+	outputFormat = strings.ToLower(outputFormat)
+	if !slices.Contains(formatsTableJSON, outputFormat) {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+
+	if strings.TrimSpace(teamID) == "" || strings.TrimSpace(userID) == "" {
+		failf(exitUsage, "Team ID and user ID are required.")
+		return
+	}
+	if !validIDs("team", teamID) || !validIDs("user", userID) {
+		return
+	}
+	if !yes && !confirm(fmt.Sprintf("This will %s user %s in team %s.", action, userID, teamID)) {
+		return
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	// Create API client
+	client := newAPIClient(cfg)
+
+	if add {
+		err = client.AddTeamMember(orgID, teamID, userID)
+	} else {
+		err = client.RemoveTeamMember(orgID, teamID, userID)
+	}
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to %s team member: %v%s", action, err, teamErrorHint(err))
+		return
+	}
+
+	fmt.Fprintf(errOut, "✅ User %s %s team %s\n", userID, verb, teamID)
+
+	// Show the resulting membership
+	team, err := client.GetTeam(orgID, teamID)
+	if err != nil {
+		fmt.Fprintf(errOut, "⚠️  Failed to read back team membership: %v\n", err)
+		return
+	}
+
+	switch outputFormat {
+	case "json":
+		outputTeamsJSON([]api.Team{*team})
+	case "table":
+		outputTeamMembersTable(*team)
+	}
+}
+
+// teamErrorHint explains common permission and conflict failures for team changes
+func teamErrorHint(err error) string {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	switch apiErr.StatusCode {
+	case http.StatusForbidden:
+		return "\n   Managing teams requires the ADMIN or OWNER role in the organization."
+	case http.StatusConflict:
+		return "\n   A team with this name may already exist. Use 'hawkop team list' to check."
+	default:
+		return ""
+	}
+}
+
+func outputTeamMembersTable(team api.Team) {
+	if len(team.Users) == 0 {
+		fmt.Fprintf(errOut, "Team %s has no members.\n", team.Name)
+		return
+	}
+
+	table := format.NewTable("USER ID", "NAME", "EMAIL")
+
+	for _, member := range team.Users {
+		name := "N/A"
+		email := "N/A"
+		if member.External != nil {
+			if member.External.FullName != "" {
+				name = member.External.FullName
+			}
+			if member.External.Email != "" {
+				email = member.External.Email
+			}
+		}
+
+		table.AddRow(member.StackhawkId, name, email)
+	}
+
+	fmt.Fprint(out, renderTable(table))
+}
+
+// filterTeamsByCreated keeps teams whose CreatedTimestamp falls within the range
+func filterTeamsByCreated(teams []api.Team, created timeRange) []api.Team {
+	if !created.IsSet() {
+		return teams
+	}
+
+	filteredTeams := []api.Team{}
+	for _, team := range teams {
+		if created.ContainsTimestamp(team.CreatedTimestamp) {
+			filteredTeams = append(filteredTeams, team)
+		}
+	}
+	return filteredTeams
 }
 
 func outputTeamsJSON(teams []api.Team) {
 	data, err := json.MarshalIndent(teams, "", "  ")
 	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 		return
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 }
 
 func outputTeamsTable(teams []api.Team) {
 	if len(teams) == 0 {
-		fmt.Println("No teams found.")
+		fmt.Fprintln(errOut, "No teams found.")
 		return
 	}
 
+	fmt.Fprint(out, renderTable(teamsTable(teams)))
+}
+
+// teamsTable lays out teams with their user and app counts for table and tsv output
+func teamsTable(teams []api.Team) *format.TableWriter {
 	table := format.NewTable("ID", "NAME", "USERS", "APPS", "CREATED")
 
 	for _, team := range teams {
 		// Count users and applications
-		userCount := fmt.Sprintf("%d", len(team.Users))
-		appCount := fmt.Sprintf("%d", len(team.Applications))
+		userCount := format.Number(int64(len(team.Users)))
+		appCount := format.Number(int64(len(team.Applications)))
 
 		// Format created date
 		created := ""
 		if team.CreatedTimestamp != "" {
 			if ts, err := strconv.ParseInt(team.CreatedTimestamp, 10, 64); err == nil {
-				created = time.Unix(ts/1000, 0).Format("2006-01-02")
+				created = format.TimeIn(ts, displayLocation).Format("2006-01-02")
 			}
 		}
 
@@ -135,5 +420,180 @@ func outputTeamsTable(teams []api.Team) {
 		table.AddRow(team.ID, name, userCount, appCount, created)
 	}
 
-	fmt.Print(table.Render())
+	return table
+}
+
+// teamApps is a team with the applications assigned to it
+type teamApps struct {
+	TeamID       string            `json:"teamId"`
+	TeamName     string            `json:"teamName"`
+	Applications []api.Application `json:"applications"`
+}
+
+// appTeams is an application with the teams it is assigned to
+type appTeams struct {
+	ApplicationID   string     `json:"applicationId"`
+	ApplicationName string     `json:"applicationName"`
+	Teams           []teamInfo `json:"teams"`
+}
+
+// teamInfo identifies a team in appTeams
+type teamInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func runTeamApps(outputFormat string, orgID string, byApp bool) {
+	outputFormat = strings.ToLower(outputFormat)
+	if !slices.Contains(formatsTableJSONTSVCSV, outputFormat) {
+		failUnknownFormat(outputFormat, formatsTableJSONTSVCSV)
+		return
+	}
+
+	cfg, err := loadConfig()
+	checkError(err)
+
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	client := newAPIClient(cfg)
+	teams, err := client.ListOrganizationTeams(orgID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list teams: %v", err)
+		return
+	}
+
+	if !byApp {
+		outputTeamApps(outputFormat, teamsWithApps(teams))
+		return
+	}
+
+	apps, err := client.ListOrganizationApplications(orgID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list applications: %v", err)
+		return
+	}
+	outputAppTeams(outputFormat, appsWithTeams(apps, teams))
+}
+
+// teamsWithApps lists each team's applications, sorted by name. Teams without
+// applications get an empty list.
+func teamsWithApps(teams []api.Team) []teamApps {
+	result := make([]teamApps, 0, len(teams))
+	for _, team := range teams {
+		apps := slices.Clone(team.Applications)
+		if apps == nil {
+			apps = []api.Application{}
+		}
+		slices.SortFunc(apps, func(a, b api.Application) int { return strings.Compare(a.Name, b.Name) })
+		result = append(result, teamApps{TeamID: team.ID, TeamName: team.Name, Applications: apps})
+	}
+	return result
+}
+
+// appsWithTeams lists each of the organization's applications with the teams it is
+// assigned to, sorted by name. Applications no team owns get an empty list, and
+// applications only known from a team's assignments are added after the rest.
+func appsWithTeams(apps []api.AppApplication, teams []api.Team) []appTeams {
+	teamsByApp := make(map[string][]teamInfo)
+	var assignedOnly []api.Application
+	known := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		known[app.ApplicationID] = true
+	}
+
+	for _, team := range teams {
+		for _, app := range team.Applications {
+			info := teamInfo{ID: team.ID, Name: team.Name}
+			if slices.Contains(teamsByApp[app.ID], info) {
+				continue
+			}
+			teamsByApp[app.ID] = append(teamsByApp[app.ID], info)
+			if !known[app.ID] {
+				known[app.ID] = true
+				assignedOnly = append(assignedOnly, app)
+			}
+		}
+	}
+
+	result := make([]appTeams, 0, len(apps)+len(assignedOnly))
+	add := func(id, name string) {
+		owners := append([]teamInfo{}, teamsByApp[id]...)
+		slices.SortFunc(owners, func(a, b teamInfo) int { return strings.Compare(a.Name, b.Name) })
+		result = append(result, appTeams{ApplicationID: id, ApplicationName: name, Teams: owners})
+	}
+	for _, app := range apps {
+		add(app.ApplicationID, app.Name)
+	}
+	for _, app := range assignedOnly {
+		add(app.ID, app.Name)
+	}
+	return result
+}
+
+// outputTeamApps writes each team's applications in outputFormat
+func outputTeamApps(outputFormat string, teams []teamApps) {
+	table := format.NewTable("TEAM ID", "TEAM", "APPS", "APPLICATIONS")
+	for _, team := range teams {
+		names := make([]string, 0, len(team.Applications))
+		for _, app := range team.Applications {
+			names = append(names, cmp.Or(app.Name, app.ID))
+		}
+		table.AddRow(team.TeamID, cmp.Or(team.TeamName, "N/A"), format.Number(int64(len(names))), strings.Join(names, ", "))
+	}
+	outputOwnership(outputFormat, teams, len(teams), table, "No teams found.")
+}
+
+// outputAppTeams writes each application's owning teams in outputFormat
+func outputAppTeams(outputFormat string, apps []appTeams) {
+	table := format.NewTable("APP ID", "APPLICATION", "TEAMS")
+	for _, app := range apps {
+		names := make([]string, 0, len(app.Teams))
+		for _, team := range app.Teams {
+			names = append(names, cmp.Or(team.Name, team.ID))
+		}
+		table.AddRow(app.ApplicationID, cmp.Or(app.ApplicationName, "N/A"), strings.Join(names, ", "))
+	}
+	outputOwnership(outputFormat, apps, len(apps), table, "No applications found.")
+
+	unowned := 0
+	for _, app := range apps {
+		if len(app.Teams) == 0 {
+			unowned++
+		}
+	}
+	if outputFormat == "table" && unowned > 0 {
+		fmt.Fprintf(errOut, "%d of %d applications have no team\n", unowned, len(apps))
+	}
+}
+
+// outputOwnership writes an ownership report of rows entries as JSON from data, or
+// from table, printing empty instead of an empty table
+func outputOwnership(outputFormat string, data interface{}, rows int, table *format.TableWriter, empty string) {
+	switch outputFormat {
+	case "json":
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(encoded))
+	case "table":
+		if rows == 0 {
+			fmt.Fprintln(errOut, empty)
+			return
+		}
+		fmt.Fprint(out, renderTable(table))
+	case "tsv":
+		outputTSV(table)
+	case "csv":
+		outputCSV(table)
+	}
 }
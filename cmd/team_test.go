@@ -2,11 +2,18 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"hawkop/internal/api"
@@ -62,6 +69,66 @@ func (suite *TeamCommandTestSuite) TestTeamCommand_Structure() {
 	}
 
 	assert.Contains(suite.T(), subcommands, "list")
+	assert.Contains(suite.T(), subcommands, "create <name>")
+	assert.Contains(suite.T(), subcommands, "add-member <team-id> <user-id>")
+	assert.Contains(suite.T(), subcommands, "remove-member <team-id> <user-id>")
+}
+
+func (suite *TeamCommandTestSuite) TestTeamMutations_RequireConfirm() {
+	for _, cmd := range []*cobra.Command{teamCreateCmd, teamAddMemberCmd, teamRemoveMemberCmd} {
+		confirmFlag := cmd.Flags().Lookup("confirm")
+		if assert.NotNil(suite.T(), confirmFlag, cmd.Use) {
+			assert.Equal(suite.T(), "false", confirmFlag.DefValue)
+		}
+	}
+
+	stubNonInteractive(suite.T())
+	stdout, stderr := captureOutput(suite.T(), func() { runTeamCreate("Platform", "table", "", false) })
+	assert.Empty(suite.T(), stdout)
+	assert.Contains(suite.T(), stderr, "--yes")
+
+	_, stderr = captureOutput(suite.T(), func() { runTeamMemberChange("team-1", "user-1", true, "table", "", false) })
+	assert.Contains(suite.T(), stderr, "add user user-1")
+
+	_, stderr = captureOutput(suite.T(), func() { runTeamCreate(" ", "table", "", true) })
+	assert.Contains(suite.T(), stderr, "Team name is required")
+}
+
+// An unsupported format is rejected before anything is changed, so a retry
+// with a valid format can't create a duplicate team
+func (suite *TeamCommandTestSuite) TestTeamMutations_RejectFormatFirst() {
+	resetExitCode(suite.T())
+	refuseAPI(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() { runTeamCreate("Platform", "csv", "", true) })
+	assert.Contains(suite.T(), stderr, "Unknown format: csv")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+
+	_, stderr = captureOutput(suite.T(), func() { runTeamMemberChange("team-1", "user-1", true, "tsv", "", true) })
+	assert.Contains(suite.T(), stderr, "Unknown format: tsv")
+}
+
+func (suite *TeamCommandTestSuite) TestTeamMemberChange_MalformedIDs() {
+	resetExitCode(suite.T())
+	refuseAPI(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() { runTeamMemberChange("team 1", "user-1", true, "table", "", true) })
+	assert.Contains(suite.T(), stderr, `invalid team ID "team 1"`)
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+
+	_, stderr = captureOutput(suite.T(), func() { runTeamMemberChange("team-1", "user@example.com", false, "table", "", true) })
+	assert.Contains(suite.T(), stderr, `invalid user ID "user@example.com"`)
+}
+
+func (suite *TeamCommandTestSuite) TestTeamErrorHint() {
+	forbidden := &api.APIError{StatusCode: http.StatusForbidden}
+	assert.Contains(suite.T(), teamErrorHint(forbidden), "ADMIN or OWNER")
+	assert.Contains(suite.T(), teamErrorHint(fmt.Errorf("create team: %w", forbidden)), "ADMIN or OWNER")
+	assert.Contains(suite.T(), teamErrorHint(&api.APIError{StatusCode: http.StatusConflict}), "already exist")
+
+	// Only the status code counts, not text that happens to look like one
+	assert.Empty(suite.T(), teamErrorHint(errors.New("team name (403) rejected")))
+	assert.Empty(suite.T(), teamErrorHint(&api.APIError{StatusCode: http.StatusInternalServerError, Body: "(409)"}))
 }
 
 func (suite *TeamCommandTestSuite) TestTeamListFlags() {
@@ -78,6 +145,89 @@ func (suite *TeamCommandTestSuite) TestTeamListFlags() {
 
 	orgFlag := cmd.Flags().Lookup("org")
 	assert.NotNil(suite.T(), orgFlag)
+
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("created-after"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("created-before"))
+}
+
+func (suite *TeamCommandTestSuite) TestFilterTeamsByCreated() {
+	teams := []api.Team{
+		{ID: "old", CreatedTimestamp: "1700000000000"},
+		{ID: "new", CreatedTimestamp: "1750000000000"},
+		{ID: "unknown"},
+	}
+
+	// No bounds keeps everything, including teams without timestamps
+	assert.Len(suite.T(), filterTeamsByCreated(teams, timeRange{}), 3)
+
+	filtered := filterTeamsByCreated(teams, timeRange{After: time.UnixMilli(1720000000000)})
+	assert.Len(suite.T(), filtered, 1)
+	assert.Equal(suite.T(), "new", filtered[0].ID)
+}
+
+func (suite *TeamCommandTestSuite) TestTeamsWithApps() {
+	teams := []api.Team{
+		{ID: "t1", Name: "Security", Applications: []api.Application{{ID: "a2", Name: "Web"}, {ID: "a1", Name: "Billing"}}},
+		{ID: "t2", Name: "Empty"},
+	}
+
+	result := teamsWithApps(teams)
+	require.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), []api.Application{{ID: "a1", Name: "Billing"}, {ID: "a2", Name: "Web"}}, result[0].Applications, "sorted by name")
+	assert.Equal(suite.T(), []api.Application{}, result[1].Applications, "teams without apps get an empty list")
+	assert.Equal(suite.T(), "a2", teams[0].Applications[0].ID, "the team's own list is left unsorted")
+}
+
+func (suite *TeamCommandTestSuite) TestAppsWithTeams() {
+	apps := []api.AppApplication{
+		{ApplicationID: "a1", Name: "Billing"},
+		{ApplicationID: "a2", Name: "Web"},
+		{ApplicationID: "a3", Name: "Orphan"},
+	}
+	teams := []api.Team{
+		{ID: "t2", Name: "Security", Applications: []api.Application{{ID: "a1"}, {ID: "a2"}, {ID: "a1"}}},
+		{ID: "t1", Name: "Payments", Applications: []api.Application{{ID: "a1"}, {ID: "a9", Name: "Legacy"}}},
+	}
+
+	result := appsWithTeams(apps, teams)
+	require.Len(suite.T(), result, 4)
+	assert.Equal(suite.T(), []teamInfo{{ID: "t1", Name: "Payments"}, {ID: "t2", Name: "Security"}}, result[0].Teams, "owned by several teams, each once")
+	assert.Equal(suite.T(), []teamInfo{{ID: "t2", Name: "Security"}}, result[1].Teams)
+	assert.Equal(suite.T(), []teamInfo{}, result[2].Teams, "apps without a team get an empty list")
+	assert.Equal(suite.T(), appTeams{ApplicationID: "a9", ApplicationName: "Legacy", Teams: []teamInfo{{ID: "t1", Name: "Payments"}}}, result[3],
+		"apps only known from a team's assignments come last")
+}
+
+func (suite *TeamCommandTestSuite) TestTeamApps_ByTeam() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runTeamApps("csv", "", false) })
+	assert.Equal(suite.T(), "TEAM ID,TEAM,APPS,APPLICATIONS\n"+
+		"team-1,Mock Team 1,0,\n"+
+		"team-2,Mock Team 2,1,Mock App\n", stdout)
+
+	stdout, _ = captureOutput(suite.T(), func() { runTeamApps("json", "", false) })
+	var teams []teamApps
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &teams))
+	require.Len(suite.T(), teams, 2)
+	assert.Equal(suite.T(), []api.Application{{ID: "app-1", Name: "Mock App"}}, teams[1].Applications)
+	assert.Contains(suite.T(), stdout, `"applications": []`)
+}
+
+func (suite *TeamCommandTestSuite) TestTeamApps_ByApp() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runTeamApps("csv", "", true) })
+	assert.Equal(suite.T(), "APP ID,APPLICATION,TEAMS\napp-1,Mock Application,Mock Team 2\n", stdout)
+
+	stdout, stderr := captureOutput(suite.T(), func() { runTeamApps("table", "", true) })
+	assert.Contains(suite.T(), stdout, "Mock Team 2")
+	assert.NotContains(suite.T(), stderr, "have no team")
+
+	resetExitCode(suite.T())
+	_, stderr = captureOutput(suite.T(), func() { runTeamApps("yaml", "", true) })
+	assert.Contains(suite.T(), stderr, "Unknown format: yaml")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
 }
 
 func TestTeamCommandTestSuite(t *testing.T) {
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"hawkop/internal/format"
+)
+
+var (
+	// templateText is the raw --template value, parsed into outputTemplate before each command
+	templateText   string
+	outputTemplate *template.Template
+	// selectText is the raw --select value, parsed into outputSelect before each command
+	selectText   string
+	outputSelect format.SelectPath
+)
+
+// addTemplateFlag adds --template and --select to a list command. Its run function
+// passes the result slice to outputTemplated before choosing a --format.
+func addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&templateText, "template", "",
+		"Render the results with a Go text/template instead of --format; @file reads the template from a file")
+	cmd.Flags().StringVar(&selectText, "select", "",
+		"Print one field of each result per line instead of --format, by JSON path (e.g. scan.id, environments[*].env)")
+	cmd.MarkFlagsMutuallyExclusive("template", "select")
+}
+
+// loadTemplate parses a --template value, reading the template from a file when the
+// value starts with @
+func loadTemplate(value string) (*template.Template, error) {
+	text := value
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --template file: %w", err)
+		}
+		text = string(data)
+	}
+
+	tmpl, err := format.NewTemplate(text, displayLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templating reports whether --template or --select replaces --format
+func templating() bool {
+	return outputTemplate != nil || outputSelect != nil
+}
+
+// outputTemplated renders data with --template or --select, returning false when
+// neither was given. Output is buffered so a template that fails partway prints
+// nothing but the error.
+func outputTemplated(data interface{}) bool {
+	if outputSelect != nil {
+		outputSelected(data)
+		return true
+	}
+	if outputTemplate == nil {
+		return false
+	}
+
+	var buf bytes.Buffer
+	if err := outputTemplate.Execute(&buf, data); err != nil {
+		failf(exitUsage, "Failed to render --template: %v", err)
+		return true
+	}
+	out.Write(buf.Bytes())
+	return true
+}
+
+// outputSelected prints the --select path's values from each element of data, a
+// list of results, one per line. The path applies to the JSON form of the results.
+func outputSelected(data interface{}) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		failf(exitError, "Failed to encode results for --select: %v", err)
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		failf(exitError, "Failed to decode results for --select: %v", err)
+		return
+	}
+	elements, ok := decoded.([]interface{})
+	if !ok {
+		elements = []interface{}{decoded}
+	}
+
+	var buf bytes.Buffer
+	matched := 0
+	for _, element := range elements {
+		for _, value := range outputSelect.Select(element) {
+			line, err := format.SelectedValue(value)
+			if err != nil {
+				failf(exitError, "Failed to format --select value: %v", err)
+				return
+			}
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+			matched++
+		}
+	}
+	out.Write(buf.Bytes())
+
+	if matched == 0 && len(elements) > 0 {
+		fmt.Fprintf(errOut, "⚠️  --select %s matched no fields in %d results\n", selectText, len(elements))
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/format"
+)
+
+// useTemplate sets --template for the duration of a test
+func useTemplate(t *testing.T, text string) {
+	t.Helper()
+
+	tmpl, err := loadTemplate(text)
+	require.NoError(t, err)
+	outputTemplate = tmpl
+	t.Cleanup(func() { outputTemplate = nil })
+}
+
+func TestTemplate_ScanList(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useTemplate(t, `{{range .}}{{.Scan.ID}} {{.Scan.Status}}{{"\n"}}{{end}}`)
+
+	// --template replaces --format, including the streamed JSON
+	for _, outputFormat := range []string{"table", "json"} {
+		stdout, _ := captureOutput(t, func() { runScanList(outputFormat, "", scanListOptions{}, false, 0) })
+		assert.Equal(t, "scan-1 COMPLETED\n", stdout, outputFormat)
+	}
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestTemplate_AppListFuncs(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useTemplate(t, `{{range .}}{{upper .Name}} {{json .Environments}}{{end}}`)
+
+	stdout, _ := captureOutput(t, func() { runAppList("table", 0, "", "", false) })
+	assert.Equal(t, `MOCK APPLICATION [{"envId":"env-1","env":"development","policyName":"DEFAULT"},{"envId":"env-2","env":"production"}]`, stdout)
+}
+
+func TestTemplate_ExecError(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useTemplate(t, `{{range .}}{{.ApplicationID}} {{.NoSuchField}}{{end}}`)
+
+	stdout, stderr := captureOutput(t, func() { runAppList("table", 0, "", "", false) })
+	assert.Empty(t, stdout, "a failed template prints nothing")
+	assert.Contains(t, stderr, "Failed to render --template")
+	assert.Contains(t, stderr, "NoSuchField")
+	assert.Equal(t, exitUsage, commandExitCode)
+}
+
+func TestLoadTemplate(t *testing.T) {
+	_, err := loadTemplate(`{{range .}}`)
+	assert.ErrorContains(t, err, "invalid --template")
+
+	path := filepath.Join(t.TempDir(), "scans.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(`{{len .}}`), 0600))
+	tmpl, err := loadTemplate("@" + path)
+	require.NoError(t, err)
+	assert.Equal(t, "output", tmpl.Name())
+
+	_, err = loadTemplate("@" + filepath.Join(t.TempDir(), "missing.tmpl"))
+	assert.ErrorContains(t, err, "failed to read --template file")
+}
+
+// useSelect sets --select for the duration of a test
+func useSelect(t *testing.T, expr string) {
+	t.Helper()
+
+	path, err := format.ParseSelectPath(expr)
+	require.NoError(t, err)
+	outputSelect, selectText = path, expr
+	t.Cleanup(func() { outputSelect, selectText = nil, "" })
+}
+
+func TestSelect_ScanList(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useSelect(t, "scan.id")
+
+	for _, outputFormat := range []string{"table", "json"} {
+		stdout, _ := captureOutput(t, func() { runScanList(outputFormat, "", scanListOptions{}, false, 0) })
+		assert.Equal(t, "scan-1\n", stdout, outputFormat)
+	}
+	assert.Equal(t, exitOK, commandExitCode)
+}
+
+func TestSelect_Wildcard(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useSelect(t, "environments[*].env")
+
+	stdout, _ := captureOutput(t, func() { runAppList("table", 0, "", "", false) })
+	assert.Equal(t, "development\nproduction\n", stdout)
+}
+
+func TestSelect_NoMatch(t *testing.T) {
+	resetExitCode(t)
+	useMockAPI(t)
+	useSelect(t, "noSuchField")
+
+	stdout, stderr := captureOutput(t, func() { runAppList("table", 0, "", "", false) })
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "--select noSuchField matched no fields")
+	assert.Equal(t, exitOK, commandExitCode)
+}
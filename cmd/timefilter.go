@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// timeBoundLayouts are the absolute date formats accepted by time filter flags
+var timeBoundLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTimeBound parses an absolute date (e.g. 2025-01-31 or RFC3339) or a relative
+// duration back from now (e.g. 30m, 24h, 7d, 2w)
+func parseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time value")
+	}
+
+	for _, layout := range timeBoundLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	// Relative durations: day and week units aren't supported by time.ParseDuration
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n >= 0 {
+			days := n
+			if unit == 'w' {
+				days = n * 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a date like 2025-01-31, an RFC3339 timestamp, or a duration like 24h, 7d, or 2w", value)
+}
+
+// parseMillisTimestamp converts an API millisecond timestamp string to a time
+func parseMillisTimestamp(ts string) (time.Time, bool) {
+	if ts == "" {
+		return time.Time{}, false
+	}
+	ms, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// timeRange is a half-open window [After, Before); zero bounds are unbounded
+type timeRange struct {
+	After  time.Time
+	Before time.Time
+}
+
+// IsSet reports whether either bound is set
+func (r timeRange) IsSet() bool {
+	return !r.After.IsZero() || !r.Before.IsZero()
+}
+
+// ContainsTimestamp reports whether an API millisecond timestamp falls within the range.
+// Missing or unparseable timestamps only match an unbounded range.
+func (r timeRange) ContainsTimestamp(ts string) bool {
+	if !r.IsSet() {
+		return true
+	}
+
+	t, ok := parseMillisTimestamp(ts)
+	if !ok {
+		return false
+	}
+	if !r.After.IsZero() && t.Before(r.After) {
+		return false
+	}
+	if !r.Before.IsZero() && !t.Before(r.Before) {
+		return false
+	}
+	return true
+}
+
+// addCreatedRangeFlags registers the --created-after and --created-before flags
+func addCreatedRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("created-after", "", "Only include items created at or after this date or duration ago (e.g. 2025-01-31, 7d)")
+	cmd.Flags().String("created-before", "", "Only include items created before this date or duration ago (e.g. 2025-02-01, 24h)")
+}
+
+// createdRangeFromFlags builds a time range from the --created-after and --created-before flags
+func createdRangeFromFlags(cmd *cobra.Command) (timeRange, error) {
+	after, _ := cmd.Flags().GetString("created-after")
+	before, _ := cmd.Flags().GetString("created-before")
+	return newTimeRange(after, before, time.Now())
+}
+
+// newTimeRange parses optional after/before bounds relative to now
+func newTimeRange(after string, before string, now time.Time) (timeRange, error) {
+	var r timeRange
+	var err error
+
+	if after != "" {
+		if r.After, err = parseTimeBound(after, now); err != nil {
+			return timeRange{}, err
+		}
+	}
+	if before != "" {
+		if r.Before, err = parseTimeBound(before, now); err != nil {
+			return timeRange{}, err
+		}
+	}
+	if !r.After.IsZero() && !r.Before.IsZero() && !r.After.Before(r.Before) {
+		return timeRange{}, fmt.Errorf("the after bound (%s) must be earlier than the before bound (%s)", after, before)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseTimeBound("2025-01-31T08:30:00Z", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2025, 1, 31, 8, 30, 0, 0, time.UTC)))
+
+	got, err = parseTimeBound("2025-01-31", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local)))
+
+	got, err = parseTimeBound("24h", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(now.Add(-24*time.Hour)))
+
+	got, err = parseTimeBound("7d", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(now.AddDate(0, 0, -7)))
+
+	got, err = parseTimeBound("2w", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(now.AddDate(0, 0, -14)))
+
+	for _, bad := range []string{"", "yesterday", "-5d", "2025-13-01", "d"} {
+		_, err := parseTimeBound(bad, now)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTimeRange_ContainsTimestamp_Boundaries(t *testing.T) {
+	after := time.UnixMilli(1700000000000)
+	before := time.UnixMilli(1700000100000)
+	r := timeRange{After: after, Before: before}
+
+	// After is inclusive, before is exclusive
+	assert.True(t, r.ContainsTimestamp("1700000000000"))
+	assert.False(t, r.ContainsTimestamp("1699999999999"))
+	assert.True(t, r.ContainsTimestamp("1700000099999"))
+	assert.False(t, r.ContainsTimestamp("1700000100000"))
+
+	// Missing or malformed timestamps are excluded from bounded queries
+	assert.False(t, r.ContainsTimestamp(""))
+	assert.False(t, r.ContainsTimestamp("not-a-number"))
+	assert.True(t, timeRange{}.ContainsTimestamp(""))
+
+	// Single-sided ranges
+	assert.True(t, timeRange{After: after}.ContainsTimestamp("1800000000000"))
+	assert.False(t, timeRange{Before: before}.ContainsTimestamp("1800000000000"))
+}
+
+func TestNewTimeRange(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	r, err := newTimeRange("", "", now)
+	require.NoError(t, err)
+	assert.False(t, r.IsSet())
+
+	r, err = newTimeRange("7d", "1d", now)
+	require.NoError(t, err)
+	assert.True(t, r.After.Before(r.Before))
+
+	_, err = newTimeRange("1d", "7d", now)
+	assert.Error(t, err)
+
+	_, err = newTimeRange("soon", "", now)
+	assert.Error(t, err)
+}
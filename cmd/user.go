@@ -3,14 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"hawkop/internal/api"
-	"hawkop/internal/config"
 	"hawkop/internal/format"
 )
 
@@ -30,54 +29,98 @@ var userListCmd = &cobra.Command{
 	Long: `List all users that belong to the specified organization.
 	
 By default, uses your configured default organization. You can specify a different
-organization using the --org flag. This command requires ADMIN or OWNER role.`,
+organization using the --org flag. This command requires ADMIN or OWNER role.
+
+--with-teams also lists the organization's teams and adds each member's teams, for
+an access matrix; --format csv writes it for a spreadsheet.`,
+	Example: `  hawkop user list --role admin
+  hawkop user list --with-teams --format csv > access.csv`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		limit, _ := cmd.Flags().GetInt("limit")
 		org, _ := cmd.Flags().GetString("org")
 		role, _ := cmd.Flags().GetString("role")
-		runUserList(format, limit, org, role)
+		withTeams, _ := cmd.Flags().GetBool("with-teams")
+		created, err := createdRangeFromFlags(cmd)
+		if err != nil {
+			failf(exitUsage, "%v", err)
+			return
+		}
+		runUserList(format, limit, org, role, created, withTeams)
+	},
+}
+
+// userGetCmd shows one member of an organization in detail
+var userGetCmd = &cobra.Command{
+	Use:   "get <user>",
+	Short: "Show a user's details, features, metadata, and achievements",
+	Long: `Show one member of an organization: their profile, the features enabled for
+them, their metadata, and their achievements in the order they happened. The user
+may be given by StackHawk ID or by email address.
+
+Use --format json for the full member record as the API returns it.`,
+	Example: `  hawkop user get jane@example.com
+  hawkop user get <user-id> --format json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		org, _ := cmd.Flags().GetString("org")
+		runUserGet(args[0], format, org)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(userCmd)
 	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userGetCmd)
 
 	// Add flags for user list command
-	userListCmd.Flags().StringP("format", "f", "table", "Output format (table|json)")
+	userListCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSONTSVCSV))
+	completeEnum(userListCmd, "format", formatsTableJSONTSVCSV)
+	addTemplateFlag(userListCmd)
 	userListCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
 	userListCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
-	userListCmd.Flags().StringP("role", "r", "", "Filter by user role (admin|member|owner)")
+	userListCmd.Flags().StringP("role", "r", "", enumUsage("Filter by user role", userRoleValues))
+	completeEnum(userListCmd, "role", userRoleValues)
+	addCreatedRangeFlags(userListCmd)
+	userListCmd.Flags().Bool("with-teams", false, "Add a column listing the teams each member belongs to")
+
+	// Add flags for user get command
+	userGetCmd.Flags().StringP("format", "f", "table", enumUsage("Output format", formatsTableJSON))
+	completeEnum(userGetCmd, "format", formatsTableJSON)
+	userGetCmd.Flags().StringP("org", "o", "", "Organization ID (uses default if not specified)")
 }
 
-func runUserList(outputFormat string, limit int, orgID string, roleFilter string) {
+func runUserList(outputFormat string, limit int, orgID string, roleFilter string, created timeRange, withTeams bool) {
+	outputFormat = strings.ToLower(outputFormat)
+	if !slices.Contains(formatsTableJSONTSVCSV, outputFormat) {
+		failUnknownFormat(outputFormat, formatsTableJSONTSVCSV)
+		return
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	checkError(err)
 
 	// Validate that we have credentials
 	if !cfg.HasValidCredentials() {
-		fmt.Println("❌ No API key configured. Please run 'hawkop init' first.")
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
 		return
 	}
 
 	// Determine which organization to use
-	if orgID == "" {
-		orgID = cfg.OrgID
-		if orgID == "" {
-			fmt.Println("❌ No organization specified. Use --org flag or set a default with 'hawkop org set <org-id>'")
-			return
-		}
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
 	}
 
 	// Create API client
-	client := api.NewClient(cfg)
+	client := newAPIClient(cfg)
 
 	// Get organization members
 	members, err := client.ListOrganizationMembers(orgID)
 	if err != nil {
-		fmt.Printf("❌ Failed to list users: %v\n", err)
+		failf(exitCodeFor(err), "Failed to list users: %v", err)
 		return
 	}
 
@@ -101,90 +144,372 @@ func runUserList(outputFormat string, limit int, orgID string, roleFilter string
 		members = filteredMembers
 	}
 
+	// Apply created date filter if specified
+	members = filterMembersByCreated(members, created)
+
 	// Apply limit if specified
 	if limit > 0 && len(members) > limit {
 		members = members[:limit]
 	}
 
+	if withTeams {
+		teams, err := client.ListOrganizationTeams(orgID)
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to list teams: %v", err)
+			return
+		}
+		outputUsersWithTeams(outputFormat, membersWithTeams(members, teams))
+		return
+	}
+
+	if outputTemplated(members) {
+		return
+	}
+
 	// Output based on format
-	switch strings.ToLower(outputFormat) {
+	switch outputFormat {
 	case "json":
 		outputUsersJSON(members)
 	case "table":
 		outputUsersTable(members)
-	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'table' or 'json'\n", outputFormat)
+	case "tsv":
+		outputTSV(usersTable(members))
+	case "csv":
+		outputCSV(usersTable(members))
+	}
+}
+
+// memberWithTeams is an organization member with the names of the teams they
+// belong to, as user list --with-teams outputs them
+type memberWithTeams struct {
+	api.OrganizationMember
+	Teams []string `json:"teams"`
+}
+
+// membersWithTeams pairs each member with the sorted names of the teams listing
+// them as a user. Members on no team get an empty list.
+func membersWithTeams(members []api.OrganizationMember, teams []api.Team) []memberWithTeams {
+	teamsByUser := make(map[string][]string)
+	for _, team := range teams {
+		for _, user := range team.Users {
+			if user.StackhawkId != "" && !slices.Contains(teamsByUser[user.StackhawkId], team.Name) {
+				teamsByUser[user.StackhawkId] = append(teamsByUser[user.StackhawkId], team.Name)
+			}
+		}
+	}
+
+	result := make([]memberWithTeams, 0, len(members))
+	for _, member := range members {
+		names := append([]string{}, teamsByUser[member.StackhawkId]...)
+		slices.Sort(names)
+		result = append(result, memberWithTeams{OrganizationMember: member, Teams: names})
+	}
+	return result
+}
+
+// outputUsersWithTeams writes members in outputFormat with a TEAMS column
+func outputUsersWithTeams(outputFormat string, members []memberWithTeams) {
+	if outputTemplated(members) {
 		return
 	}
+
+	table := format.NewTable("NAME", "EMAIL", "ROLE", "PROVIDER", "CREATED", "TEAMS")
+	for _, member := range members {
+		table.AddRow(append(userRow(member.OrganizationMember), strings.Join(member.Teams, ", "))...)
+	}
+
+	switch outputFormat {
+	case "json":
+		data, err := json.MarshalIndent(members, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
+		}
+		fmt.Fprintln(out, string(data))
+	case "table":
+		if len(members) == 0 {
+			fmt.Fprintln(errOut, "No users found.")
+			return
+		}
+		fmt.Fprint(out, renderTable(table))
+	case "tsv":
+		outputTSV(table)
+	case "csv":
+		outputCSV(table)
+	}
+}
+
+// filterMembersByCreated keeps members whose CreatedTimestamp falls within the range
+func filterMembersByCreated(members []api.OrganizationMember, created timeRange) []api.OrganizationMember {
+	if !created.IsSet() {
+		return members
+	}
+
+	filteredMembers := []api.OrganizationMember{}
+	for _, member := range members {
+		if created.ContainsTimestamp(member.CreatedTimestamp) {
+			filteredMembers = append(filteredMembers, member)
+		}
+	}
+	return filteredMembers
 }
 
 func outputUsersJSON(members []api.OrganizationMember) {
 	data, err := json.MarshalIndent(members, "", "  ")
 	if err != nil {
-		fmt.Printf("❌ Failed to format JSON: %v\n", err)
+		failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 		return
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(out, string(data))
 }
 
 func outputUsersTable(members []api.OrganizationMember) {
 	if len(members) == 0 {
-		fmt.Println("No users found.")
+		fmt.Fprintln(errOut, "No users found.")
 		return
 	}
 
+	fmt.Fprint(out, renderTable(usersTable(members)))
+}
+
+// usersTable lays out organization members for table, tsv, and csv output
+func usersTable(members []api.OrganizationMember) *format.TableWriter {
 	table := format.NewTable("NAME", "EMAIL", "ROLE", "PROVIDER", "CREATED")
 
 	for _, member := range members {
-		name := ""
-		email := ""
-		role := ""
-
-		// Extract user info from External field
-		if member.External != nil {
-			name = member.External.FullName
-			if name == "" {
-				name = fmt.Sprintf("%s %s", member.External.FirstName, member.External.LastName)
-			}
-			email = member.External.Email
+		table.AddRow(userRow(member)...)
+	}
 
-			// Extract role from the organizations array in External
-			for _, orgMembership := range member.External.Organizations {
-				role = orgMembership.Role
-				break // Use the first organization role (should match the requested org)
-			}
+	return table
+}
+
+// userRow returns a member's name, email, role, provider, and created date as
+// usersTable shows them
+func userRow(member api.OrganizationMember) []string {
+	name := ""
+	email := ""
+	role := ""
+
+	// Extract user info from External field
+	if member.External != nil {
+		name = member.External.FullName
+		if name == "" {
+			name = fmt.Sprintf("%s %s", member.External.FirstName, member.External.LastName)
 		}
+		email = member.External.Email
 
-		// Format provider
-		provider := ""
-		if member.Provider != nil {
-			provider = member.Provider.Slug
+		// Extract role from the organizations array in External
+		for _, orgMembership := range member.External.Organizations {
+			role = orgMembership.Role
+			break // Use the first organization role (should match the requested org)
 		}
+	}
 
-		// Format created date
-		created := ""
-		if member.CreatedTimestamp != "" {
-			if ts, err := strconv.ParseInt(member.CreatedTimestamp, 10, 64); err == nil {
-				created = time.Unix(ts/1000, 0).Format("2006-01-02")
-			}
+	// Format provider
+	provider := ""
+	if member.Provider != nil {
+		provider = member.Provider.Slug
+	}
+
+	// Format created date
+	created := ""
+	if member.CreatedTimestamp != "" {
+		if ts, err := strconv.ParseInt(member.CreatedTimestamp, 10, 64); err == nil {
+			created = format.TimeIn(ts, displayLocation).Format("2006-01-02")
 		}
+	}
 
-		// Clean up values
-		if name == "" {
-			name = "N/A"
+	// Clean up values
+	if name == "" {
+		name = "N/A"
+	}
+	if email == "" {
+		email = "N/A"
+	}
+	if role == "" {
+		role = "N/A"
+	}
+	if provider == "" {
+		provider = "N/A"
+	}
+
+	return []string{name, email, role, provider, created}
+}
+
+func runUserGet(userRef string, outputFormat string, orgID string) {
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != "table" && outputFormat != "json" {
+		failUnknownFormat(outputFormat, formatsTableJSON)
+		return
+	}
+	// Email addresses are matched against the member list; only IDs are checked
+	if !strings.Contains(userRef, "@") && !validIDs("user", userRef) {
+		return
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	checkError(err)
+
+	// Validate that we have credentials
+	if !cfg.HasValidCredentials() {
+		failf(exitAuth, "No API key configured. Please run 'hawkop init' first.")
+		return
+	}
+
+	// Determine which organization to use
+	orgID, ok := resolveOrgID(orgID, cfg)
+	if !ok {
+		return
+	}
+
+	client := newAPIClient(cfg)
+	members, err := client.ListOrganizationMembers(orgID)
+	if err != nil {
+		failf(exitCodeFor(err), "Failed to list users: %v", err)
+		return
+	}
+
+	member, found := findMember(members, userRef)
+	if !found {
+		failf(exitNotFound, "User not found: %s. Run 'hawkop user list' to see the organization's users.", userRef)
+		return
+	}
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(member, "", "  ")
+		if err != nil {
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
+			return
 		}
-		if email == "" {
-			email = "N/A"
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	outputUserDetails(member)
+}
+
+// findMember returns the member with the given StackHawk ID, or else the one whose
+// email matches ref ignoring case
+func findMember(members []api.OrganizationMember, ref string) (api.OrganizationMember, bool) {
+	for _, member := range members {
+		if member.StackhawkId == ref {
+			return member, true
 		}
-		if role == "" {
-			role = "N/A"
+	}
+	for _, member := range members {
+		if member.External != nil && member.External.Email != "" && strings.EqualFold(member.External.Email, ref) {
+			return member, true
 		}
-		if provider == "" {
-			provider = "N/A"
+	}
+	return api.OrganizationMember{}, false
+}
+
+// outputUserDetails prints a member's profile followed by a titled section each for
+// features, metadata, and achievements, noting the ones that are empty
+func outputUserDetails(member api.OrganizationMember) {
+	row := userRow(member)
+	details := format.NewTable("FIELD", "VALUE")
+	details.AddRow("User ID", member.StackhawkId)
+	details.AddRow("Name", row[0])
+	details.AddRow("Email", row[1])
+	details.AddRow("Role", row[2])
+	details.AddRow("Provider", row[3])
+	details.AddRow("Created", orNA(row[4]))
+	fmt.Fprint(out, renderTable(details))
+
+	sections := []struct {
+		title   string
+		missing string
+		table   *format.TableWriter
+	}{
+		{"Features", "No features for this user.", memberFeaturesTable(member.Features)},
+		{"Metadata", "No metadata for this user.", memberMetadataTable(member.Metadata)},
+		{"Achievements", "No achievements for this user.", memberAchievementsTable(member.Achievements)},
+	}
+	for _, section := range sections {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "%s\n%s\n", section.title, strings.Repeat("=", len(section.title)))
+		if section.table == nil {
+			fmt.Fprintln(out, section.missing)
+			continue
+		}
+		fmt.Fprint(out, renderTable(section.table))
+	}
+}
+
+// memberFeaturesTable lists feature flags by name, or returns nil if there are none
+func memberFeaturesTable(features []api.Feature) *format.TableWriter {
+	if len(features) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(features)
+	slices.SortStableFunc(sorted, func(a, b api.Feature) int { return strings.Compare(a.Name, b.Name) })
+
+	table := format.NewTable("FEATURE", "ENABLED")
+	for _, feature := range sorted {
+		enabled := "no"
+		if feature.Enabled {
+			enabled = "yes"
 		}
+		table.AddRow(orNA(feature.Name), enabled)
+	}
+	return table
+}
 
-		table.AddRow(name, email, role, provider, created)
+// memberMetadataTable lists metadata in the order the API returned it, or returns
+// nil if there is none
+func memberMetadataTable(metadata []api.Metadata) *format.TableWriter {
+	if len(metadata) == 0 {
+		return nil
 	}
 
-	fmt.Print(table.Render())
+	table := format.NewTable("NAME", "VALUE")
+	for _, entry := range metadata {
+		table.AddRow(orNA(entry.Name), entry.Value)
+	}
+	return table
+}
+
+// memberAchievementsTable lists achievements oldest first, as a timeline, or returns
+// nil if there are none. Achievements without a readable timestamp come last.
+func memberAchievementsTable(achievements []api.Achievement) *format.TableWriter {
+	if len(achievements) == 0 {
+		return nil
+	}
+
+	type dated struct {
+		name string
+		ts   int64
+		ok   bool
+	}
+	timeline := make([]dated, 0, len(achievements))
+	for _, achievement := range achievements {
+		ts, err := strconv.ParseInt(achievement.Timestamp, 10, 64)
+		timeline = append(timeline, dated{name: achievement.Achievement, ts: ts, ok: err == nil})
+	}
+	slices.SortStableFunc(timeline, func(a, b dated) int {
+		switch {
+		case a.ok != b.ok:
+			if a.ok {
+				return -1
+			}
+			return 1
+		case a.ts < b.ts:
+			return -1
+		case a.ts > b.ts:
+			return 1
+		}
+		return 0
+	})
+
+	table := format.NewTable("DATE", "ACHIEVEMENT")
+	for _, entry := range timeline {
+		date := "N/A"
+		if entry.ok {
+			date = format.FormatTimestamp(entry.ts, displayLocation)
+		}
+		table.AddRow(date, orNA(entry.name))
+	}
+	return table
 }
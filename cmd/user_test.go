@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"hawkop/internal/api"
@@ -65,6 +68,7 @@ func (suite *UserCommandTestSuite) TestUserCommand_Structure() {
 	}
 
 	assert.Contains(suite.T(), subcommands, "list")
+	assert.Contains(suite.T(), subcommands, "get <user>")
 }
 
 func (suite *UserCommandTestSuite) TestUserListFlags() {
@@ -84,6 +88,113 @@ func (suite *UserCommandTestSuite) TestUserListFlags() {
 
 	roleFlag := cmd.Flags().Lookup("role")
 	assert.NotNil(suite.T(), roleFlag)
+
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("created-after"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("created-before"))
+	assert.NotNil(suite.T(), cmd.Flags().Lookup("with-teams"))
+}
+
+func (suite *UserCommandTestSuite) TestMembersWithTeams() {
+	members := []api.OrganizationMember{{StackhawkId: "user-1"}, {StackhawkId: "user-2"}, {StackhawkId: "user-3"}}
+	teams := []api.Team{
+		{Name: "Security", Users: []api.OrganizationMember{{StackhawkId: "user-1"}, {StackhawkId: "user-3"}}},
+		{Name: "Platform", Users: []api.OrganizationMember{{StackhawkId: "user-1"}, {StackhawkId: "user-1"}}},
+		{Name: "Empty"},
+		{Name: "Former", Users: []api.OrganizationMember{{StackhawkId: "not-a-member"}}},
+	}
+
+	result := membersWithTeams(members, teams)
+	require.Len(suite.T(), result, 3)
+	assert.Equal(suite.T(), []string{"Platform", "Security"}, result[0].Teams, "sorted, each team once")
+	assert.Equal(suite.T(), []string{}, result[1].Teams, "members on no team get an empty list")
+	assert.Equal(suite.T(), []string{"Security"}, result[2].Teams)
+}
+
+func (suite *UserCommandTestSuite) TestUserList_WithTeams() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runUserList("csv", 0, "", "", timeRange{}, true) })
+	assert.Equal(suite.T(), "NAME,EMAIL,ROLE,PROVIDER,CREATED,TEAMS\n"+
+		"Mock User 1,user1@mock.com,ADMIN,N/A,,Mock Team 1\n"+
+		"Mock User 2,user2@mock.com,MEMBER,N/A,,\n", stdout)
+
+	stdout, _ = captureOutput(suite.T(), func() { runUserList("json", 0, "", "", timeRange{}, true) })
+	var members []memberWithTeams
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &members))
+	require.Len(suite.T(), members, 2)
+	assert.Equal(suite.T(), "user1@mock.com", members[0].External.Email)
+	assert.Equal(suite.T(), []string{"Mock Team 1"}, members[0].Teams)
+	assert.Contains(suite.T(), stdout, `"teams": []`)
+
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runUserList("xml", 0, "", "", timeRange{}, true) })
+	assert.Contains(suite.T(), stderr, "Unknown format: xml")
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *UserCommandTestSuite) TestFilterMembersByCreated() {
+	members := []api.OrganizationMember{
+		{StackhawkId: "old", CreatedTimestamp: "1700000000000"},
+		{StackhawkId: "new", CreatedTimestamp: "1750000000000"},
+		{StackhawkId: "unknown"},
+	}
+
+	filtered := filterMembersByCreated(members, timeRange{Before: time.UnixMilli(1720000000000)})
+	assert.Len(suite.T(), filtered, 1)
+	assert.Equal(suite.T(), "old", filtered[0].StackhawkId)
+}
+
+func (suite *UserCommandTestSuite) TestUserGet_Sections() {
+	useMockAPI(suite.T())
+
+	// Users can be looked up by email as well as ID
+	stdout, _ := captureOutput(suite.T(), func() { runUserGet("USER1@mock.com", "table", "") })
+	assert.Regexp(suite.T(), `User ID\s+user-1`, stdout)
+	assert.Regexp(suite.T(), `BETA_REPORTS\s+no\s+SSO\s+yes`, stdout)
+	assert.Regexp(suite.T(), `department\s+platform`, stdout)
+	assert.Regexp(suite.T(), `ACCOUNT_CREATED[\s\S]*FIRST_SCAN`, stdout, "achievements read oldest first")
+
+	// Members without any of them still get every section
+	stdout, _ = captureOutput(suite.T(), func() { runUserGet("user-2", "table", "") })
+	assert.Contains(suite.T(), stdout, "Features\n========\nNo features for this user.")
+	assert.Contains(suite.T(), stdout, "No metadata for this user.")
+	assert.Contains(suite.T(), stdout, "No achievements for this user.")
+}
+
+func (suite *UserCommandTestSuite) TestUserGet_JSON() {
+	useMockAPI(suite.T())
+
+	stdout, _ := captureOutput(suite.T(), func() { runUserGet("user-1", "json", "") })
+	var member api.OrganizationMember
+	require.NoError(suite.T(), json.Unmarshal([]byte(stdout), &member))
+	assert.Len(suite.T(), member.Features, 2)
+	assert.Equal(suite.T(), []api.Metadata{{Name: "department", Value: "platform"}}, member.Metadata)
+	assert.Len(suite.T(), member.Achievements, 2)
+
+	resetExitCode(suite.T())
+	_, stderr := captureOutput(suite.T(), func() { runUserGet("nobody@mock.com", "table", "") })
+	assert.Contains(suite.T(), stderr, "❌ User not found: nobody@mock.com")
+	assert.Equal(suite.T(), exitNotFound, commandExitCode)
+}
+
+func (suite *UserCommandTestSuite) TestUserGet_MalformedID() {
+	resetExitCode(suite.T())
+	refuseAPI(suite.T())
+
+	_, stderr := captureOutput(suite.T(), func() { runUserGet("user 1", "table", "") })
+	assert.Contains(suite.T(), stderr, `❌ invalid user ID "user 1"`)
+	assert.Equal(suite.T(), exitUsage, commandExitCode)
+}
+
+func (suite *UserCommandTestSuite) TestMemberAchievementsTable_UndatedLast() {
+	table := memberAchievementsTable([]api.Achievement{
+		{Achievement: "UNDATED", Timestamp: "soon"},
+		{Achievement: "LATER", Timestamp: "1700000000000"},
+		{Achievement: "EARLIER", Timestamp: "1600000000000"},
+	})
+	assert.Regexp(suite.T(), `EARLIER[\s\S]*LATER[\s\S]*N/A\s+UNDATED`, table.Render())
+
+	assert.Nil(suite.T(), memberAchievementsTable(nil))
 }
 
 func TestUserCommandTestSuite(t *testing.T) {
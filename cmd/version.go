@@ -22,7 +22,8 @@ var versionCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
-	versionCmd.Flags().StringP("format", "f", "text", "Output format (text|json)")
+	versionCmd.Flags().StringP("format", "f", "text", enumUsage("Output format", formatsTextJSON))
+	completeEnum(versionCmd, "format", formatsTextJSON)
 }
 
 func runVersion(outputFormat string) {
@@ -31,13 +32,13 @@ func runVersion(outputFormat string) {
 		info := version.GetInfo()
 		data, err := json.MarshalIndent(info, "", "  ")
 		if err != nil {
-			fmt.Printf("❌ Failed to format JSON: %v\n", err)
+			failf(exitCodeFor(err), "Failed to format JSON: %v", err)
 			return
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(out, string(data))
 	case "text":
-		fmt.Println(version.GetDetailedVersion())
+		fmt.Fprintln(out, version.GetDetailedVersion())
 	default:
-		fmt.Printf("❌ Unknown format: %s. Use 'text' or 'json'\n", outputFormat)
+		failUnknownFormat(outputFormat, formatsTextJSON)
 	}
 }
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RedactedValue replaces secrets in captured exchanges
+const RedactedValue = "[REDACTED]"
+
+// sensitiveHeaders are never written to capture files
+var sensitiveHeaders = []string{"Authorization", "X-ApiKey", "Cookie", "Set-Cookie"}
+
+// sensitiveBodyFields are redacted from top-level JSON object bodies
+var sensitiveBodyFields = []string{"token", "apiKey", "api_key"}
+
+// CaptureTransport writes each request/response exchange to a timestamped JSON
+// file in Dir for attaching to bug reports. Secrets are redacted, and requests and
+// responses pass through unchanged; capture write failures are ignored.
+type CaptureTransport struct {
+	Dir  string
+	Next http.RoundTripper
+
+	seq atomic.Int64
+}
+
+// capturedExchange is the file format of a captured request/response pair
+type capturedExchange struct {
+	Time       time.Time         `json:"time"`
+	DurationMs int64             `json:"durationMs"`
+	Request    capturedRequest   `json:"request"`
+	Response   *capturedResponse `json:"response,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type capturedRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+type capturedResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+// NewCaptureTransport creates a capture transport writing into dir, creating it if needed
+func NewCaptureTransport(dir string, next http.RoundTripper) (*CaptureTransport, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &CaptureTransport{Dir: dir, Next: next}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CaptureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	exchange := capturedExchange{
+		Time: start.UTC(),
+		Request: capturedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: redactHeaders(req.Header),
+		},
+	}
+
+	// Read the request body and restore it so the request is sent unchanged
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		exchange.Request.Body = captureBody(body)
+	}
+
+	resp, err := t.Next.RoundTrip(req)
+	exchange.DurationMs = time.Since(start).Milliseconds()
+
+	if err == nil {
+		err = decompressResponse(resp)
+	}
+	if err != nil {
+		exchange.Error = err.Error()
+		t.write(req, exchange)
+		return nil, err
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		exchange.Error = readErr.Error()
+	}
+
+	exchange.Response = &capturedResponse{
+		Status:  resp.StatusCode,
+		Headers: redactHeaders(resp.Header),
+		Body:    captureBody(body),
+	}
+	t.write(req, exchange)
+
+	return resp, readErr
+}
+
+// write saves an exchange as <timestamp>-<seq>-<request key>.json
+func (t *CaptureTransport) write(req *http.Request, exchange capturedExchange) {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return
+	}
+
+	name := fmt.Sprintf("%s-%04d-%s", exchange.Time.Format("20060102T150405.000Z"), t.seq.Add(1), SnapshotKey(req))
+	_ = os.WriteFile(filepath.Join(t.Dir, name), data, 0600)
+}
+
+// redactHeaders copies headers, replacing credential values
+func redactHeaders(headers http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		redacted[name] = append([]string(nil), values...)
+	}
+	for _, name := range sensitiveHeaders {
+		canonical := http.CanonicalHeaderKey(name)
+		if _, ok := redacted[canonical]; ok {
+			redacted[canonical] = []string{RedactedValue}
+		}
+	}
+	return redacted
+}
+
+// captureBody returns a JSON body with secrets redacted, or a non-JSON body as a JSON string
+func captureBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	// Only re-encode objects that hold secrets so other bodies are captured verbatim
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err == nil {
+		redacted := false
+		for key := range object {
+			for _, field := range sensitiveBodyFields {
+				if strings.EqualFold(key, field) {
+					object[key] = json.RawMessage(`"` + RedactedValue + `"`)
+					redacted = true
+				}
+			}
+		}
+		if redacted {
+			if data, err := json.Marshal(object); err == nil {
+				return data
+			}
+		}
+	}
+
+	if json.Valid(body) {
+		return body
+	}
+
+	data, _ := json.Marshal(string(body))
+	return data
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/config"
+)
+
+type CaptureTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *CaptureTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+// readCaptures returns the captured exchanges in the order they were written
+func (suite *CaptureTestSuite) readCaptures() []capturedExchange {
+	files, err := os.ReadDir(suite.dir)
+	require.NoError(suite.T(), err)
+
+	exchanges := make([]capturedExchange, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(suite.dir, file.Name()))
+		require.NoError(suite.T(), err)
+
+		var exchange capturedExchange
+		require.NoError(suite.T(), json.Unmarshal(data, &exchange))
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges
+}
+
+func (suite *CaptureTestSuite) TestCapture_RedactsCredentials() {
+	server := NewMockAPIServer()
+	defer server.Close()
+
+	client := NewClient(&config.Config{APIKey: "super-secret-key"})
+	client.SetBaseURL(server.URL())
+	require.NoError(suite.T(), client.UseCapture(suite.dir))
+
+	// Send the auth request directly to avoid writing the token to the real config file
+	req, _ := http.NewRequest("GET", server.URL()+AuthEndpoint, nil)
+	req.Header.Set("X-ApiKey", "super-secret-key")
+	req.Header.Set("Authorization", "Bearer jwt-secret")
+	resp, err := client.HTTPClient.Do(req)
+	require.NoError(suite.T(), err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// The caller still sees the real token
+	assert.Contains(suite.T(), string(body), `"token"`)
+	assert.NotContains(suite.T(), string(body), RedactedValue)
+
+	exchanges := suite.readCaptures()
+	require.Len(suite.T(), exchanges, 1)
+
+	auth := exchanges[0]
+	assert.Equal(suite.T(), []string{RedactedValue}, auth.Request.Headers["X-Apikey"])
+	assert.Equal(suite.T(), []string{RedactedValue}, auth.Request.Headers["Authorization"])
+	require.NotNil(suite.T(), auth.Response)
+	assert.Equal(suite.T(), http.StatusOK, auth.Response.Status)
+
+	var authBody map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal(auth.Response.Body, &authBody))
+	assert.Equal(suite.T(), RedactedValue, authBody["token"])
+
+	// Nothing in the capture directory contains the secrets
+	files, _ := os.ReadDir(suite.dir)
+	for _, file := range files {
+		data, _ := os.ReadFile(filepath.Join(suite.dir, file.Name()))
+		assert.NotContains(suite.T(), string(data), "super-secret-key")
+		assert.NotContains(suite.T(), string(data), "jwt-secret")
+	}
+}
+
+func (suite *CaptureTestSuite) TestCapture_PassesThroughUnchanged() {
+	server := NewMockAPIServer()
+	defer server.Close()
+
+	cfg := testClientConfig(server.URL())
+
+	plain := NewClient(cfg)
+	plain.SetBaseURL(server.URL())
+	want, err := plain.ListOrganizationScans("test-org-id")
+	require.NoError(suite.T(), err)
+
+	captured := NewClient(cfg)
+	captured.SetBaseURL(server.URL())
+	require.NoError(suite.T(), captured.UseCapture(suite.dir))
+	got, err := captured.ListOrganizationScans("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), want, got)
+
+	exchanges := suite.readCaptures()
+	require.Len(suite.T(), exchanges, 1)
+	assert.Equal(suite.T(), "GET", exchanges[0].Request.Method)
+	assert.True(suite.T(), strings.Contains(exchanges[0].Request.URL, "/api/v1/scan/test-org-id"))
+	assert.Contains(suite.T(), string(exchanges[0].Response.Body), "applicationScanResults")
+}
+
+func (suite *CaptureTestSuite) TestCapture_RecordsTransportErrors() {
+	client := NewClient(&config.Config{})
+	client.HTTPClient.Timeout = time.Second
+	require.NoError(suite.T(), client.UseCapture(suite.dir))
+
+	_, err := client.HTTPClient.Get("http://127.0.0.1:1/unreachable")
+	assert.Error(suite.T(), err)
+
+	exchanges := suite.readCaptures()
+	require.Len(suite.T(), exchanges, 1)
+	assert.NotEmpty(suite.T(), exchanges[0].Error)
+	assert.Nil(suite.T(), exchanges[0].Response)
+}
+
+func (suite *CaptureTestSuite) TestCaptureBody() {
+	assert.Nil(suite.T(), captureBody(nil))
+	assert.JSONEq(suite.T(), `"plain text"`, string(captureBody([]byte("plain text"))))
+	assert.JSONEq(suite.T(), `[1,2]`, string(captureBody([]byte(`[1,2]`))))
+	assert.JSONEq(suite.T(), `{"token":"[REDACTED]","expires_at":"soon"}`, string(captureBody([]byte(`{"token":"abc","expires_at":"soon"}`))))
+}
+
+func TestCaptureTestSuite(t *testing.T) {
+	suite.Run(t, new(CaptureTestSuite))
+}
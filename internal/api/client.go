@@ -4,12 +4,20 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"hawkop/internal/config"
@@ -19,21 +27,154 @@ const (
 	DefaultBaseURL = "https://api.stackhawk.com"
 	AuthEndpoint   = "/api/v1/auth/login"
 
+	// TokenLifetime is how long a JWT from AuthEndpoint lasts, per the API docs
+	TokenLifetime = 30 * time.Minute
+
 	// Pagination constants - use max page size to minimize API requests
 	DefaultPageSize = 1000 // Use maximum to reduce API calls
 	MaxPageSize     = 1000
+	MaxPagesDefault = 100 // Backstop against pagination that never terminates
 
 	// Rate limiting constants
 	MaxRequestsPerMinute = 360
+	MinRequestInterval   = 167 * time.Millisecond // 360/min = 6/sec
+	RateLimitCooldown    = 60 * time.Second       // How long the limiter stays slowed after a 429
+	MaxThrottleFactor    = 8                      // Cap on how far repeated 429s stretch the interval
 	RetryAfterDefault    = 60 * time.Second
+	MaxRetryAfterDefault = 120 * time.Second // Cap so a bogus Retry-After can't hang the CLI
+	RetryAfterJitter     = 0.1               // ±10% to avoid synchronized retries
+
+	// DefaultAlertConcurrency is the default number of concurrent alert fetches
+	DefaultAlertConcurrency = 4
+	// DefaultPageConcurrency is the default number of list pages fetched at once
+	// when the API supports page numbers
+	DefaultPageConcurrency = 4
 )
 
+// ErrInvalidOrgID is returned when an organization ID is empty or malformed
+var ErrInvalidOrgID = errors.New("invalid organization ID")
+
+// ErrInvalidID is wrapped by the errors ValidateID returns for empty or malformed IDs
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrStopPaging can be returned from a page callback to stop following pages
+var ErrStopPaging = errors.New("stop paging")
+
+// ErrNotFound is wrapped by errors for 404 responses
+var ErrNotFound = errors.New("not found (404)")
+
+// ErrPaginationLoop is returned when the API repeats a page token or pagination
+// exceeds the client's MaxPages, which would otherwise loop forever
+var ErrPaginationLoop = errors.New("pagination did not terminate")
+
+// idPattern matches the IDs the API hands out for organizations, applications,
+// scans, teams, and users: UUIDs and similar slugs of letters, digits, '-' and '_'
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// invalidIDError describes an ID ValidateID rejected; it wraps ErrInvalidID
+type invalidIDError struct {
+	kind string
+	id   string
+}
+
+func (e *invalidIDError) Error() string {
+	if e.id == "" {
+		return fmt.Sprintf("%s ID is empty", e.kind)
+	}
+	return fmt.Sprintf("invalid %s ID %q: IDs contain only letters, digits, '-' and '_'", e.kind, e.id)
+}
+
+func (e *invalidIDError) Unwrap() error {
+	return ErrInvalidID
+}
+
+// ValidateID checks that id, the ID of a kind of object such as "scan", is
+// non-empty and safe to use in an endpoint path
+func ValidateID(kind string, id string) error {
+	if !idPattern.MatchString(id) {
+		return &invalidIDError{kind: kind, id: id}
+	}
+	return nil
+}
+
+// ValidateOrgID checks that an organization ID is non-empty and safe to use in an endpoint path
+func ValidateOrgID(orgID string) error {
+	if orgID == "" {
+		return fmt.Errorf("%w: organization ID is empty - use --org or set a default with 'hawkop org set <org-id>'", ErrInvalidOrgID)
+	}
+	if ValidateID("organization", orgID) != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidOrgID, orgID)
+	}
+	return nil
+}
+
 // Client represents the StackHawk API client
 type Client struct {
 	BaseURL     string
 	HTTPClient  *http.Client
 	config      *config.Config
 	lastRequest time.Time
+
+	// throttleFactor multiplies the request interval until throttleUntil, after
+	// the API answers with a 429; see throttle
+	throttleFactor int
+	throttleUntil  time.Time
+	// rateLimit is the limit last reported in response headers; see RateLimit
+	rateLimit *RateLimitState
+
+	// rateMu guards lastRequest, the throttle state, and rateLimit so concurrent
+	// requests share one rate limiter
+	rateMu sync.Mutex
+	// authMu serializes JWT refreshes and token reads across goroutines
+	authMu sync.Mutex
+
+	// MaxRetryAfter caps how long the client will wait after a 429 response
+	MaxRetryAfter time.Duration
+
+	// MinRequestInterval is the spacing between requests until the API reports its
+	// rate limit in response headers, and RateLimitCooldown how long the spacing
+	// stays stretched after a 429
+	MinRequestInterval time.Duration
+	RateLimitCooldown  time.Duration
+
+	// Debug receives a line for each response and rate limit wait when set
+	Debug io.Writer
+	// StrictDecoding rejects responses with fields the Go types don't declare;
+	// see unmarshalJSON
+	StrictDecoding bool
+
+	// MaxPages caps how many pages a paginated listing will follow
+	MaxPages int
+	// PageConcurrency is how many pages are fetched at once when a listing can
+	// be paged by number; see ForEachOrganizationScanPage
+	PageConcurrency int
+
+	// MaxConnectRetries and ConnectRetryBackoff control resending requests after
+	// transient connection failures and RetryStatuses responses; see
+	// doWithConnectRetry and makeRequestWithRetry
+	MaxConnectRetries   int
+	ConnectRetryBackoff time.Duration
+	// RetryStatuses are the error statuses a GET or HEAD is resent after. A 429 is
+	// always retried once, after the wait the API asks for.
+	RetryStatuses []int
+
+	// FollowRedirects controls whether redirects are followed or reported as
+	// errors; see checkRedirect
+	FollowRedirects bool
+
+	// ctx bounds every request and wait made by the client; see SetContext
+	ctx context.Context
+
+	// lists coalesces identical list requests; see getList
+	lists listCache
+
+	// replaying is set by UseSnapshot when responses come from recorded fixtures.
+	// Requests then carry SnapshotToken and the config's JWTs are never touched.
+	replaying bool
+
+	// saveConfig writes the config after a JWT refresh; tests replace it so they
+	// don't write the user's config file
+	saveConfig func(*config.Config) error
 }
 
 // AuthResponse represents the response from the authentication endpoint
@@ -41,17 +182,37 @@ type AuthResponse struct {
 	Token     string    `json:"token"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
 	TokenType string    `json:"token_type,omitempty"`
+
+	// ExpiryReported is false when the API left out expires_at and ExpiresAt
+	// was estimated from TokenLifetime
+	ExpiryReported bool `json:"-"`
 }
 
 // NewClient creates a new StackHawk API client
 func NewClient(cfg *config.Config) *Client {
-	return &Client{
+	c := &Client{
 		BaseURL: DefaultBaseURL,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		config: cfg,
+		config:          cfg,
+		MaxRetryAfter:   MaxRetryAfterDefault,
+		MaxPages:        MaxPagesDefault,
+		PageConcurrency: DefaultPageConcurrency,
+
+		MinRequestInterval: MinRequestInterval,
+		RateLimitCooldown:  RateLimitCooldown,
+
+		MaxConnectRetries:   MaxConnectRetriesDefault,
+		ConnectRetryBackoff: ConnectRetryBackoffDefault,
+		RetryStatuses:       slices.Clone(RetryStatusesDefault),
+
+		FollowRedirects: true,
+
+		saveConfig: (*config.Config).Save,
 	}
+	c.HTTPClient.CheckRedirect = c.checkRedirect
+	return c
 }
 
 // SetBaseURL updates the base URL for the API client
@@ -59,16 +220,98 @@ func (c *Client) SetBaseURL(baseURL string) {
 	c.BaseURL = baseURL
 }
 
+// SetContext bounds all subsequent requests, retries, and rate limit waits by ctx,
+// so a command-wide deadline cancels in-flight work
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// context returns the context requests are bound to
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// sleep waits for d, returning early with the context's error if it is cancelled
+func (c *Client) sleep(d time.Duration) error {
+	if d <= 0 {
+		return c.context().Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.context().Done():
+		return c.context().Err()
+	}
+}
+
+// UseProxy sends all requests through the proxy at proxyURL (http, https, or
+// socks5), ignoring HTTP_PROXY, HTTPS_PROXY, and NO_PROXY. Without it the client
+// uses Go's default transport, which honors those variables.
+func (c *Client) UseProxy(proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q", proxyURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q; use http, https, or socks5", u.Scheme)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(u)
+	c.HTTPClient.Transport = transport
+	return nil
+}
+
+// UseSnapshot routes requests through a snapshot directory, replaying recorded
+// responses or, when record is true, saving live responses for later replay
+func (c *Client) UseSnapshot(dir string, record bool) {
+	c.HTTPClient.Transport = NewSnapshotTransport(dir, record, c.HTTPClient.Transport)
+	c.replaying = !record
+}
+
+// UseCapture writes every request/response exchange, with secrets redacted, into dir
+func (c *Client) UseCapture(dir string) error {
+	transport, err := NewCaptureTransport(dir, c.HTTPClient.Transport)
+	if err != nil {
+		return err
+	}
+	c.HTTPClient.Transport = transport
+	return nil
+}
+
+// UseCache makes GET requests conditional on the ETag or Last-Modified of the
+// response in store, reusing the stored body when the API answers 304 Not Modified
+func (c *Client) UseCache(store CacheStore) {
+	c.HTTPClient.Transport = NewCacheTransport(store, c.HTTPClient.Transport)
+}
+
 // EnsureValidJWT checks if we have a valid JWT token and refreshes it if needed
 func (c *Client) EnsureValidJWT() error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	return c.ensureValidJWTLocked()
+}
+
+// ensureValidJWTLocked refreshes the JWT if needed; the caller must hold authMu
+func (c *Client) ensureValidJWTLocked() error {
 	// Check if we need to refresh the JWT
-	if !c.config.NeedsJWTRefresh() {
+	if c.replaying || !c.config.NeedsJWTRefresh(c.BaseURL) {
 		return nil
 	}
 
 	// Check if we have valid credentials for authentication
 	if !c.config.HasValidCredentials() {
-		return fmt.Errorf("no API key configured - run 'hawkop init' to set up credentials")
+		return fmt.Errorf("%w - run 'hawkop init' to set up credentials", ErrNoCredentials)
 	}
 
 	// Authenticate to get a new JWT
@@ -77,52 +320,67 @@ func (c *Client) EnsureValidJWT() error {
 
 // authenticate performs authentication with the StackHawk API to get a JWT token
 func (c *Client) authenticate() error {
+	authResp, err := c.ExchangeAPIKey(c.config.APIKey)
+	if err != nil {
+		return err
+	}
+
+	// Update the JWT for this API in config, leaving other instances' tokens alone
+	c.config.SetJWT(c.BaseURL, authResp.Token, authResp.ExpiresAt)
+
+	// Save config with new JWT
+	if err := c.saveConfig(c.config); err != nil {
+		return fmt.Errorf("failed to save JWT token: %w", err)
+	}
+
+	return nil
+}
+
+// ExchangeAPIKey trades apiKey for a JWT at the login endpoint without storing
+// either. A rejected key wraps ErrAuthFailed along with the response's APIError.
+func (c *Client) ExchangeAPIKey(apiKey string) (*AuthResponse, error) {
 	authURL := c.BaseURL + AuthEndpoint
 
 	// Create HTTP GET request with API key in X-ApiKey header (as per curl example)
-	req, err := http.NewRequest("GET", authURL, nil)
+	req, err := http.NewRequestWithContext(c.context(), "GET", authURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create auth request: %w", err)
+		return nil, fmt.Errorf("failed to create auth request: %w", err)
 	}
 
-	req.Header.Set("X-ApiKey", c.config.APIKey)
+	req.Header.Set("X-ApiKey", apiKey)
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set("User-Agent", "hawkop-cli")
 
 	// Make the request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to authenticate: %w", err)
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	if err := decompressResponse(resp); err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check for success status
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed: HTTP %d - %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("%w: %w", ErrAuthFailed, &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
 	}
 
 	// Parse response
 	var authResp AuthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return fmt.Errorf("failed to parse auth response: %w", err)
-	}
-
-	// If no expiration is provided, set it to 30 minutes from now (as mentioned in the docs)
-	expiresAt := authResp.ExpiresAt
-	if expiresAt.IsZero() {
-		expiresAt = time.Now().Add(30 * time.Minute)
+	if err := c.decodeResponse(resp, &authResp); err != nil {
+		return nil, fmt.Errorf("failed to parse auth response: %w", err)
 	}
 
-	// Update JWT in config
-	c.config.SetJWT(authResp.Token, expiresAt)
-
-	// Save config with new JWT
-	if err := c.config.Save(); err != nil {
-		return fmt.Errorf("failed to save JWT token: %w", err)
+	// If no expiration is provided, assume the documented token lifetime
+	authResp.ExpiryReported = !authResp.ExpiresAt.IsZero()
+	if !authResp.ExpiryReported {
+		authResp.ExpiresAt = time.Now().Add(TokenLifetime)
 	}
 
-	return nil
+	return &authResp, nil
 }
 
 // DoAuthenticatedRequest performs an HTTP request with automatic JWT handling, rate limiting, and retry logic
@@ -132,13 +390,20 @@ func (c *Client) DoAuthenticatedRequest(method, endpoint string, body interface{
 
 // DoAuthenticatedRequestWithParams performs an HTTP request with pagination and query parameters
 func (c *Client) DoAuthenticatedRequestWithParams(method, endpoint string, body interface{}, params map[string]string) (*http.Response, error) {
+	// Anything other than a read may change what list endpoints return
+	if method != http.MethodGet {
+		c.lists.reset()
+	}
+
 	// Ensure we have a valid JWT
 	if err := c.EnsureValidJWT(); err != nil {
 		return nil, err
 	}
 
 	// Rate limiting: ensure we don't exceed 360 requests per minute
-	c.respectRateLimit()
+	if err := c.respectRateLimit(); err != nil {
+		return nil, err
+	}
 
 	// Prepare request body
 	var reqBody *bytes.Buffer
@@ -171,121 +436,242 @@ func (c *Client) DoAuthenticatedRequestWithParams(method, endpoint string, body
 	}
 
 	// Create request
-	req, err := http.NewRequest(method, reqURL, reqBody)
+	req, err := http.NewRequestWithContext(c.context(), method, reqURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers with Bearer JWT token
-	req.Header.Set("Authorization", "Bearer "+c.config.JWT.Token)
+	req.Header.Set("Authorization", "Bearer "+c.currentToken())
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set("User-Agent", "hawkop-cli")
 
 	// Make the request with retry logic
-	resp, err := c.makeRequestWithRetry(req)
-	if err != nil {
-		return nil, err
+	return c.makeRequestWithRetry(req)
+}
+
+// currentToken returns the JWT token currently in use
+func (c *Client) currentToken() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.replaying {
+		return SnapshotToken
 	}
+	jwt := c.config.JWTFor(c.BaseURL)
+	if jwt == nil {
+		return ""
+	}
+	return jwt.Token
+}
 
-	// Update last request time for rate limiting
-	c.lastRequest = time.Now()
+// refreshJWT discards the current JWT and obtains a new one, returning the new token.
+// If another goroutine already refreshed away from staleToken, that token is reused.
+func (c *Client) refreshJWT(staleToken string) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 
-	return resp, nil
+	if c.replaying {
+		return SnapshotToken, nil
+	}
+	if jwt := c.config.JWTFor(c.BaseURL); jwt == nil || jwt.Token == staleToken {
+		c.config.ClearJWT(c.BaseURL)
+	}
+	if err := c.ensureValidJWTLocked(); err != nil {
+		return "", err
+	}
+	return c.config.JWTFor(c.BaseURL).Token, nil
 }
 
-// respectRateLimit implements basic rate limiting to stay under 360 requests/minute
-func (c *Client) respectRateLimit() {
-	// Simple rate limiting: ensure at least 167ms between requests (360/min = 6/sec)
-	minInterval := 167 * time.Millisecond
-	if !c.lastRequest.IsZero() {
-		elapsed := time.Since(c.lastRequest)
-		if elapsed < minInterval {
-			time.Sleep(minInterval - elapsed)
+// respectRateLimit paces requests to stay within the API's rate limit: by the limit
+// reported in response headers when there is one (see reportedPaceLocked), and
+// otherwise by MinRequestInterval, which stays under 360 requests/minute. Each
+// caller reserves the next free slot, so concurrent requests share the limit.
+// While throttled after a 429 the slots are spread further apart.
+func (c *Client) respectRateLimit() error {
+	c.rateMu.Lock()
+	now := time.Now()
+	minInterval := c.MinRequestInterval
+	interval, waitUntil, reported := c.reportedPaceLocked(now)
+	if reported {
+		minInterval = interval
+	}
+	if c.throttleFactor > 1 {
+		if now.Before(c.throttleUntil) {
+			minInterval *= time.Duration(c.throttleFactor)
+		} else {
+			// The cooldown passed without another 429, so return to the normal pace
+			c.throttleFactor = 1
 		}
 	}
+
+	next := now
+	if !c.lastRequest.IsZero() && c.lastRequest.Add(minInterval).After(now) {
+		next = c.lastRequest.Add(minInterval)
+	}
+	if waitUntil.After(next) {
+		next = waitUntil
+	}
+	c.lastRequest = next
+	c.rateMu.Unlock()
+
+	if !waitUntil.IsZero() {
+		c.debugf("rate limit exhausted; waiting %s for it to reset", time.Until(next).Round(time.Millisecond))
+	}
+	return c.sleep(time.Until(next))
+}
+
+// send makes one attempt at req, recording the rate limit the response reports
+// and decompressing its body
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.doWithConnectRetry(req)
+	if err != nil {
+		return nil, err
+	}
+	c.recordRateLimit(req, resp)
+	if err := decompressResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// makeRequestWithRetry executes an HTTP request with retry logic for rate limiting and auth errors
+// makeRequestWithRetry executes an HTTP request with retry logic for rate limiting, auth
+// errors, and transient connection failures
 func (c *Client) makeRequestWithRetry(req *http.Request) (*http.Response, error) {
 	// Make the initial request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
-	// Handle different HTTP status codes
+	// Retry once after an expired token or a rate limit; other statuses are final
 	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
-		return resp, nil
-
 	case http.StatusUnauthorized:
 		resp.Body.Close()
 
 		// Clear the JWT and try once more
-		c.config.ClearJWT()
-		if err := c.EnsureValidJWT(); err != nil {
+		staleToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		token, err := c.refreshJWT(staleToken)
+		if err != nil {
 			return nil, fmt.Errorf("failed to refresh token after 401: %w", err)
 		}
 
 		// Retry the request with new token
-		req.Header.Set("Authorization", "Bearer "+c.config.JWT.Token)
-		resp, err = c.HTTPClient.Do(req)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+		resp, err = c.send(req)
 		if err != nil {
 			return nil, fmt.Errorf("retry request failed: %w", err)
 		}
-		return resp, nil
 
 	case http.StatusTooManyRequests:
 		resp.Body.Close()
-
-		// Check for Retry-After header
-		retryAfter := RetryAfterDefault
-		if retryHeader := resp.Header.Get("Retry-After"); retryHeader != "" {
-			if seconds, err := strconv.Atoi(retryHeader); err == nil {
-				retryAfter = time.Duration(seconds) * time.Second
-			}
-		}
+		c.throttle()
 
 		// Wait and retry once
-		time.Sleep(retryAfter)
-		resp, err = c.HTTPClient.Do(req)
+		if err := c.sleep(c.retryAfterDelay(resp.Header.Get("Retry-After"))); err != nil {
+			return nil, fmt.Errorf("retry after rate limit cancelled: %w", err)
+		}
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+		resp, err = c.send(req)
 		if err != nil {
 			return nil, fmt.Errorf("retry after rate limit failed: %w", err)
 		}
-		return resp, nil
+	}
 
-	case http.StatusBadRequest:
-		bodyBytes, _ := io.ReadAll(resp.Body)
+	// Resend after retryable statuses with the connection retry backoff, or the
+	// wait the response asks for
+	backoff := c.ConnectRetryBackoff
+	for attempt := 0; attempt < c.MaxConnectRetries && c.retriesStatus(req, resp); attempt++ {
 		resp.Body.Close()
-		return nil, fmt.Errorf("bad request (400): %s", string(bodyBytes))
+		status, delay := resp.StatusCode, backoff
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			delay = c.retryAfterDelay(retryAfter)
+		}
+		c.debugf("HTTP %d; retrying in %s", status, delay.Round(time.Millisecond))
+		if err := c.sleep(delay); err != nil {
+			return nil, fmt.Errorf("retry after HTTP %d cancelled: %w", status, err)
+		}
+		backoff *= 2
 
-	case http.StatusForbidden:
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, fmt.Errorf("forbidden (403): insufficient permissions - %s", string(bodyBytes))
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+		resp, err = c.send(req)
+		if err != nil {
+			return nil, fmt.Errorf("retry after HTTP %d failed: %w", status, err)
+		}
+	}
 
-	case http.StatusNotFound:
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, fmt.Errorf("not found (404): resource does not exist - %s", string(bodyBytes))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.throttle()
+	}
+	return checkResponse(resp)
+}
 
-	case http.StatusConflict:
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, fmt.Errorf("conflict (409): resource cannot be modified - %s", string(bodyBytes))
+// throttle slows the rate limiter after a 429: each one doubles the request
+// interval, up to MaxThrottleFactor, and restarts the cooldown before the normal
+// pace resumes
+func (c *Client) throttle() {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
 
-	case http.StatusUnprocessableEntity:
+	if c.throttleFactor < 1 {
+		c.throttleFactor = 1
+	}
+	c.throttleFactor = min(c.throttleFactor*2, MaxThrottleFactor)
+	c.throttleUntil = time.Now().Add(c.RateLimitCooldown)
+}
+
+// checkResponse returns resp if its status is successful. Otherwise the body is
+// read into an APIError, or a PendingError for 202 Accepted, and closed.
+func checkResponse(resp *http.Response) (*http.Response, error) {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return resp, nil
+	case http.StatusAccepted:
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("unprocessable entity (422): invalid input - %s", string(bodyBytes))
-
+		return nil, newPendingError(resp, bodyBytes)
 	default:
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("API error: HTTP %d - %s", resp.StatusCode, string(bodyBytes))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 }
 
+// retryAfterDelay computes the wait before retrying a rate limited request from the
+// Retry-After header, applying random jitter and capping it at MaxRetryAfter
+func (c *Client) retryAfterDelay(retryHeader string) time.Duration {
+	retryAfter := RetryAfterDefault
+	if retryHeader != "" {
+		if seconds, err := strconv.ParseInt(retryHeader, 10, 64); err == nil && seconds >= 0 {
+			// Clamp before converting: a huge header would overflow time.Duration
+			// and wrap to a negative or tiny wait. Half the range leaves room for jitter.
+			if c.MaxRetryAfter > 0 && seconds > int64(c.MaxRetryAfter/time.Second) {
+				return c.MaxRetryAfter
+			}
+			seconds = min(seconds, int64(math.MaxInt64/time.Second)/2)
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+
+	// Apply ±10% jitter so parallel clients don't wake up simultaneously
+	jitter := (rand.Float64()*2 - 1) * RetryAfterJitter
+	retryAfter += time.Duration(float64(retryAfter) * jitter)
+
+	if c.MaxRetryAfter > 0 && retryAfter > c.MaxRetryAfter {
+		retryAfter = c.MaxRetryAfter
+	}
+
+	return retryAfter
+}
+
 // Get performs a GET request with authentication
 func (c *Client) Get(endpoint string) (*http.Response, error) {
 	return c.DoAuthenticatedRequest("GET", endpoint, nil)
@@ -324,7 +710,7 @@ func (c *Client) GetUser() (*User, error) {
 	}
 
 	var userResp UserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&userResp); err != nil {
+	if err := c.decodeResponse(resp, &userResp); err != nil {
 		return nil, fmt.Errorf("failed to parse user response: %w", err)
 	}
 
@@ -349,20 +735,23 @@ func (c *Client) ListOrganizations() ([]Organization, error) {
 
 // ListOrganizationMembers retrieves all users/members in the specified organization
 func (c *Client) ListOrganizationMembers(orgID string) ([]OrganizationMember, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/org/%s/members", orgID)
 
 	// Use standard parameters with optimal defaults
 	params := c.BuildStandardParams(nil)
 
-	resp, err := c.GetWithParams(endpoint, params)
+	body, err := c.getList(endpoint, params)
 	if err != nil {
-		return nil, err // Error handling now done in makeRequestWithRetry
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Parse the wrapped response (users are in a "users" array)
 	var wrappedResp OrganizationMembersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&wrappedResp); err != nil {
+	if err := c.unmarshalJSON(body, &wrappedResp); err != nil {
 		return nil, fmt.Errorf("failed to parse organization members response: %w", err)
 	}
 	members := wrappedResp.Users
@@ -371,48 +760,250 @@ func (c *Client) ListOrganizationMembers(orgID string) ([]OrganizationMember, er
 
 // ListOrganizationTeams retrieves all teams in the specified organization
 func (c *Client) ListOrganizationTeams(orgID string) ([]Team, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/org/%s/teams", orgID)
 
 	// Use standard parameters with optimal defaults
 	params := c.BuildStandardParams(nil)
 
-	resp, err := c.GetWithParams(endpoint, params)
+	body, err := c.getList(endpoint, params)
 	if err != nil {
-		return nil, err // Error handling now done in makeRequestWithRetry
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Parse the response (teams are in a "teams" array)
 	var teamsResp OrganizationTeamsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&teamsResp); err != nil {
+	if err := c.unmarshalJSON(body, &teamsResp); err != nil {
 		return nil, fmt.Errorf("failed to parse organization teams response: %w", err)
 	}
 
 	return teamsResp.Teams, nil
 }
 
+// ListPolicies retrieves the scan policies available to an organization
+func (c *Client) ListPolicies(orgID string) ([]Policy, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/policy/%s/list", orgID)
+
+	body, err := c.getList(endpoint, c.BuildStandardParams(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	var policiesResp PoliciesResponse
+	if err := c.unmarshalJSON(body, &policiesResp); err != nil {
+		return nil, fmt.Errorf("failed to parse policies response: %w", err)
+	}
+
+	return policiesResp.ScanPolicies, nil
+}
+
+// CreateTeam creates an empty team in the specified organization
+func (c *Client) CreateTeam(orgID, name string) (*Team, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("team name is required")
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/org/%s/team", orgID)
+
+	body := TeamRequest{
+		Name:           name,
+		OrganizationID: orgID,
+		UserIDs:        []string{},
+		ApplicationIDs: []string{},
+	}
+
+	resp, err := c.Post(endpoint, body)
+	if err != nil {
+		return nil, err // 403/409 errors are surfaced by makeRequestWithRetry
+	}
+	defer resp.Body.Close()
+
+	var created Team
+	if err := c.decodeResponse(resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse create team response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetTeam retrieves a team with its users and applications
+func (c *Client) GetTeam(orgID, teamID string) (*Team, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+	if teamID == "" {
+		return nil, fmt.Errorf("team ID is required")
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/org/%s/team/%s", orgID, teamID)
+
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var team Team
+	if err := c.decodeResponse(resp, &team); err != nil {
+		return nil, fmt.Errorf("failed to parse team response: %w", err)
+	}
+
+	return &team, nil
+}
+
+// AddTeamMember adds a user to a team. Adding an existing member is a no-op.
+func (c *Client) AddTeamMember(orgID, teamID, userID string) error {
+	return c.updateTeamMembers(orgID, teamID, userID, func(userIDs []string, isMember bool) ([]string, error) {
+		if isMember {
+			return nil, nil
+		}
+		return append(userIDs, userID), nil
+	})
+}
+
+// RemoveTeamMember removes a user from a team
+func (c *Client) RemoveTeamMember(orgID, teamID, userID string) error {
+	return c.updateTeamMembers(orgID, teamID, userID, func(userIDs []string, isMember bool) ([]string, error) {
+		if !isMember {
+			return nil, fmt.Errorf("user %s is not a member of team %s", userID, teamID)
+		}
+		remaining := make([]string, 0, len(userIDs))
+		for _, id := range userIDs {
+			if id != userID {
+				remaining = append(remaining, id)
+			}
+		}
+		return remaining, nil
+	})
+}
+
+// updateTeamMembers reads the team, applies change to its user IDs, and writes the
+// team back. The team update endpoint replaces membership, so the current users and
+// applications are always sent. A nil result from change skips the update.
+func (c *Client) updateTeamMembers(orgID, teamID, userID string, change func(userIDs []string, isMember bool) ([]string, error)) error {
+	if userID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	team, err := c.GetTeam(orgID, teamID)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]string, 0, len(team.Users))
+	isMember := false
+	for _, user := range team.Users {
+		userIDs = append(userIDs, user.StackhawkId)
+		if user.StackhawkId == userID {
+			isMember = true
+		}
+	}
+
+	updated, err := change(userIDs, isMember)
+	if err != nil || updated == nil {
+		return err
+	}
+
+	appIDs := make([]string, 0, len(team.Applications))
+	for _, app := range team.Applications {
+		appIDs = append(appIDs, app.ID)
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/org/%s/team/%s", orgID, teamID)
+	resp, err := c.Put(endpoint, TeamRequest{
+		Name:           team.Name,
+		OrganizationID: orgID,
+		UserIDs:        updated,
+		ApplicationIDs: appIDs,
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
 // ListOrganizationApplications retrieves all applications in the specified organization
 func (c *Client) ListOrganizationApplications(orgID string) ([]AppApplication, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v2/org/%s/apps", orgID)
 
 	// Use standard parameters with optimal defaults
 	params := c.BuildStandardParams(nil)
 
-	resp, err := c.GetWithParams(endpoint, params)
+	body, err := c.getList(endpoint, params)
 	if err != nil {
-		return nil, err // Error handling now done in makeRequestWithRetry
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Parse the response (applications are in an "applications" array)
 	var appsResp OrganizationApplicationsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&appsResp); err != nil {
+	if err := c.unmarshalJSON(body, &appsResp); err != nil {
 		return nil, fmt.Errorf("failed to parse organization applications response: %w", err)
 	}
 
 	return appsResp.Applications, nil
 }
 
+// CreateApplication creates a new application in the specified organization
+func (c *Client) CreateApplication(orgID string, app AppApplication) (*AppApplication, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/org/%s/app", orgID)
+
+	body := CreateApplicationRequest{
+		Name: app.Name,
+		Env:  app.Env,
+	}
+
+	resp, err := c.Post(endpoint, body)
+	if err != nil {
+		return nil, err // 409/422 validation errors are returned by checkResponse
+	}
+	defer resp.Body.Close()
+
+	var created AppApplication
+	if err := c.decodeResponse(resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse create application response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// DeleteApplication deletes the specified application. Applications are addressed
+// by ID alone; orgID is accepted for symmetry with the other organization calls.
+func (c *Client) DeleteApplication(orgID, appID string) error {
+	if err := ValidateID("application", appID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/app/%s", url.PathEscape(appID))
+
+	resp, err := c.Delete(endpoint)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
 // ListOrganizationScans retrieves all scans for the specified organization
 func (c *Client) ListOrganizationScans(orgID string) ([]ApplicationScanResult, error) {
 	return c.ListOrganizationScansWithOptions(orgID, nil)
@@ -420,6 +1011,177 @@ func (c *Client) ListOrganizationScans(orgID string) ([]ApplicationScanResult, e
 
 // ListOrganizationScansWithOptions retrieves scans with pagination and sorting options
 func (c *Client) ListOrganizationScansWithOptions(orgID string, opts *PaginationOptions) ([]ApplicationScanResult, error) {
+	scansResp, err := c.listOrganizationScansPage(orgID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return scansResp.ApplicationScanResults, nil
+}
+
+// ForEachOrganizationScanPage fetches scans page by page and calls fn with each page,
+// in order. Return ErrStopPaging from fn to stop early. When the first page reports
+// its page number and the total count, the remaining pages are requested by number,
+// up to c.PageConcurrency at a time; otherwise pages are followed one after another
+// by nextPageToken. A repeated nextPageToken or more than c.MaxPages pages aborts
+// with ErrPaginationLoop.
+func (c *Client) ForEachOrganizationScanPage(orgID string, pageSize int, fn func([]ApplicationScanResult) error) error {
+	opts := &PaginationOptions{PageSize: pageSize}
+
+	first, err := c.listOrganizationScansPage(orgID, opts)
+	if err != nil {
+		return err
+	}
+	if err := fn(first.ApplicationScanResults); err != nil {
+		if errors.Is(err, ErrStopPaging) {
+			return nil
+		}
+		return err
+	}
+	if first.NextPageToken == "" || len(first.ApplicationScanResults) == 0 {
+		return nil
+	}
+
+	page, last := 1, first
+	if firstPage, pages, ok := numberedPages(first, opts.PageSize); ok {
+		page, last, err = c.forEachNumberedScanPage(orgID, opts.PageSize, firstPage, pages, first, fn)
+		if last == nil || err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return c.followScanPageTokens(orgID, opts, page, last.NextPageToken, fn)
+}
+
+// numberedPages reports whether a listing can be fetched by page number, and if so
+// the number of its first page and how many pages it has. That takes the first
+// page's currentPage and totalCount; pageSize is the size already clamped by
+// listOrganizationScansPage.
+func numberedPages(first *OrganizationScansResponse, pageSize int) (int, int, bool) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	current, err := strconv.Atoi(first.CurrentPage.String())
+	if err != nil {
+		return 0, 0, false
+	}
+	total, ok := first.TotalCount.Count()
+	if !ok || total <= int64(len(first.ApplicationScanResults)) {
+		return 0, 0, false
+	}
+	return current, int((total + int64(pageSize) - 1) / int64(pageSize)), true
+}
+
+// scanPageResult is one page fetched by forEachNumberedScanPage
+type scanPageResult struct {
+	resp *OrganizationScansResponse
+	err  error
+}
+
+// forEachNumberedScanPage fetches pages 2 through pages by number and calls fn with
+// each in order. Fetches run ahead of fn by at most c.PageConcurrency pages, so
+// stopping early wastes little. It returns the count and response of the last page
+// delivered, or a nil response once pagination is finished. If the API answers a
+// numbered request with a different page, it stops there so the caller can carry
+// on by token from the last page delivered.
+func (c *Client) forEachNumberedScanPage(orgID string, pageSize int, firstPage int, pages int, first *OrganizationScansResponse, fn func([]ApplicationScanResult) error) (int, *OrganizationScansResponse, error) {
+	concurrency := c.PageConcurrency
+	if concurrency < 1 {
+		concurrency = DefaultPageConcurrency
+	}
+
+	// Each page gets its own buffered channel, so fetches still running when we
+	// return early finish without blocking
+	results := make([]chan scanPageResult, pages)
+	launched := 1
+	launch := func(index int) {
+		results[index] = make(chan scanPageResult, 1)
+		opts := &PaginationOptions{PageSize: pageSize, Page: strconv.Itoa(firstPage + index)}
+		go func() {
+			resp, err := c.listOrganizationScansPage(orgID, opts)
+			results[index] <- scanPageResult{resp: resp, err: err}
+		}()
+	}
+
+	last := first
+	for index := 1; index < pages; index++ {
+		if c.MaxPages > 0 && index+1 > c.MaxPages {
+			return index, nil, fmt.Errorf("%w: stopped after %d pages", ErrPaginationLoop, c.MaxPages)
+		}
+		for ; launched < pages && launched < index+concurrency; launched++ {
+			launch(launched)
+		}
+
+		result := <-results[index]
+		if result.err != nil {
+			return index, nil, result.err
+		}
+		if result.resp.CurrentPage.String() != strconv.Itoa(firstPage+index) {
+			return index, last, nil
+		}
+		if err := fn(result.resp.ApplicationScanResults); err != nil {
+			return index + 1, nil, err
+		}
+		if len(result.resp.ApplicationScanResults) == 0 {
+			return index + 1, nil, nil
+		}
+		last = result.resp
+	}
+
+	// The total may have grown since the first page; pick up any stragglers by token
+	if last.NextPageToken == "" {
+		return pages, nil, nil
+	}
+	return pages, last, nil
+}
+
+// followScanPageTokens fetches the pages after the given number of pages already
+// delivered, following nextPageToken from next
+func (c *Client) followScanPageTokens(orgID string, opts *PaginationOptions, delivered int, next string, fn func([]ApplicationScanResult) error) error {
+	pager := newClientPager(c, c.scansPageFetcher(orgID, *opts), next, delivered)
+
+	for {
+		scans, ok, err := pager.Next(c.context())
+		if err != nil || !ok {
+			return err
+		}
+		if err := fn(scans); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// CountOrganizationScans returns how many scans the organization has, from the
+// totalCount of a single one-scan page. ok is false when the API doesn't report a
+// usable count, in which case the caller must walk the pages instead.
+func (c *Client) CountOrganizationScans(orgID string) (count int, ok bool, err error) {
+	scansResp, err := c.listOrganizationScansPage(orgID, &PaginationOptions{PageSize: 1})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if total, ok := scansResp.TotalCount.Count(); ok && total <= math.MaxInt {
+		return int(total), true, nil
+	}
+	// Without a count, a lone page is only conclusive when it is the last one
+	if scansResp.NextPageToken == "" {
+		return len(scansResp.ApplicationScanResults), true, nil
+	}
+	return 0, false, nil
+}
+
+// listOrganizationScansPage retrieves a single page of scans for the organization
+func (c *Client) listOrganizationScansPage(orgID string, opts *PaginationOptions) (*OrganizationScansResponse, error) {
+	if err := ValidateOrgID(orgID); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/scan/%s", orgID)
 
 	// Start with standard parameters (includes optimal pageSize=1000)
@@ -449,49 +1211,304 @@ func (c *Client) ListOrganizationScansWithOptions(orgID string, opts *Pagination
 
 	params := c.BuildStandardParams(overrides)
 
-	resp, err := c.GetWithParams(endpoint, params)
+	body, err := c.getList(endpoint, params)
 	if err != nil {
-		return nil, err // Error handling now done in makeRequestWithRetry
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Parse the response
 	var scansResp OrganizationScansResponse
-	if err := json.NewDecoder(resp.Body).Decode(&scansResp); err != nil {
+	if err := c.unmarshalJSON(body, &scansResp); err != nil {
 		return nil, fmt.Errorf("failed to parse organization scans response: %w", err)
 	}
 
-	return scansResp.ApplicationScanResults, nil
+	return &scansResp, nil
+}
+
+// GetScan retrieves a single scan with its duration, URL count, and alert stats.
+// It returns an error wrapping ErrNotFound if the scan doesn't exist.
+func (c *Client) GetScan(scanID string) (*ApplicationScanResult, error) {
+	if strings.TrimSpace(scanID) == "" {
+		return nil, fmt.Errorf("%w: scan ID is required", ErrNotFound)
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/scan/%s/summary", url.PathEscape(scanID))
+
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err // Error handling now done in makeRequestWithRetry
+	}
+	defer resp.Body.Close()
+
+	var result ApplicationScanResult
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse scan response: %w", err)
+	}
+
+	// An empty body means the API had nothing for this ID
+	if result.Scan.ID == "" {
+		return nil, fmt.Errorf("%w: scan %s", ErrNotFound, scanID)
+	}
+
+	return &result, nil
+}
+
+// GetScansByIDs fetches the given scans, in the order requested. Each ID is tried
+// with GetScan first; any that 404 are looked up in the organization's scan list,
+// which is fetched at most once. IDs that can't be found either way are left out
+// of the result, as are repeats of an ID already returned.
+func (c *Client) GetScansByIDs(orgID string, ids []string) ([]ApplicationScanResult, error) {
+	found := make(map[string]ApplicationScanResult, len(ids))
+	missing := make(map[string]bool)
+	for _, id := range ids {
+		if _, ok := found[id]; ok || missing[id] {
+			continue
+		}
+		scan, err := c.GetScan(id)
+		if err == nil {
+			found[id] = *scan
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		missing[id] = true
+	}
+
+	if len(missing) > 0 {
+		scans, err := c.ListOrganizationScans(orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, scan := range scans {
+			if missing[scan.Scan.ID] {
+				found[scan.Scan.ID] = scan
+			}
+		}
+	}
+
+	results := make([]ApplicationScanResult, 0, len(found))
+	for _, id := range ids {
+		if scan, ok := found[id]; ok {
+			results = append(results, scan)
+			delete(found, id)
+		}
+	}
+	return results, nil
 }
 
 // GetScanAlerts retrieves alerts for a specific scan
 func (c *Client) GetScanAlerts(scanID string) ([]ScanAlert, error) {
+	_, alerts, err := c.GetScanWithAlerts(scanID)
+	return alerts, err
+}
+
+// GetScanWithAlerts retrieves alerts for a specific scan along with the scan's
+// application and environment details
+func (c *Client) GetScanWithAlerts(scanID string) (*Scan, []ScanAlert, error) {
 	endpoint := fmt.Sprintf("/api/v1/scan/%s/alerts", scanID)
 
 	resp, err := c.Get(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get scan alerts: %w", err)
+		return nil, nil, fmt.Errorf("failed to get scan alerts: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: HTTP %d - %s", resp.StatusCode, string(bodyBytes))
+		return nil, nil, fmt.Errorf("API error: HTTP %d - %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	// Parse the response
 	var alertsResp ScanAlertsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&alertsResp); err != nil {
-		return nil, fmt.Errorf("failed to parse scan alerts response: %w", err)
+	if err := c.decodeResponse(resp, &alertsResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse scan alerts response: %w", err)
 	}
 
 	// Extract alerts from nested structure
+	scan := &Scan{ID: scanID}
 	var alerts []ScanAlert
 	for _, result := range alertsResp.ApplicationScanResults {
+		if result.Scan.ID != "" {
+			scan = &result.Scan
+		}
 		alerts = append(alerts, result.ApplicationAlerts...)
 	}
 
-	return alerts, nil
+	return scan, alerts, nil
+}
+
+// GetAlertFindings retrieves the individual URIs where a scan found an alert,
+// following every page. The returned response holds the alert details from the
+// first page and the findings from all pages.
+func (c *Client) GetAlertFindings(scanID, pluginID string) (*ScanAlertFindingsResponse, error) {
+	var result *ScanAlertFindingsResponse
+	pager := newClientPager(c, c.alertFindingsPageFetcher(scanID, pluginID, &result), "", 0)
+
+	findings, err := pager.All(c.context())
+	if err != nil {
+		return nil, err
+	}
+
+	result.ApplicationScanAlertUris = findings
+	result.NextPageToken = ""
+	return result, nil
+}
+
+// CollectOrgAlerts lists the organization's scans, selects the latest COMPLETED scan
+// for each application/environment, and fetches their alerts concurrently. Results
+// are keyed by scan ID; a failure fetching one scan is recorded on its entry.
+func (c *Client) CollectOrgAlerts(orgID string, opts *CollectAlertsOptions) (map[string]OrgScanAlerts, error) {
+	concurrency := DefaultAlertConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	scanResults, err := c.ListOrganizationScans(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scans: %w", err)
+	}
+
+	latest := LatestCompletedScans(scanResults)
+
+	// Skip scans outside the requested environments before fetching their alerts
+	if opts != nil && len(opts.Envs) > 0 {
+		filtered := latest[:0]
+		for _, scanResult := range latest {
+			for _, env := range opts.Envs {
+				if strings.EqualFold(scanResult.Scan.Env, env) {
+					filtered = append(filtered, scanResult)
+					break
+				}
+			}
+		}
+		latest = filtered
+	}
+
+	var progress func(done, total int)
+	if opts != nil {
+		progress = opts.Progress
+	}
+	return c.FetchScanAlerts(latest, concurrency, progress), nil
+}
+
+// FetchScanAlerts fetches the alerts of each scan using up to concurrency workers,
+// which share this client's rate limiter. Results are keyed by scan ID; a failure
+// fetching one scan is recorded on its entry. A non-nil progress is called with 0
+// before any fetch and then with the number of scans done after each one, from one
+// worker at a time.
+func (c *Client) FetchScanAlerts(scans []ApplicationScanResult, concurrency int, progress func(done, total int)) map[string]OrgScanAlerts {
+	if concurrency < 1 {
+		concurrency = DefaultAlertConcurrency
+	}
+	if progress == nil {
+		progress = func(done, total int) {}
+	}
+	progress(0, len(scans))
+
+	results := make(map[string]OrgScanAlerts, len(scans))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan ApplicationScanResult)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for scanResult := range jobs {
+				// Once the deadline passes, remaining scans fail fast with the context error
+				alerts, err := c.GetScanAlerts(scanResult.Scan.ID)
+
+				mu.Lock()
+				results[scanResult.Scan.ID] = OrgScanAlerts{
+					Scan:   scanResult,
+					Alerts: alerts,
+					Err:    err,
+				}
+				progress(len(results), len(scans))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, scanResult := range scans {
+		jobs <- scanResult
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// FetchAlertFindings fetches the findings of each of a scan's alerts using up to
+// concurrency workers, which share this client's rate limiter. Results are in the
+// order of alerts; a failure fetching one alert's findings is recorded on its entry.
+func (c *Client) FetchAlertFindings(scanID string, alerts []ScanAlert, concurrency int) []AlertFindings {
+	if concurrency < 1 {
+		concurrency = DefaultAlertConcurrency
+	}
+
+	results := make([]AlertFindings, len(alerts))
+	var wg sync.WaitGroup
+
+	jobs := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker writes only its own entries, so results needs no lock
+			for index := range jobs {
+				result := AlertFindings{Alert: alerts[index]}
+				resp, err := c.GetAlertFindings(scanID, alerts[index].PluginID)
+				if err != nil {
+					result.Err = err
+				} else {
+					result.Findings = resp.ApplicationScanAlertUris
+				}
+				results[index] = result
+			}
+		}()
+	}
+
+	for i := range alerts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// LatestCompletedScans returns the most recent COMPLETED scan for each application/environment pair
+func LatestCompletedScans(scanResults []ApplicationScanResult) []ApplicationScanResult {
+	latest := make(map[string]ApplicationScanResult)
+	order := make([]string, 0)
+
+	for _, result := range scanResults {
+		if !strings.EqualFold(result.Scan.Status, "COMPLETED") {
+			continue
+		}
+
+		key := result.Scan.ApplicationID + "/" + result.Scan.Env
+		existing, ok := latest[key]
+		if !ok {
+			order = append(order, key)
+			latest[key] = result
+			continue
+		}
+
+		existingTS, _ := strconv.ParseInt(existing.Scan.Timestamp, 10, 64)
+		resultTS, _ := strconv.ParseInt(result.Scan.Timestamp, 10, 64)
+		if resultTS > existingTS {
+			latest[key] = result
+		}
+	}
+
+	selected := make([]ApplicationScanResult, 0, len(order))
+	for _, key := range order {
+		selected = append(selected, latest[key])
+	}
+	return selected
 }
 
 // BuildStandardParams creates optimized API parameters with smart defaults
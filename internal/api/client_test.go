@@ -1,13 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"hawkop/internal/config"
@@ -19,28 +25,33 @@ type ClientTestSuite struct {
 	client     *Client
 	server     *httptest.Server
 	testConfig *config.Config
+
+	// lastTeamUpdate is the most recent team update body received by the mock server
+	lastTeamUpdate *TeamRequest
 }
 
 // SetupSuite runs before all tests in the suite
 func (suite *ClientTestSuite) SetupSuite() {
-	// Create test config with mock credentials
-	suite.testConfig = &config.Config{
-		APIKey: "test-api-key",
-		OrgID:  "test-org-id",
-		JWT: &config.JWT{
-			Token:     "test-jwt-token",
-			ExpiresAt: time.Now().Add(1 * time.Hour),
-		},
-	}
-
 	// Create test HTTP server
 	suite.server = httptest.NewServer(http.HandlerFunc(suite.mockAPIHandler))
 
+	// Create test config with mock credentials for the server
+	suite.testConfig = testClientConfig(suite.server.URL)
+	suite.testConfig.OrgID = "test-org-id"
+
 	// Create client with test server URL
 	suite.client = NewClient(suite.testConfig)
 	suite.client.SetBaseURL(suite.server.URL)
 }
 
+// testClientConfig returns a config with an API key and a valid JWT for the API
+// at baseURL
+func testClientConfig(baseURL string) *config.Config {
+	cfg := &config.Config{APIKey: "test-api-key"}
+	cfg.SetJWT(baseURL, "test-jwt-token", time.Now().Add(1*time.Hour))
+	return cfg
+}
+
 // TearDownSuite runs after all tests in the suite
 func (suite *ClientTestSuite) TearDownSuite() {
 	suite.server.Close()
@@ -61,6 +72,16 @@ func (suite *ClientTestSuite) mockAPIHandler(w http.ResponseWriter, r *http.Requ
 		suite.handleMockApps(w, r)
 	case "/api/v1/scan/test-org-id":
 		suite.handleMockScans(w, r)
+	case "/api/v1/scan/scan-1/summary":
+		suite.handleMockScan(w, r)
+	case "/api/v1/org/test-org-id/app":
+		suite.handleMockCreateApp(w, r)
+	case "/api/v1/app/app-1":
+		suite.handleMockDeleteApp(w, r)
+	case "/api/v1/org/test-org-id/team":
+		suite.handleMockCreateTeam(w, r)
+	case "/api/v1/org/test-org-id/team/team-1":
+		suite.handleMockTeam(w, r)
 	case "/api/v1/auth/login":
 		suite.handleMockAuth(w, r)
 	default:
@@ -208,6 +229,77 @@ func (suite *ClientTestSuite) handleMockScans(w http.ResponseWriter, r *http.Req
 	_ = json.NewEncoder(w).Encode(scans)
 }
 
+func (suite *ClientTestSuite) handleMockScan(w http.ResponseWriter, r *http.Request) {
+	scan := ApplicationScanResult{
+		Scan: Scan{
+			ID:              "scan-1",
+			ApplicationID:   "app-1",
+			ApplicationName: "Test App",
+			Status:          "COMPLETED",
+		},
+		ScanDuration: "45",
+		AlertStats:   &AlertStats{High: 2, Total: 2},
+	}
+	_ = json.NewEncoder(w).Encode(scan)
+}
+
+func (suite *ClientTestSuite) handleMockCreateApp(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(suite.T(), "POST", r.Method)
+
+	var req CreateApplicationRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if req.Name == "duplicate" {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"application name already exists"}`))
+		return
+	}
+
+	app := AppApplication{
+		ApplicationID: "new-app-id",
+		Name:          req.Name,
+		Env:           req.Env,
+	}
+	_ = json.NewEncoder(w).Encode(app)
+}
+
+func (suite *ClientTestSuite) handleMockDeleteApp(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(suite.T(), "DELETE", r.Method)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (suite *ClientTestSuite) handleMockCreateTeam(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(suite.T(), "POST", r.Method)
+
+	var req TeamRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if req.Name == "duplicate" {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"team name already exists"}`))
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(Team{ID: "new-team-id", Name: req.Name, OrganizationID: req.OrganizationID})
+}
+
+func (suite *ClientTestSuite) handleMockTeam(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		_ = json.NewEncoder(w).Encode(Team{
+			ID:           "team-1",
+			Name:         "Test Team",
+			Users:        []OrganizationMember{{StackhawkId: "user-1"}},
+			Applications: []Application{{ID: "app-1"}},
+		})
+	case "PUT":
+		var req TeamRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		suite.lastTeamUpdate = &req
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
 // Test API client creation
 func (suite *ClientTestSuite) TestNewClient() {
 	client := NewClient(suite.testConfig)
@@ -216,6 +308,34 @@ func (suite *ClientTestSuite) TestNewClient() {
 	assert.NotNil(suite.T(), client.HTTPClient)
 }
 
+// Test that a token cached for one API is never sent to another
+func (suite *ClientTestSuite) TestCurrentToken_PerBaseURL() {
+	cfg := &config.Config{APIKey: "test-api-key"}
+	cfg.SetJWT(DefaultBaseURL, "prod-token", time.Now().Add(time.Hour))
+
+	client := NewClient(cfg)
+	assert.Equal(suite.T(), "prod-token", client.currentToken())
+
+	client.SetBaseURL("https://eu.api.example.com")
+	assert.Empty(suite.T(), client.currentToken())
+	assert.True(suite.T(), cfg.NeedsJWTRefresh(client.BaseURL))
+}
+
+// Test exchanging an API key without touching the client's credentials
+func (suite *ClientTestSuite) TestExchangeAPIKey() {
+	jwt := suite.testConfig.JWTFor(suite.server.URL)
+
+	auth, err := suite.client.ExchangeAPIKey("test-api-key")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new-jwt-token", auth.Token)
+	assert.False(suite.T(), auth.ExpiresAt.IsZero())
+	assert.Same(suite.T(), jwt, suite.testConfig.JWTFor(suite.server.URL), "exchanging a key must not store its token")
+
+	_, err = suite.client.ExchangeAPIKey("wrong-key")
+	assert.ErrorIs(suite.T(), err, ErrAuthFailed)
+	assert.Equal(suite.T(), http.StatusUnauthorized, statusCode(err))
+}
+
 // Test BuildStandardParams with defaults
 func (suite *ClientTestSuite) TestBuildStandardParams_Defaults() {
 	params := suite.client.BuildStandardParams(nil)
@@ -271,6 +391,28 @@ func (suite *ClientTestSuite) TestListOrganizationTeams_Success() {
 	assert.Len(suite.T(), teams[1].Applications, 1)
 }
 
+// Test scan policy listing
+func (suite *ClientTestSuite) TestListPolicies_Success() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(suite.T(), "/api/v1/policy/test-org-id/list", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"scanPolicies": [{"id": "policy-1", "name": "DEFAULT", "plugins": [{"pluginId": "40012"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	policies, err := client.ListPolicies("test-org-id")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), policies, 1)
+	assert.Equal(suite.T(), "DEFAULT", policies[0].Name)
+	assert.Equal(suite.T(), []PolicyPlugin{{PluginID: "40012"}}, policies[0].Plugins)
+
+	_, err = client.ListPolicies("")
+	assert.ErrorIs(suite.T(), err, ErrInvalidOrgID)
+}
+
 // Test organization applications listing
 func (suite *ClientTestSuite) TestListOrganizationApplications_Success() {
 	apps, err := suite.client.ListOrganizationApplications("test-org-id")
@@ -292,6 +434,168 @@ func (suite *ClientTestSuite) TestListOrganizationScans_Success() {
 	assert.Equal(suite.T(), 6, scans[0].AlertStats.Total)
 }
 
+// Test fetching a single scan by ID
+func (suite *ClientTestSuite) TestGetScan_Success() {
+	scan, err := suite.client.GetScan("scan-1")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "scan-1", scan.Scan.ID)
+	assert.Equal(suite.T(), FlexString("45"), scan.ScanDuration)
+	assert.Equal(suite.T(), 2, scan.AlertStats.Total)
+}
+
+// Test that a missing scan wraps ErrNotFound
+func (suite *ClientTestSuite) TestGetScan_NotFound() {
+	_, err := suite.client.GetScan("missing-scan")
+
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
+	assert.Contains(suite.T(), err.Error(), "not found (404)")
+}
+
+// Test that alert findings are collected across pages
+func (suite *ClientTestSuite) TestGetAlertFindings_Pagination() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(suite.T(), "/api/v1/scan/scan-1/alert/40012", r.URL.Path)
+		resp := ScanAlertFindingsResponse{Alert: ScanAlert{PluginID: "40012", Name: "XSS"}}
+		if r.URL.Query().Get("pageToken") == "" {
+			resp.ApplicationScanAlertUris = []ScanAlertFinding{{URI: "/a"}, {URI: "/b"}}
+			resp.NextPageToken = "page-2"
+		} else {
+			resp.ApplicationScanAlertUris = []ScanAlertFinding{{URI: "/c"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	result, err := client.GetAlertFindings("scan-1", "40012")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "XSS", result.Alert.Name)
+	assert.Equal(suite.T(), []ScanAlertFinding{{URI: "/a"}, {URI: "/b"}, {URI: "/c"}}, result.ApplicationScanAlertUris)
+	assert.Empty(suite.T(), result.NextPageToken)
+}
+
+// Test that batch fetches keep the requested order and skip unknown IDs
+func (suite *ClientTestSuite) TestGetScansByIDs_PartialMatch() {
+	var listRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/scan/scan-a/summary":
+			_ = json.NewEncoder(w).Encode(ApplicationScanResult{Scan: Scan{ID: "scan-a"}})
+		case "/api/v1/scan/test-org-id":
+			listRequests++
+			_ = json.NewEncoder(w).Encode(OrganizationScansResponse{
+				ApplicationScanResults: []ApplicationScanResult{
+					{Scan: Scan{ID: "scan-c"}},
+					{Scan: Scan{ID: "scan-b"}},
+					{Scan: Scan{ID: "scan-a"}},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	scans, err := client.GetScansByIDs("test-org-id", []string{"scan-b", "missing", "scan-a", "scan-b"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), scans, 2)
+	assert.Equal(suite.T(), "scan-b", scans[0].Scan.ID)
+	assert.Equal(suite.T(), "scan-a", scans[1].Scan.ID)
+	assert.Equal(suite.T(), 1, listRequests, "the scan list should be fetched once for all misses")
+}
+
+// Test that a batch of direct hits never fetches the scan list
+func (suite *ClientTestSuite) TestGetScansByIDs_AllDirect() {
+	scans, err := suite.client.GetScansByIDs("test-org-id", []string{"scan-1"})
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), scans, 1)
+	assert.Equal(suite.T(), 2, scans[0].AlertStats.Total, "should come from the summary endpoint")
+}
+
+// Test application creation
+func (suite *ClientTestSuite) TestCreateApplication_Success() {
+	app, err := suite.client.CreateApplication("test-org-id", AppApplication{Name: "New App", Env: "Development"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new-app-id", app.ApplicationID)
+	assert.Equal(suite.T(), "New App", app.Name)
+	assert.Equal(suite.T(), "Development", app.Env)
+}
+
+// Test application creation conflict is surfaced
+func (suite *ClientTestSuite) TestCreateApplication_Conflict() {
+	_, err := suite.client.CreateApplication("test-org-id", AppApplication{Name: "duplicate", Env: "Development"})
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "conflict (409)")
+	assert.Contains(suite.T(), err.Error(), "already exists")
+}
+
+// Test application deletion with 204 No Content
+func (suite *ClientTestSuite) TestDeleteApplication_Success() {
+	err := suite.client.DeleteApplication("test-org-id", "app-1")
+	assert.NoError(suite.T(), err)
+}
+
+// Test that an empty or malformed application ID can't change the endpoint path
+func (suite *ClientTestSuite) TestDeleteApplication_InvalidID() {
+	for _, appID := range []string{"", "app-1/env", "app-1?force=true", "../org"} {
+		err := suite.client.DeleteApplication("test-org-id", appID)
+		assert.ErrorIs(suite.T(), err, ErrInvalidID, appID)
+	}
+}
+
+// Test team creation and conflict handling
+func (suite *ClientTestSuite) TestCreateTeam() {
+	team, err := suite.client.CreateTeam("test-org-id", "Platform")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new-team-id", team.ID)
+	assert.Equal(suite.T(), "Platform", team.Name)
+
+	_, err = suite.client.CreateTeam("test-org-id", "duplicate")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "conflict (409)")
+
+	_, err = suite.client.CreateTeam("test-org-id", "  ")
+	assert.Error(suite.T(), err)
+}
+
+// Test that membership changes send the full user and application lists
+func (suite *ClientTestSuite) TestTeamMembership() {
+	suite.lastTeamUpdate = nil
+	assert.NoError(suite.T(), suite.client.AddTeamMember("test-org-id", "team-1", "user-2"))
+	if assert.NotNil(suite.T(), suite.lastTeamUpdate) {
+		assert.Equal(suite.T(), []string{"user-1", "user-2"}, suite.lastTeamUpdate.UserIDs)
+		assert.Equal(suite.T(), []string{"app-1"}, suite.lastTeamUpdate.ApplicationIDs)
+		assert.Equal(suite.T(), "Test Team", suite.lastTeamUpdate.Name)
+	}
+
+	// Adding an existing member doesn't update the team
+	suite.lastTeamUpdate = nil
+	assert.NoError(suite.T(), suite.client.AddTeamMember("test-org-id", "team-1", "user-1"))
+	assert.Nil(suite.T(), suite.lastTeamUpdate)
+
+	assert.NoError(suite.T(), suite.client.RemoveTeamMember("test-org-id", "team-1", "user-1"))
+	if assert.NotNil(suite.T(), suite.lastTeamUpdate) {
+		assert.Empty(suite.T(), suite.lastTeamUpdate.UserIDs)
+	}
+
+	err := suite.client.RemoveTeamMember("test-org-id", "team-1", "user-9")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "not a member")
+
+	assert.Error(suite.T(), suite.client.AddTeamMember("test-org-id", "team-1", ""))
+}
+
 // Test error handling for invalid organization
 func (suite *ClientTestSuite) TestListOrganizationMembers_InvalidOrg() {
 	_, err := suite.client.ListOrganizationMembers("invalid-org")
@@ -300,6 +604,46 @@ func (suite *ClientTestSuite) TestListOrganizationMembers_InvalidOrg() {
 	assert.Contains(suite.T(), err.Error(), "not found (404)")
 }
 
+// Test validation of empty organization IDs
+func (suite *ClientTestSuite) TestListOrganizationMethods_EmptyOrgID() {
+	_, err := suite.client.ListOrganizationMembers("")
+	assert.ErrorIs(suite.T(), err, ErrInvalidOrgID)
+
+	_, err = suite.client.ListOrganizationTeams("")
+	assert.ErrorIs(suite.T(), err, ErrInvalidOrgID)
+
+	_, err = suite.client.ListOrganizationApplications("")
+	assert.ErrorIs(suite.T(), err, ErrInvalidOrgID)
+
+	_, err = suite.client.ListOrganizationScans("")
+	assert.ErrorIs(suite.T(), err, ErrInvalidOrgID)
+	assert.Contains(suite.T(), err.Error(), "invalid organization ID")
+}
+
+// Test validation of malformed organization IDs
+func (suite *ClientTestSuite) TestValidateOrgID() {
+	assert.NoError(suite.T(), ValidateOrgID("test-org-id"))
+	assert.NoError(suite.T(), ValidateOrgID("058b994a-b95e-4562-ad0a-de8175164c60"))
+
+	for _, orgID := range []string{"", " ", "../admin", "org/123", "org id", "org?x=1", "-leading"} {
+		assert.ErrorIs(suite.T(), ValidateOrgID(orgID), ErrInvalidOrgID, orgID)
+	}
+}
+
+// Test that every kind of ID is held to the same pattern as organization IDs
+func (suite *ClientTestSuite) TestValidateID() {
+	assert.NoError(suite.T(), ValidateID("scan", "058b994a-b95e-4562-ad0a-de8175164c60"))
+	assert.NoError(suite.T(), ValidateID("team", "team_1"))
+
+	for _, id := range []string{"", " ", "../admin", "scan/123", "scan id", "scan?x=1", "-leading"} {
+		err := ValidateID("scan", id)
+		assert.ErrorIs(suite.T(), err, ErrInvalidID, id)
+		assert.ErrorIs(suite.T(), ValidateOrgID(id), ErrInvalidOrgID, id)
+	}
+	assert.EqualError(suite.T(), ValidateID("team", ""), "team ID is empty")
+	assert.EqualError(suite.T(), ValidateID("user", "a b"), `invalid user ID "a b": IDs contain only letters, digits, '-' and '_'`)
+}
+
 // Test rate limiting behavior
 func (suite *ClientTestSuite) TestRateLimiting() {
 	start := time.Now()
@@ -315,6 +659,487 @@ func (suite *ClientTestSuite) TestRateLimiting() {
 	assert.GreaterOrEqual(suite.T(), elapsed, 334*time.Millisecond)
 }
 
+// Test that an absurd Retry-After is capped
+func (suite *ClientTestSuite) TestRetryAfterDelay_Capped() {
+	client := NewClient(suite.testConfig)
+
+	assert.Equal(suite.T(), MaxRetryAfterDefault, client.retryAfterDelay("999999999"))
+
+	client.MaxRetryAfter = 5 * time.Second
+	assert.Equal(suite.T(), 5*time.Second, client.retryAfterDelay("3600"))
+
+	// Values too large for time.Duration must not wrap around to a short wait
+	assert.Equal(suite.T(), 5*time.Second, client.retryAfterDelay("99999999999999999"))
+	client.MaxRetryAfter = 0
+	assert.Greater(suite.T(), client.retryAfterDelay("99999999999999999"), 24*time.Hour)
+
+	// Negative values are ignored in favour of the default
+	client.MaxRetryAfter = MaxRetryAfterDefault
+	delay := client.retryAfterDelay("-30")
+	assert.GreaterOrEqual(suite.T(), delay, 54*time.Second)
+	assert.LessOrEqual(suite.T(), delay, 66*time.Second)
+}
+
+// Test that jitter stays within ±10% of Retry-After
+func (suite *ClientTestSuite) TestRetryAfterDelay_Jitter() {
+	client := NewClient(suite.testConfig)
+
+	for i := 0; i < 50; i++ {
+		delay := client.retryAfterDelay("10")
+		assert.GreaterOrEqual(suite.T(), delay, 9*time.Second)
+		assert.LessOrEqual(suite.T(), delay, 11*time.Second)
+	}
+
+	// Missing or invalid headers fall back to the default
+	delay := client.retryAfterDelay("soon")
+	assert.GreaterOrEqual(suite.T(), delay, 54*time.Second)
+	assert.LessOrEqual(suite.T(), delay, 66*time.Second)
+}
+
+// Test selecting the latest completed scan per app/env
+func (suite *ClientTestSuite) TestLatestCompletedScans() {
+	scans := []ApplicationScanResult{
+		{Scan: Scan{ID: "old", ApplicationID: "app-1", Env: "prod", Status: "COMPLETED", Timestamp: "1000"}},
+		{Scan: Scan{ID: "new", ApplicationID: "app-1", Env: "prod", Status: "COMPLETED", Timestamp: "2000"}},
+		{Scan: Scan{ID: "running", ApplicationID: "app-1", Env: "prod", Status: "STARTED", Timestamp: "3000"}},
+		{Scan: Scan{ID: "dev", ApplicationID: "app-1", Env: "dev", Status: "COMPLETED", Timestamp: "1500"}},
+	}
+
+	latest := LatestCompletedScans(scans)
+
+	assert.Len(suite.T(), latest, 2)
+	assert.Equal(suite.T(), "new", latest[0].Scan.ID)
+	assert.Equal(suite.T(), "dev", latest[1].Scan.ID)
+}
+
+// Test concurrent org-wide alert collection with a per-scan failure
+func (suite *ClientTestSuite) TestCollectOrgAlerts() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/scan/test-org-id":
+			scans := OrganizationScansResponse{ApplicationScanResults: []ApplicationScanResult{}}
+			for i := 1; i <= 5; i++ {
+				scans.ApplicationScanResults = append(scans.ApplicationScanResults, ApplicationScanResult{
+					Scan: Scan{
+						ID:            fmt.Sprintf("scan-%d", i),
+						ApplicationID: fmt.Sprintf("app-%d", i),
+						Env:           []string{"Development", "Production"}[i%2],
+						Status:        "COMPLETED",
+						Timestamp:     "1756596062834",
+					},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(scans)
+		case "/api/v1/scan/scan-3/alerts":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			alerts := ScanAlertsResponse{}
+			alerts.ApplicationScanResults = append(alerts.ApplicationScanResults, ScanAlertsResult{
+				ApplicationAlerts: []ScanAlert{{PluginID: "10001", Name: "SQL Injection", Severity: "High"}},
+			})
+			_ = json.NewEncoder(w).Encode(alerts)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	var progress []int
+	results, err := client.CollectOrgAlerts("test-org-id", &CollectAlertsOptions{
+		Concurrency: 3,
+		Progress: func(done, total int) {
+			assert.Equal(suite.T(), 5, total)
+			progress = append(progress, done)
+		},
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 5)
+	assert.Equal(suite.T(), []int{0, 1, 2, 3, 4, 5}, progress, "reported before fetching and after each scan")
+	assert.Error(suite.T(), results["scan-3"].Err)
+	assert.NoError(suite.T(), results["scan-1"].Err)
+	assert.Len(suite.T(), results["scan-1"].Alerts, 1)
+
+	// Only scans in the requested environments are collected
+	results, err = client.CollectOrgAlerts("test-org-id", &CollectAlertsOptions{Envs: []string{"production"}})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 3)
+	assert.Contains(suite.T(), results, "scan-1")
+	assert.NotContains(suite.T(), results, "scan-2")
+}
+
+// Test that scan pages are followed via nextPageToken and can be stopped early
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage() {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+
+		token := r.URL.Query().Get("pageToken")
+		resp := OrganizationScansResponse{ApplicationScanResults: []ApplicationScanResult{
+			{Scan: Scan{ID: "scan-" + token}},
+		}}
+		switch token {
+		case "":
+			resp.ApplicationScanResults[0].Scan.ID = "scan-first"
+			resp.NextPageToken = "2"
+		case "2":
+			resp.NextPageToken = "3"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	var ids []string
+	err := client.ForEachOrganizationScanPage("test-org-id", 0, func(page []ApplicationScanResult) error {
+		for _, result := range page {
+			ids = append(ids, result.Scan.ID)
+		}
+		return nil
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"scan-first", "scan-2", "scan-3"}, ids)
+	assert.Equal(suite.T(), 3, requests)
+
+	// ErrStopPaging ends pagination without an error
+	requests = 0
+	client.ClearListCache()
+	err = client.ForEachOrganizationScanPage("test-org-id", 0, func(page []ApplicationScanResult) error {
+		return ErrStopPaging
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, requests)
+}
+
+// Test that a self-referential page token aborts instead of looping forever
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_RepeatedToken() {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OrganizationScansResponse{
+			ApplicationScanResults: []ApplicationScanResult{{Scan: Scan{ID: "scan-" + strconv.Itoa(requests)}}},
+			NextPageToken:          "same-token",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	pages := 0
+	err := client.ForEachOrganizationScanPage("test-org-id", 0, func(page []ApplicationScanResult) error {
+		pages++
+		return nil
+	})
+	require.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrPaginationLoop)
+	assert.Contains(suite.T(), err.Error(), `"same-token"`)
+	assert.Equal(suite.T(), 2, requests)
+	assert.Equal(suite.T(), 2, pages)
+}
+
+// Test that pagination stops at the MaxPages cap when every page has a new token
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_MaxPages() {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OrganizationScansResponse{
+			ApplicationScanResults: []ApplicationScanResult{{Scan: Scan{ID: "scan"}}},
+			NextPageToken:          "token-" + strconv.Itoa(requests),
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.MaxPages = 3
+
+	err := client.ForEachOrganizationScanPage("test-org-id", 0, func(page []ApplicationScanResult) error {
+		return nil
+	})
+	assert.ErrorIs(suite.T(), err, ErrPaginationLoop)
+	assert.Contains(suite.T(), err.Error(), "stopped after 3 pages")
+	assert.Equal(suite.T(), 3, requests)
+}
+
+// Test that a context deadline cancels a rate limit wait instead of sleeping it out
+func (suite *ClientTestSuite) TestSetContext_CancelsRetryWait() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.SetContext(ctx)
+
+	start := time.Now()
+	_, err := client.ListOrganizationTeams("test-org-id")
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), errors.Is(err, context.DeadlineExceeded))
+	assert.Less(suite.T(), time.Since(start), 5*time.Second)
+}
+
+// Test that 429s slow the rate limiter for the cooldown window, then it recovers
+func (suite *ClientTestSuite) TestRateLimit_ThrottlesAfter429() {
+	var mu sync.Mutex
+	var requestTimes []time.Time
+	rateLimited := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requestTimes = append(requestTimes, time.Now())
+		if rateLimited {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"teams": []}`))
+	}))
+	defer server.Close()
+
+	const interval = 20 * time.Millisecond
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.MinRequestInterval = interval
+	client.RateLimitCooldown = 500 * time.Millisecond
+
+	// Each call is rate limited twice: the first attempt and its retry
+	for i := 0; i < 3; i++ {
+		_, err := client.ListOrganizationTeams("test-org-id")
+		require.True(suite.T(), IsRateLimited(err))
+	}
+	require.Len(suite.T(), requestTimes, 6)
+
+	// Two 429s stretch the interval 4x, two more hit the 8x cap
+	firstGap := requestTimes[2].Sub(requestTimes[0])
+	secondGap := requestTimes[4].Sub(requestTimes[2])
+	assert.GreaterOrEqual(suite.T(), firstGap, 4*interval-5*time.Millisecond)
+	assert.GreaterOrEqual(suite.T(), secondGap, MaxThrottleFactor*interval-5*time.Millisecond)
+	assert.Greater(suite.T(), secondGap, firstGap)
+
+	// Once the cooldown passes without a 429 the normal pace resumes
+	mu.Lock()
+	rateLimited = false
+	requestTimes = nil
+	mu.Unlock()
+	time.Sleep(client.RateLimitCooldown + 50*time.Millisecond)
+
+	// Different orgs, so the second listing isn't served from the list cache
+	for _, orgID := range []string{"test-org-id", "other-org-id"} {
+		_, err := client.ListOrganizationTeams(orgID)
+		require.NoError(suite.T(), err)
+	}
+	require.Len(suite.T(), requestTimes, 2)
+	assert.Less(suite.T(), requestTimes[1].Sub(requestTimes[0]), 4*interval)
+}
+
+// numberedPagesServer serves scans two per page out of ten, reporting currentPage
+// and totalCount. When honorPage is false the page parameter is ignored, as by an
+// endpoint that only pages by token.
+func numberedPagesServer(honorPage bool) (*httptest.Server, func() (requests int, maxInFlight int, byToken int)) {
+	var mu sync.Mutex
+	var requests, inFlight, maxInFlight, byToken int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		page := 0
+		if token := r.URL.Query().Get("pageToken"); token != "" {
+			mu.Lock()
+			byToken++
+			mu.Unlock()
+			page, _ = strconv.Atoi(token)
+		} else if honorPage && r.URL.Query().Get("page") != "" {
+			page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+		}
+
+		resp := OrganizationScansResponse{
+			ApplicationScanResults: []ApplicationScanResult{
+				{Scan: Scan{ID: fmt.Sprintf("scan-%d-a", page)}},
+				{Scan: Scan{ID: fmt.Sprintf("scan-%d-b", page)}},
+			},
+			TotalCount:  "10",
+			CurrentPage: FlexString(strconv.Itoa(page)),
+		}
+		if page < 4 {
+			resp.NextPageToken = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	return server, func() (int, int, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests, maxInFlight, byToken
+	}
+}
+
+// Test that numbered pages are fetched concurrently and delivered in order
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_Numbered() {
+	server, stats := numberedPagesServer(true)
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.MinRequestInterval = time.Millisecond
+	client.PageConcurrency = 2
+
+	var ids []string
+	err := client.ForEachOrganizationScanPage("test-org-id", 2, func(page []ApplicationScanResult) error {
+		for _, result := range page {
+			ids = append(ids, result.Scan.ID)
+		}
+		return nil
+	})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{
+		"scan-0-a", "scan-0-b", "scan-1-a", "scan-1-b", "scan-2-a",
+		"scan-2-b", "scan-3-a", "scan-3-b", "scan-4-a", "scan-4-b",
+	}, ids)
+
+	requests, maxInFlight, byToken := stats()
+	assert.Equal(suite.T(), 5, requests)
+	assert.Equal(suite.T(), 2, maxInFlight, "pages are fetched two at a time")
+	assert.Zero(suite.T(), byToken)
+}
+
+// Test that stopping early only wastes the pages already being fetched
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_NumberedStop() {
+	server, stats := numberedPagesServer(true)
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.MinRequestInterval = time.Millisecond
+	client.PageConcurrency = 2
+
+	pages := 0
+	err := client.ForEachOrganizationScanPage("test-org-id", 2, func(page []ApplicationScanResult) error {
+		pages++
+		if pages == 2 {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, pages)
+
+	time.Sleep(50 * time.Millisecond)
+	requests, _, _ := stats()
+	assert.LessOrEqual(suite.T(), requests, 3)
+}
+
+// Test that an endpoint ignoring page numbers falls back to following tokens
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_NumberedFallback() {
+	server, stats := numberedPagesServer(false)
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.MinRequestInterval = time.Millisecond
+
+	var ids []string
+	err := client.ForEachOrganizationScanPage("test-org-id", 2, func(page []ApplicationScanResult) error {
+		for _, result := range page {
+			ids = append(ids, result.Scan.ID)
+		}
+		return nil
+	})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), ids, 10)
+	assert.Equal(suite.T(), "scan-1-a", ids[2], "no page is delivered twice")
+
+	_, _, byToken := stats()
+	assert.Equal(suite.T(), 4, byToken)
+}
+
+// Test that pagination stops once the context is done
+func (suite *ClientTestSuite) TestForEachOrganizationScanPage_ContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(suite.testConfig)
+	client.SetBaseURL(suite.server.URL)
+	client.SetContext(ctx)
+
+	pages := 0
+	err := client.ForEachOrganizationScanPage("test-org-id", 0, func(page []ApplicationScanResult) error {
+		pages++
+		return nil
+	})
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Equal(suite.T(), 0, pages)
+}
+
+// Test that an explicit proxy receives requests for the API host
+func TestUseProxy_RoutesRequests(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy sees the absolute target URL
+		proxiedHost = r.URL.Host
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(UserResponse{User: User{StackhawkId: "proxied-user"}})
+	}))
+	defer proxy.Close()
+
+	client := NewClient(testClientConfig("http://api.hawkop.invalid"))
+	client.SetBaseURL("http://api.hawkop.invalid")
+	require.NoError(t, client.UseProxy(proxy.URL))
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "proxied-user", user.StackhawkId)
+	assert.Equal(t, "api.hawkop.invalid", proxiedHost)
+}
+
+// Test that only http, https, and socks5 proxy URLs are accepted
+func TestUseProxy_Validation(t *testing.T) {
+	client := NewClient(&config.Config{})
+
+	assert.Error(t, client.UseProxy("proxy.example.com:8080"))
+	assert.Error(t, client.UseProxy("ftp://proxy.example.com"))
+	assert.Nil(t, client.HTTPClient.Transport, "a rejected proxy leaves the default transport")
+
+	require.NoError(t, client.UseProxy("socks5://127.0.0.1:1080"))
+	transport := client.HTTPClient.Transport.(*http.Transport)
+	req := httptest.NewRequest(http.MethodGet, "https://api.stackhawk.com/api/v1/user", nil)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "socks5://127.0.0.1:1080", proxyURL.String())
+}
+
+// Without UseProxy the client falls back to http.DefaultTransport, whose Proxy
+// is http.ProxyFromEnvironment, so HTTP_PROXY, HTTPS_PROXY, and NO_PROXY apply
+func TestNewClient_UsesEnvironmentProxy(t *testing.T) {
+	client := NewClient(&config.Config{})
+	assert.Nil(t, client.HTTPClient.Transport)
+	assert.NotNil(t, http.DefaultTransport.(*http.Transport).Proxy)
+}
+
 // Run the test suite
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, new(ClientTestSuite))
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// decodeResponse reads resp's body and decodes it into v; see unmarshalJSON
+func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	return c.unmarshalJSON(body, v)
+}
+
+// unmarshalJSON decodes an API response body into v. With StrictDecoding, fields
+// the Go types don't declare are an error, to catch schema drift. Otherwise they
+// are ignored, and the debug log notes top-level keys v doesn't declare along with
+// required (not omitempty) fields the response left out, so a renamed field shows
+// up as a pair instead of silently blank columns. A body that fails to decode is
+// reported as a *DecodeError.
+func (c *Client) unmarshalJSON(body []byte, v interface{}) error {
+	if c.StrictDecoding {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(v); err != nil {
+			return c.newDecodeError(err, body, decoder.InputOffset())
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return c.newDecodeError(err, body, -1)
+	}
+	if c.Debug != nil {
+		unknown, missing := schemaDrift(body, v)
+		if len(unknown) > 0 {
+			c.debugf("%T response has fields hawkop doesn't know: %s", v, strings.Join(unknown, ", "))
+		}
+		if len(missing) > 0 {
+			c.debugf("%T response is missing expected fields: %s", v, strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
+
+// decodeSnippetContext is how many bytes of the body DecodeError shows on each
+// side of the failing offset
+const decodeSnippetContext = 40
+
+// DecodeError describes a response body that could not be decoded: where in the
+// body decoding failed, the text around that point, and, with debug output on,
+// the file the whole body was saved to.
+type DecodeError struct {
+	Err     error
+	Offset  int64
+	Snippet string
+	// BodyFile holds the full response body when Debug is set
+	BodyFile string
+}
+
+func (e *DecodeError) Error() string {
+	msg := e.Err.Error()
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at byte %d", e.Offset)
+	}
+	msg += " near: " + e.Snippet
+	if e.BodyFile != "" {
+		msg += "; full response saved to " + e.BodyFile
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// newDecodeError wraps a decoding error with the offset it occurred at, taken from
+// the error when encoding/json reports one and otherwise from fallback (-1 for
+// none), and a snippet of body around it. With debug output on, the whole body
+// is written to a temporary file for inspection.
+func (c *Client) newDecodeError(err error, body []byte, fallback int64) *DecodeError {
+	offset := fallback
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+
+	decodeErr := &DecodeError{Err: err, Offset: offset, Snippet: bodySnippet(body, offset)}
+	if c.Debug != nil {
+		if path, saveErr := saveResponseBody(body); saveErr != nil {
+			c.debugf("could not save undecodable response: %v", saveErr)
+		} else {
+			decodeErr.BodyFile = path
+		}
+	}
+	return decodeErr
+}
+
+// bodySnippet returns the bytes of body around offset on one line, marking where
+// it was cut with "...". Without an offset the start of the body is shown.
+func bodySnippet(body []byte, offset int64) string {
+	if offset < 0 || offset > int64(len(body)) {
+		offset = 0
+	}
+	start := max(int(offset)-decodeSnippetContext, 0)
+	end := min(int(offset)+decodeSnippetContext, len(body))
+
+	snippet := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, string(body[start:end]))
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(body) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+// saveResponseBody writes body to a new temporary file and returns its path
+func saveResponseBody(body []byte) (string, error) {
+	file, err := os.CreateTemp("", "hawkop-response-*.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(body); err != nil {
+		file.Close()
+		return "", err
+	}
+	return file.Name(), file.Close()
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// schemaDrift compares the top-level keys of a JSON object against the fields of
+// the struct v points to, returning the sorted keys v doesn't declare and the
+// required fields body lacks. Bodies that aren't objects, and types that decode
+// themselves, are not compared.
+func schemaDrift(body []byte, v interface{}) (unknown, missing []string) {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct || t.Implements(jsonUnmarshalerType) {
+		return nil, nil
+	}
+
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(body, &keys) != nil {
+		return nil, nil
+	}
+
+	// encoding/json matches keys to fields without regard to case
+	fields := make(map[string]bool)
+	jsonFields(t.Elem(), fields)
+	present := make(map[string]bool, len(keys))
+	for key := range keys {
+		present[strings.ToLower(key)] = true
+		if _, ok := fields[strings.ToLower(key)]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	for name, required := range fields {
+		if required && !present[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	slices.Sort(unknown)
+	slices.Sort(missing)
+	return unknown, missing
+}
+
+// jsonFields adds the lower-cased JSON names of t's fields to fields, following
+// embedded structs, each mapped to whether it is required (not omitempty)
+func jsonFields(t reflect.Type, fields map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				jsonFields(embedded, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = !slices.Contains(strings.Split(options, ","), "omitempty")
+	}
+}
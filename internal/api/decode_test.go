@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userServer answers every request with body
+func userServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Test that unknown fields are tolerated but noted in the debug log
+func TestDecode_ExtraFieldsLogged(t *testing.T) {
+	server := userServer(t, `{"user":{"stackhawkId":"user-1","nickname":"x"},"region":"eu","tier":"gold"}`)
+	client := newRetryTestClient(server.URL)
+	var debug bytes.Buffer
+	client.Debug = &debug
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.StackhawkId)
+	assert.Contains(t, debug.String(), "*api.UserResponse response has fields hawkop doesn't know: region, tier")
+	assert.NotContains(t, debug.String(), "nickname", "only top-level keys are compared")
+	assert.NotContains(t, debug.String(), "missing")
+}
+
+// Test that a renamed field shows up as an unknown key and a missing field
+func TestDecode_RenamedFieldLogged(t *testing.T) {
+	server := userServer(t, `{"account":{"stackhawkId":"user-1"}}`)
+	client := newRetryTestClient(server.URL)
+	var debug bytes.Buffer
+	client.Debug = &debug
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Empty(t, user.StackhawkId)
+	assert.Contains(t, debug.String(), "doesn't know: account")
+	assert.Contains(t, debug.String(), "missing expected fields: user")
+}
+
+// Test that strict decoding rejects fields the types don't declare, at any depth
+func TestDecode_Strict(t *testing.T) {
+	server := userServer(t, `{"user":{"stackhawkId":"user-1","nickname":"x"}}`)
+	client := newRetryTestClient(server.URL)
+	client.StrictDecoding = true
+
+	_, err := client.GetUser()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "nickname"`)
+
+	strict := newRetryTestClient(userServer(t, `{"user":{"stackhawkId":"user-1"}}`).URL)
+	strict.StrictDecoding = true
+	user, err := strict.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.StackhawkId)
+}
+
+// Test that a malformed body is reported with the failing offset and nearby text,
+// and saved in full when debugging
+func TestDecode_MalformedBody(t *testing.T) {
+	body := `{"applications":[{"applicationId":"app-1","name":"Billing API"},{"applicationId":"app-2",name:"Checkout"}],"totalCount":"2"}`
+	server := userServer(t, body)
+
+	_, err := newRetryTestClient(server.URL).ListOrganizationApplications("org-1")
+	require.Error(t, err)
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, int64(strings.Index(body, "name:")+1), decodeErr.Offset)
+	assert.Contains(t, err.Error(), "failed to parse organization applications response: invalid character 'n'")
+	assert.Contains(t, err.Error(), `at byte 90 near: ...Billing API"},{`)
+	assert.Contains(t, decodeErr.Snippet, `"applicationId":"app-2",name:"Checkout"`)
+	assert.Empty(t, decodeErr.BodyFile, "the body is only saved with debug output on")
+
+	client := newRetryTestClient(server.URL)
+	client.Debug = io.Discard
+	_, err = client.ListOrganizationApplications("org-1")
+	require.ErrorAs(t, err, &decodeErr)
+	require.NotEmpty(t, decodeErr.BodyFile)
+	t.Cleanup(func() { os.Remove(decodeErr.BodyFile) })
+	assert.Contains(t, err.Error(), "full response saved to "+decodeErr.BodyFile)
+	saved, err := os.ReadFile(decodeErr.BodyFile)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(saved))
+}
+
+func TestBodySnippet(t *testing.T) {
+	body := []byte(strings.Repeat("a", 50) + "X" + strings.Repeat("b", 50))
+	assert.Equal(t, "..."+strings.Repeat("a", 40)+"X"+strings.Repeat("b", 39)+"...", bodySnippet(body, 50))
+	assert.Equal(t, `{"a":1}`, bodySnippet([]byte(`{"a":1}`), -1))
+	assert.Equal(t, strings.Repeat("a", 40)+"...", bodySnippet(body, 0))
+	assert.Equal(t, `{  "a": 1 }`, bodySnippet([]byte("{\n\t\"a\": 1\n}"), -1), "kept to one line")
+}
+
+func TestSchemaDrift(t *testing.T) {
+	type base struct {
+		ID string `json:"id"`
+	}
+	type response struct {
+		base
+		Name    string `json:"name,omitempty"`
+		Count   int
+		Skipped string `json:"-"`
+	}
+
+	unknown, missing := schemaDrift([]byte(`{"ID":"1","count":2,"Skipped":"x"}`), &response{})
+	assert.Equal(t, []string{"Skipped"}, unknown, "keys match fields without regard to case")
+	assert.Empty(t, missing, "omitempty fields are optional")
+
+	unknown, missing = schemaDrift([]byte(`{}`), &response{})
+	assert.Empty(t, unknown)
+	assert.Equal(t, []string{"count", "id"}, missing)
+
+	unknown, missing = schemaDrift([]byte(`[1,2]`), &[]int{})
+	assert.Empty(t, unknown)
+	assert.Empty(t, missing)
+}
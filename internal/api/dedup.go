@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// listCache coalesces identical list requests made through one client, so
+// helpers that fetch the same list during a command share a single API call.
+// Concurrent callers wait on the in-flight request; later callers reuse its body.
+type listCache struct {
+	group singleflight.Group
+
+	mu     sync.Mutex
+	bodies map[string][]byte
+}
+
+// listKey identifies a request by endpoint and query parameters. url.Values
+// encodes keys in sorted order, so equal parameter maps produce equal keys.
+func listKey(endpoint string, params map[string]string) string {
+	values := url.Values{}
+	for key, value := range params {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return endpoint + "?" + values.Encode()
+}
+
+func (l *listCache) get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	body, ok := l.bodies[key]
+	return body, ok
+}
+
+func (l *listCache) put(key string, body []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.bodies == nil {
+		l.bodies = make(map[string][]byte)
+	}
+	l.bodies[key] = body
+}
+
+// reset drops cached bodies, e.g. after a request that may have changed them
+func (l *listCache) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bodies = nil
+}
+
+// ClearListCache forgets list responses seen so far, so the next list calls
+// fetch fresh data. Use it when polling the same lists repeatedly.
+func (c *Client) ClearListCache() {
+	c.lists.reset()
+}
+
+// getList performs a GET for a list endpoint and returns the response body,
+// sharing the result with identical requests made through this client
+func (c *Client) getList(endpoint string, params map[string]string) ([]byte, error) {
+	key := listKey(endpoint, params)
+	if body, ok := c.lists.get(key); ok {
+		return body, nil
+	}
+
+	body, err, _ := c.lists.group.Do(key, func() (interface{}, error) {
+		resp, err := c.GetWithParams(endpoint, params)
+		if err != nil {
+			return nil, err // Error handling now done in makeRequestWithRetry
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		c.lists.put(key, data)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body.([]byte), nil
+}
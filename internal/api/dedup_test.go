@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DedupTestSuite struct {
+	suite.Suite
+	requests atomic.Int32
+	server   *httptest.Server
+	client   *Client
+}
+
+func (suite *DedupTestSuite) SetupTest() {
+	suite.requests.Store(0)
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			suite.requests.Add(1)
+		}
+
+		// Hold the response so concurrent callers overlap
+		time.Sleep(50 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(OrganizationApplicationsResponse{
+			Applications: []AppApplication{{ApplicationID: "app-1", Name: "Test App"}},
+		})
+	}))
+
+	suite.client = NewClient(testClientConfig(suite.server.URL))
+	suite.client.SetBaseURL(suite.server.URL)
+}
+
+func (suite *DedupTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// Test that duplicate concurrent list calls share one request
+func (suite *DedupTestSuite) TestConcurrentDuplicatesHitEndpointOnce() {
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apps, err := suite.client.ListOrganizationApplications("test-org-id")
+			assert.NoError(suite.T(), err)
+			assert.Len(suite.T(), apps, 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(suite.T(), int32(1), suite.requests.Load())
+}
+
+// Test that later identical calls reuse the response until the cache is cleared
+func (suite *DedupTestSuite) TestSequentialDuplicatesReuseResponse() {
+	_, err := suite.client.ListOrganizationApplications("test-org-id")
+	require.NoError(suite.T(), err)
+	_, err = suite.client.ListOrganizationApplications("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int32(1), suite.requests.Load())
+
+	// A different organization is a different request
+	_, err = suite.client.ListOrganizationApplications("other-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int32(2), suite.requests.Load())
+
+	suite.client.ClearListCache()
+	_, err = suite.client.ListOrganizationApplications("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int32(3), suite.requests.Load())
+}
+
+// Test that a write invalidates cached lists
+func (suite *DedupTestSuite) TestWriteResetsCache() {
+	_, err := suite.client.ListOrganizationApplications("test-org-id")
+	require.NoError(suite.T(), err)
+
+	resp, err := suite.client.Post("/api/v1/anything", map[string]string{"name": "x"})
+	require.NoError(suite.T(), err)
+	resp.Body.Close()
+
+	_, err = suite.client.ListOrganizationApplications("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int32(2), suite.requests.Load())
+}
+
+func TestListKey(t *testing.T) {
+	a := listKey("/api/v1/scan/org", map[string]string{"pageSize": "1000", "sortDir": "desc", "empty": ""})
+	b := listKey("/api/v1/scan/org", map[string]string{"sortDir": "desc", "pageSize": "1000"})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, listKey("/api/v1/scan/org", map[string]string{"pageSize": "10"}))
+}
+
+func TestDedupTestSuite(t *testing.T) {
+	suite.Run(t, new(DedupTestSuite))
+}
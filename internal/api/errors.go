@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrAuthFailed is wrapped by errors for API keys the login endpoint rejected
+var ErrAuthFailed = errors.New("authentication failed")
+
+// ErrNoCredentials is returned when a request needs a JWT but no API key is configured
+var ErrNoCredentials = errors.New("no API key configured")
+
+// APIError is returned for API responses with an unsuccessful status code. A 404
+// unwraps to ErrNotFound.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return fmt.Sprintf("bad request (400): %s", e.Body)
+	case http.StatusForbidden:
+		return fmt.Sprintf("forbidden (403): insufficient permissions - %s", e.Body)
+	case http.StatusNotFound:
+		return fmt.Sprintf("%v: resource does not exist - %s", ErrNotFound, e.Body)
+	case http.StatusConflict:
+		return fmt.Sprintf("conflict (409): resource cannot be modified - %s", e.Body)
+	case http.StatusUnprocessableEntity:
+		return fmt.Sprintf("unprocessable entity (422): invalid input - %s", e.Body)
+	default:
+		return fmt.Sprintf("API error: HTTP %d - %s", e.StatusCode, e.Body)
+	}
+}
+
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ErrPending is wrapped by PendingError, for requests the API accepted but hasn't
+// finished
+var ErrPending = errors.New("request accepted but not finished")
+
+// PendingError is returned for a 202 Accepted response in place of the response
+// itself, whose body describes the pending work rather than the data asked for.
+// Location, when the API sends it, is the absolute URL to poll for the result.
+type PendingError struct {
+	Location   string
+	PollToken  string        // the body's pollToken field, if any
+	RetryAfter time.Duration // from the Retry-After header; 0 if not sent
+	Body       string
+}
+
+func (e *PendingError) Error() string {
+	if e.Location != "" {
+		return fmt.Sprintf("%v (202); poll %s for the result", ErrPending, e.Location)
+	}
+	return fmt.Sprintf("%v (202)", ErrPending)
+}
+
+func (e *PendingError) Unwrap() error {
+	return ErrPending
+}
+
+// newPendingError describes a 202 response, resolving its Location against the
+// request URL
+func newPendingError(resp *http.Response, body []byte) *PendingError {
+	pending := &PendingError{Body: string(body)}
+
+	if location, err := resp.Location(); err == nil {
+		pending.Location = location.String()
+	}
+	if seconds, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil && seconds > 0 {
+		// Clamp before converting so a huge header can't overflow time.Duration
+		pending.RetryAfter = time.Duration(min(seconds, int64(MaxRetryAfterDefault/time.Second))) * time.Second
+	}
+
+	var fields struct {
+		PollToken string `json:"pollToken"`
+	}
+	if json.Unmarshal(body, &fields) == nil {
+		pending.PollToken = fields.PollToken
+	}
+	return pending
+}
+
+// AsPending returns the PendingError in err's chain, if the API answered 202 Accepted
+func AsPending(err error) (*PendingError, bool) {
+	var pending *PendingError
+	ok := errors.As(err, &pending)
+	return pending, ok
+}
+
+// statusCode returns the status of the APIError in err's chain, or 0 if there is none
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsAuthError reports whether err means the credentials are missing, were rejected,
+// or lack permission for the request
+func IsAuthError(err error) bool {
+	if errors.Is(err, ErrAuthFailed) || errors.Is(err, ErrNoCredentials) {
+		return true
+	}
+	status := statusCode(err)
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// IsNotFound reports whether err means the requested resource does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited reports whether err is a 429 response that persisted after retrying
+func IsRateLimited(err error) bool {
+	return statusCode(err) == http.StatusTooManyRequests
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Messages(t *testing.T) {
+	assert.Equal(t, "not found (404): resource does not exist - missing", (&APIError{StatusCode: 404, Body: "missing"}).Error())
+	assert.Equal(t, "forbidden (403): insufficient permissions - denied", (&APIError{StatusCode: 403, Body: "denied"}).Error())
+	assert.Equal(t, "API error: HTTP 500 - boom", (&APIError{StatusCode: 500, Body: "boom"}).Error())
+}
+
+func TestAPIError_Predicates(t *testing.T) {
+	notFound := fmt.Errorf("failed to get scan: %w", &APIError{StatusCode: http.StatusNotFound})
+	assert.True(t, IsNotFound(notFound))
+	assert.ErrorIs(t, notFound, ErrNotFound)
+	assert.False(t, IsAuthError(notFound))
+
+	assert.True(t, IsAuthError(&APIError{StatusCode: http.StatusUnauthorized}))
+	assert.True(t, IsAuthError(&APIError{StatusCode: http.StatusForbidden}))
+	assert.True(t, IsAuthError(fmt.Errorf("%w: HTTP 401", ErrAuthFailed)))
+	assert.True(t, IsAuthError(fmt.Errorf("%w - run 'hawkop init'", ErrNoCredentials)))
+
+	assert.True(t, IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, IsRateLimited(&APIError{StatusCode: http.StatusInternalServerError}))
+	assert.False(t, IsRateLimited(errors.New("rate limited")))
+}
+
+// A 429 that persists after the retry is returned as an APIError rather than a
+// response the caller would try to decode
+func TestMakeRequest_PersistentRateLimit(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	_, err := client.ListOrganizationTeams("test-org-id")
+	require.Error(t, err)
+	assert.True(t, IsRateLimited(err))
+	assert.Equal(t, 2, requests)
+}
+
+// A 202 is returned as a PendingError carrying where to poll, rather than a
+// response the caller would try to decode as the finished result
+func TestMakeRequest_Accepted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/api/v1/jobs/job-1")
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"pollToken": "poll-1", "status": "RUNNING"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	_, err := client.ListOrganizationTeams("test-org-id")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrPending)
+	assert.False(t, IsAuthError(err))
+
+	pending, ok := AsPending(err)
+	require.True(t, ok)
+	assert.Equal(t, server.URL+"/api/v1/jobs/job-1", pending.Location)
+	assert.Equal(t, "poll-1", pending.PollToken)
+	assert.Equal(t, 5*time.Second, pending.RetryAfter)
+	assert.Contains(t, pending.Body, `"RUNNING"`)
+	assert.Contains(t, err.Error(), "poll "+server.URL+"/api/v1/jobs/job-1")
+
+	_, ok = AsPending(&APIError{StatusCode: http.StatusInternalServerError})
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Fingerprint identifies a finding across scans: two scans report the same finding
+// when their fingerprints are equal, even if the API gave each a different message
+// ID or triage status. It is a hex SHA-256 of the plugin ID, the URI as normalized
+// by NormalizeFindingURI, the parameter, and the upper-cased request method, so
+// findings of one plugin that differ only by URI, parameter, or method stay apart.
+func (f ScanAlertFinding) Fingerprint() string {
+	key := strings.Join([]string{
+		strings.TrimSpace(f.PluginID),
+		NormalizeFindingURI(f.URI),
+		strings.TrimSpace(f.Param),
+		strings.ToUpper(strings.TrimSpace(f.RequestMethod)),
+	}, "\n")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeFindingURI reduces a finding URI to the parts that identify the finding,
+// so incidental differences between scans don't change its fingerprint:
+//
+//   - the scheme and host are lower-cased, and a default port (80 for http, 443
+//     for https) is dropped
+//   - a trailing slash is dropped from the path, except for the root path "/"
+//   - query parameter values are dropped and the names sorted, since scanners
+//     inject different payloads on each run; repeated names are kept once
+//   - the fragment is dropped
+//
+// Path case and percent-encoding are kept as they are. A URI that doesn't parse is
+// only trimmed of surrounding space.
+func NormalizeFindingURI(uri string) string {
+	uri = strings.TrimSpace(uri)
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if (u.Scheme == "http" && strings.HasSuffix(host, ":80")) || (u.Scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	u.Host = host
+
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.RawPath = strings.TrimSuffix(u.RawPath, "/")
+	}
+
+	names := []string{}
+	for name := range u.Query() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	u.RawQuery = strings.Join(names, "&")
+	u.ForceQuery = false
+	u.Fragment, u.RawFragment = "", ""
+
+	return u.String()
+}
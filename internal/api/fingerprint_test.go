@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FingerprintTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FingerprintTestSuite) TestFingerprint_SameFinding() {
+	base := ScanAlertFinding{PluginID: "40012", URI: "https://Example.com:443/api/search/?q=<script>", Param: "q", RequestMethod: "GET", Status: "UNKNOWN", MsgID: "msg-1"}
+	again := ScanAlertFinding{PluginID: "40012", URI: "https://example.com/api/search?q=other#top", Param: "q", RequestMethod: "get", Status: "RISK_ACCEPTED", MsgID: "msg-9"}
+
+	assert.Equal(suite.T(), base.Fingerprint(), again.Fingerprint())
+	assert.Len(suite.T(), base.Fingerprint(), 64)
+}
+
+func (suite *FingerprintTestSuite) TestFingerprint_DifferentFindings() {
+	base := ScanAlertFinding{PluginID: "40012", URI: "/api/search?q=1", Param: "q", RequestMethod: "GET"}
+	variants := map[string]ScanAlertFinding{
+		"plugin": {PluginID: "40014", URI: base.URI, Param: base.Param, RequestMethod: base.RequestMethod},
+		"path":   {PluginID: base.PluginID, URI: "/api/users?q=1", Param: base.Param, RequestMethod: base.RequestMethod},
+		"query":  {PluginID: base.PluginID, URI: "/api/search?term=1", Param: base.Param, RequestMethod: base.RequestMethod},
+		"param":  {PluginID: base.PluginID, URI: base.URI, Param: "page", RequestMethod: base.RequestMethod},
+		"method": {PluginID: base.PluginID, URI: base.URI, Param: base.Param, RequestMethod: "POST"},
+	}
+
+	for name, variant := range variants {
+		assert.NotEqual(suite.T(), base.Fingerprint(), variant.Fingerprint(), name)
+	}
+}
+
+func (suite *FingerprintTestSuite) TestNormalizeFindingURI() {
+	tests := map[string]string{
+		"/api/v1/users/":              "/api/v1/users",
+		"/":                           "/",
+		" /api ":                      "/api",
+		"/api?b=2&a=1&b=3":            "/api?a&b",
+		"/api?":                       "/api",
+		"/api#section":                "/api",
+		"HTTP://Example.COM:80/Path":  "http://example.com/Path",
+		"https://example.com:8443/a/": "https://example.com:8443/a",
+		"http://example.com:443/":     "http://example.com:443/",
+		"/files/a%2Fb/":               "/files/a%2Fb",
+		"%zz":                         "%zz",
+	}
+
+	for input, want := range tests {
+		assert.Equal(suite.T(), want, NormalizeFindingURI(input), "input %q", input)
+	}
+}
+
+func TestFingerprintTestSuite(t *testing.T) {
+	suite.Run(t, new(FingerprintTestSuite))
+}
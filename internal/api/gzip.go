@@ -0,0 +1,58 @@
+package api
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent with API requests so large scan payloads transfer
+// compressed. Setting Accept-Encoding ourselves turns off the transport's
+// transparent decompression, so every layer that reads a response body (the
+// client, and the snapshot, capture, and cache transports) calls
+// decompressResponse first; it is a no-op once a layer below has done so.
+const acceptEncoding = "gzip"
+
+// gzipBody reads a gzip-compressed response body, closing both the decompressor
+// and the underlying body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close implements io.Closer
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressResponse replaces a gzip-encoded response body with its decompressed
+// form and drops the Content-Encoding and Content-Length headers that described
+// the compressed bytes. Responses in any other encoding are left as they are.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	switch {
+	case errors.Is(err, io.EOF):
+		// An empty body, as on a HEAD request or 304, has nothing to decompress
+		resp.Body.Close()
+		resp.Body = http.NoBody
+	case err != nil:
+		resp.Body.Close()
+		return fmt.Errorf("failed to decompress gzip response: %w", err)
+	default:
+		resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
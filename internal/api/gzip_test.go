@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipServer starts a server that answers every request with body gzip-compressed,
+// recording the Accept-Encoding each request carried
+func gzipServer(t *testing.T, body string) (*httptest.Server, *string) {
+	t.Helper()
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	acceptEncoding := new(string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server, acceptEncoding
+}
+
+// Test that gzip responses are requested and decoded
+func TestGzipResponse_Decoded(t *testing.T) {
+	server, acceptEncoding := gzipServer(t, `{"user":{"stackhawkId":"gzip-user"}}`)
+	client := newRetryTestClient(server.URL)
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "gzip-user", user.StackhawkId)
+	assert.Equal(t, "gzip", *acceptEncoding)
+}
+
+// Test that a body that claims gzip but isn't fails with a clear error
+func TestGzipResponse_Corrupt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte(`{"user":{}}`))
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(server.URL).GetUser()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decompress gzip response")
+}
+
+// Test that snapshots are recorded decompressed, so they replay without an encoding
+func TestGzipResponse_SnapshotRecordsPlainBody(t *testing.T) {
+	server, _ := gzipServer(t, `{"user":{"stackhawkId":"gzip-user"}}`)
+	client := newRetryTestClient(server.URL)
+	dir := t.TempDir()
+	client.UseSnapshot(dir, true)
+
+	_, err := client.GetUser()
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user":{"stackhawkId":"gzip-user"}}`, string(data))
+}
+
+func TestDecompressResponse_Passthrough(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Content-Encoding": []string{"br"}}, Body: http.NoBody}
+	require.NoError(t, decompressResponse(resp))
+	assert.Equal(t, "br", resp.Header.Get("Content-Encoding"), "other encodings are left alone")
+
+	empty := &http.Response{Header: http.Header{"Content-Encoding": []string{"gzip"}}, Body: http.NoBody}
+	require.NoError(t, decompressResponse(empty))
+	assert.Empty(t, empty.Header.Get("Content-Encoding"))
+	assert.Equal(t, http.NoBody, empty.Body)
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// CachedResponse is a response body stored with the validators needed to ask the
+// API whether it has changed
+type CachedResponse struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	ContentType  string `json:"contentType,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// CacheStore holds cached responses by key. Implementations must be safe for
+// concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Put(key string, entry *CachedResponse) error
+}
+
+// MemoryCacheStore keeps cached responses in memory for the life of the process
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]*CachedResponse
+}
+
+// NewMemoryCacheStore creates an empty in-memory cache store
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]*CachedResponse)}
+}
+
+// Get implements CacheStore
+func (s *MemoryCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+// Put implements CacheStore
+func (s *MemoryCacheStore) Put(key string, entry *CachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = entry
+	return nil
+}
+
+// DiskCacheStore keeps cached responses as JSON files in Dir, readable only by the
+// current user, so they survive between commands
+type DiskCacheStore struct {
+	Dir string
+}
+
+// NewDiskCacheStore creates a cache store writing to dir
+func NewDiskCacheStore(dir string) *DiskCacheStore {
+	return &DiskCacheStore{Dir: dir}
+}
+
+// path returns the file for key; keys are hashed since URLs aren't valid file names
+func (s *DiskCacheStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements CacheStore. Unreadable or corrupt entries are treated as misses.
+func (s *DiskCacheStore) Get(key string) (*CachedResponse, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry CachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != key {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put implements CacheStore
+func (s *DiskCacheStore) Put(key string, entry *CachedResponse) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	// Write then rename so a concurrent reader never sees a partial entry
+	tmp, err := os.CreateTemp(s.Dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// CacheTransport makes GET requests conditional. Responses carrying an ETag or
+// Last-Modified header are stored, later requests for the same URL send
+// If-None-Match/If-Modified-Since, and a 304 Not Modified is answered from the store
+// as a 200 with the cached body.
+type CacheTransport struct {
+	Store CacheStore
+	Next  http.RoundTripper
+}
+
+// NewCacheTransport creates a cache transport in front of next
+func NewCacheTransport(store CacheStore, next http.RoundTripper) *CacheTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &CacheTransport{Store: store, Next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.Next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, ok := t.Store.Get(key)
+	if ok {
+		// RoundTrippers must not modify the caller's request
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// Bodies are stored decompressed
+	if err := decompressResponse(resp); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		resp.Body.Close()
+		return cachedHTTPResponse(req, resp, cached), nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A cache that can't be written only costs the next request its shortcut
+	_ = t.Store.Put(key, &CachedResponse{
+		URL:          key,
+		ETag:         etag,
+		LastModified: lastModified,
+		ContentType:  resp.Header.Get("Content-Type"),
+		Body:         body,
+	})
+	return resp, nil
+}
+
+// cachedHTTPResponse turns a 304 into a 200 carrying the cached body
+func cachedHTTPResponse(req *http.Request, notModified *http.Response, cached *CachedResponse) *http.Response {
+	header := notModified.Header.Clone()
+	header.Del("Content-Encoding")
+	if cached.ContentType != "" {
+		header.Set("Content-Type", cached.ContentType)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(cached.Body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
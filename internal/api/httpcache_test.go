@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cachedTeamsBody = `{"teams": [{"id": "team-1", "name": "Platform"}]}`
+
+// etagServer serves a teams listing with an ETag, answering 304 when the client
+// already has it. It counts full responses and 304s.
+func etagServer(t *testing.T, full, notModified *int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"teams-v1"` {
+			*notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		*full++
+		w.Header().Set("ETag", `"teams-v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(cachedTeamsBody))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// cacheTestClient creates a client for server that uses store
+func cacheTestClient(server *httptest.Server, store CacheStore) *Client {
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	client.UseCache(store)
+	return client
+}
+
+func TestCacheTransport_NotModifiedUsesCachedBody(t *testing.T) {
+	var full, notModified int
+	server := etagServer(t, &full, &notModified)
+	store := NewMemoryCacheStore()
+
+	// Separate clients, as with separate commands, so the list cache doesn't answer
+	teams, err := cacheTestClient(server, store).ListOrganizationTeams("test-org-id")
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+
+	teams, err = cacheTestClient(server, store).ListOrganizationTeams("test-org-id")
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "Platform", teams[0].Name)
+
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 1, notModified)
+}
+
+func TestCacheTransport_LastModified(t *testing.T) {
+	const lastModified = "Wed, 14 Oct 2026 10:00:00 GMT"
+	var conditional string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = r.Header.Get("If-Modified-Since")
+		if conditional == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(cachedTeamsBody))
+	}))
+	defer server.Close()
+
+	transport := NewCacheTransport(NewMemoryCacheStore(), nil)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, server.URL+"/api/v1/org/test-org-id/teams", nil)
+		req.RequestURI = ""
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+		assert.Empty(t, req.Header.Get("If-Modified-Since"), "the caller's request is left unchanged")
+	}
+	assert.Equal(t, lastModified, conditional)
+}
+
+func TestCacheTransport_SkipsUncacheableResponses(t *testing.T) {
+	var conditional []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		_, _ = w.Write([]byte(cachedTeamsBody)) // no validators
+	}))
+	defer server.Close()
+
+	store := NewMemoryCacheStore()
+	for i := 0; i < 2; i++ {
+		_, err := cacheTestClient(server, store).ListOrganizationTeams("test-org-id")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"", ""}, conditional)
+	assert.Empty(t, store.entries)
+}
+
+func TestDiskCacheStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	store := NewDiskCacheStore(dir)
+
+	_, ok := store.Get("https://api.example.com/teams")
+	assert.False(t, ok)
+
+	entry := &CachedResponse{URL: "https://api.example.com/teams", ETag: `"v1"`, Body: []byte(cachedTeamsBody)}
+	require.NoError(t, store.Put(entry.URL, entry))
+
+	got, ok := store.Get(entry.URL)
+	require.True(t, ok)
+	assert.Equal(t, entry, got)
+
+	info, err := os.Stat(store.path(entry.URL))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Corrupt entries are misses rather than errors
+	require.NoError(t, os.WriteFile(store.path(entry.URL), []byte("{"), 0600))
+	_, ok = store.Get(entry.URL)
+	assert.False(t, ok)
+}
+
+func TestDiskCacheStore_SharedAcrossClients(t *testing.T) {
+	var full, notModified int
+	server := etagServer(t, &full, &notModified)
+	dir := t.TempDir()
+
+	for i := 0; i < 3; i++ {
+		teams, err := cacheTestClient(server, NewDiskCacheStore(dir)).ListOrganizationTeams("test-org-id")
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+	}
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 2, notModified)
+}
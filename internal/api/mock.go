@@ -72,6 +72,30 @@ func (m *MockClient) ListOrganizationApplications(orgID string) ([]AppApplicatio
 	return args.Get(0).([]AppApplication), args.Error(1)
 }
 
+// CreateApplication mocks the CreateApplication method
+func (m *MockClient) CreateApplication(orgID string, app AppApplication) (*AppApplication, error) {
+	args := m.Called(orgID, app)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AppApplication), args.Error(1)
+}
+
+// DeleteApplication mocks the DeleteApplication method
+func (m *MockClient) DeleteApplication(orgID, appID string) error {
+	args := m.Called(orgID, appID)
+	return args.Error(0)
+}
+
+// GetScan mocks the GetScan method
+func (m *MockClient) GetScan(scanID string) (*ApplicationScanResult, error) {
+	args := m.Called(scanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ApplicationScanResult), args.Error(1)
+}
+
 // ListOrganizationScans mocks the ListOrganizationScans method
 func (m *MockClient) ListOrganizationScans(orgID string) ([]ApplicationScanResult, error) {
 	args := m.Called(orgID)
@@ -90,6 +114,77 @@ func (m *MockClient) GetScanAlerts(scanID string) ([]ScanAlert, error) {
 	return args.Get(0).([]ScanAlert), args.Error(1)
 }
 
+// CollectOrgAlerts mocks the CollectOrgAlerts method
+func (m *MockClient) CollectOrgAlerts(orgID string, opts *CollectAlertsOptions) (map[string]OrgScanAlerts, error) {
+	args := m.Called(orgID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]OrgScanAlerts), args.Error(1)
+}
+
+// GetScanWithAlerts mocks the GetScanWithAlerts method
+func (m *MockClient) GetScanWithAlerts(scanID string) (*Scan, []ScanAlert, error) {
+	args := m.Called(scanID)
+	var scan *Scan
+	if args.Get(0) != nil {
+		scan = args.Get(0).(*Scan)
+	}
+	var alerts []ScanAlert
+	if args.Get(1) != nil {
+		alerts = args.Get(1).([]ScanAlert)
+	}
+	return scan, alerts, args.Error(2)
+}
+
+// GetAlertFindings mocks the GetAlertFindings method
+func (m *MockClient) GetAlertFindings(scanID, pluginID string) (*ScanAlertFindingsResponse, error) {
+	args := m.Called(scanID, pluginID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ScanAlertFindingsResponse), args.Error(1)
+}
+
+// ListPolicies mocks the ListPolicies method
+func (m *MockClient) ListPolicies(orgID string) ([]Policy, error) {
+	args := m.Called(orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Policy), args.Error(1)
+}
+
+// CreateTeam mocks the CreateTeam method
+func (m *MockClient) CreateTeam(orgID, name string) (*Team, error) {
+	args := m.Called(orgID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Team), args.Error(1)
+}
+
+// GetTeam mocks the GetTeam method
+func (m *MockClient) GetTeam(orgID, teamID string) (*Team, error) {
+	args := m.Called(orgID, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Team), args.Error(1)
+}
+
+// AddTeamMember mocks the AddTeamMember method
+func (m *MockClient) AddTeamMember(orgID, teamID, userID string) error {
+	args := m.Called(orgID, teamID, userID)
+	return args.Error(0)
+}
+
+// RemoveTeamMember mocks the RemoveTeamMember method
+func (m *MockClient) RemoveTeamMember(orgID, teamID, userID string) error {
+	args := m.Called(orgID, teamID, userID)
+	return args.Error(0)
+}
+
 // MockAPIServer provides a test HTTP server with mock responses
 type MockAPIServer struct {
 	Server *httptest.Server
@@ -130,6 +225,12 @@ func mockAPIHandler(w http.ResponseWriter, r *http.Request) {
 		handleMockApps(w, r)
 	case "/api/v1/scan/test-org-id":
 		handleMockScans(w, r)
+	case "/api/v1/policy/test-org-id/list":
+		handleMockPolicies(w, r)
+	case "/api/v1/scan/scan-1/alerts":
+		handleMockScanAlerts(w, r)
+	case "/api/v1/scan/scan-1/alert/40012":
+		handleMockAlertFindings(w, r)
 	case "/api/v1/auth/login":
 		handleMockAuth(w, r)
 	default:
@@ -198,6 +299,12 @@ func handleMockMembers(w http.ResponseWriter, r *http.Request) {
 						{Role: "ADMIN"},
 					},
 				},
+				Features: []Feature{{Name: "SSO", Enabled: true}, {Name: "BETA_REPORTS", Enabled: false}},
+				Metadata: []Metadata{{Name: "department", Value: "platform"}},
+				Achievements: []Achievement{
+					{Achievement: "FIRST_SCAN", Timestamp: "1700000000000"},
+					{Achievement: "ACCOUNT_CREATED", Timestamp: "1690000000000"},
+				},
 			},
 			{
 				StackhawkId: "user-2",
@@ -244,12 +351,69 @@ func handleMockApps(w http.ResponseWriter, r *http.Request) {
 				Name:              "Mock Application",
 				ApplicationStatus: "ACTIVE",
 				ApplicationType:   "STANDARD",
+				Environments: []AppEnvironment{
+					{EnvID: "env-1", Env: "development", PolicyName: "DEFAULT"},
+					{EnvID: "env-2", Env: "production"},
+				},
 			},
 		},
 	}
 	_ = json.NewEncoder(w).Encode(apps)
 }
 
+func handleMockPolicies(w http.ResponseWriter, r *http.Request) {
+	policies := PoliciesResponse{
+		ScanPolicies: []Policy{
+			{
+				ID:          "policy-1",
+				Name:        "DEFAULT",
+				DisplayName: "Default",
+				Description: "Standard web application scan",
+				Plugins: []PolicyPlugin{
+					{PluginID: "40012", Name: "Cross Site Scripting (Reflected)", AlertThreshold: "MEDIUM", AttackStrength: "MEDIUM"},
+					{PluginID: "40018", Name: "SQL Injection", AlertThreshold: "LOW", AttackStrength: "HIGH"},
+				},
+			},
+			{
+				ID:             "policy-2",
+				Name:           "api-quick",
+				OrganizationID: "test-org-id",
+				Plugins: []PolicyPlugin{
+					{PluginID: "40018", Name: "SQL Injection"},
+				},
+			},
+		},
+	}
+	_ = json.NewEncoder(w).Encode(policies)
+}
+
+func handleMockScanAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts := ScanAlertsResponse{}
+	alerts.ApplicationScanResults = append(alerts.ApplicationScanResults, ScanAlertsResult{
+		Scan: Scan{ID: "scan-1", ApplicationID: "app-1", ApplicationName: "Mock App", Env: "production"},
+		ApplicationAlerts: []ScanAlert{
+			{PluginID: "10020", Name: "Missing Anti-clickjacking Header", Severity: "Medium", URICount: 5, CWEID: "1021"},
+			{PluginID: "40012", Name: "Cross Site Scripting (Reflected)", Severity: "High", URICount: 3, CWEID: "79"},
+			{PluginID: "10038", Name: "Content Security Policy Header Not Set", Severity: "Medium", URICount: 8, CWEID: "693"},
+			{PluginID: "10021", Name: "X-Content-Type-Options Header Missing", Severity: "Low", URICount: 12, CWEID: "693"},
+		},
+	})
+	_ = json.NewEncoder(w).Encode(alerts)
+}
+
+func handleMockAlertFindings(w http.ResponseWriter, r *http.Request) {
+	findings := ScanAlertFindingsResponse{
+		Alert: ScanAlert{PluginID: "40012", Name: "Cross Site Scripting (Reflected)", Severity: "High", URICount: 3},
+		ApplicationScanAlertUris: []ScanAlertFinding{
+			{PluginID: "40012", URI: "/api/v1/search", RequestMethod: "GET", Status: "UNKNOWN", MsgID: "msg-1"},
+			{PluginID: "40012", URI: "/api/v2/users", RequestMethod: "POST", Status: "RISK_ACCEPTED", MsgID: "msg-2"},
+			{PluginID: "40012", URI: "/login", RequestMethod: "POST", Status: "UNKNOWN", MsgID: "msg-3"},
+		},
+		TotalCount: "3",
+	}
+	_ = json.NewEncoder(w).Encode(findings)
+}
+
 func handleMockScans(w http.ResponseWriter, r *http.Request) {
 	scans := OrganizationScansResponse{
 		ApplicationScanResults: []ApplicationScanResult{
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// PageFetcher fetches the page of a list that token names, "" naming the first
+// page. It returns the page's items and the token of the page after it, or "" on
+// the last page.
+type PageFetcher[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// Pager steps through the pages of a list that is paginated by nextPageToken, one
+// request per call to Next, so callers can stream pages or stop early.
+type Pager[T any] struct {
+	fetch    PageFetcher[T]
+	token    string
+	pages    int
+	maxPages int
+	done     bool
+	seen     map[string]bool
+}
+
+// NewPager returns a Pager that fetches pages with fetch, starting from the first.
+// It follows pages until one has no next token or no items.
+func NewPager[T any](fetch PageFetcher[T]) *Pager[T] {
+	return &Pager[T]{fetch: fetch, seen: make(map[string]bool)}
+}
+
+// Next fetches the next page. It returns false, with no error, once every page has
+// been delivered. A repeated page token, or more pages than the client's MaxPages,
+// returns an error wrapping ErrPaginationLoop. After any other error, such as a
+// failed request or ctx being done, calling Next again retries the same page. ctx
+// is checked before each request; requests made through a Client are bounded by
+// the client's own context (see SetContext).
+func (p *Pager[T]) Next(ctx context.Context) ([]T, bool, error) {
+	if p.done {
+		return nil, false, nil
+	}
+	if p.seen[p.token] {
+		return nil, false, fmt.Errorf("%w: nextPageToken %q repeated on page %d", ErrPaginationLoop, p.token, p.pages)
+	}
+	if p.maxPages > 0 && p.pages >= p.maxPages {
+		return nil, false, fmt.Errorf("%w: stopped after %d pages", ErrPaginationLoop, p.maxPages)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	items, next, err := p.fetch(ctx, p.token)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.pages++
+	if p.token != "" {
+		p.seen[p.token] = true
+	}
+	if next == "" || len(items) == 0 {
+		p.done = true
+	}
+	p.token = next
+	return items, true, nil
+}
+
+// Pages returns how many pages the Pager has delivered
+func (p *Pager[T]) Pages() int {
+	return p.pages
+}
+
+// All fetches the remaining pages and returns their items in order
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for {
+		items, ok, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return all, nil
+		}
+		all = append(all, items...)
+	}
+}
+
+// newClientPager returns a Pager bounded by the client's MaxPages that resumes
+// after delivered pages at token
+func newClientPager[T any](c *Client, fetch PageFetcher[T], token string, delivered int) *Pager[T] {
+	pager := NewPager(fetch)
+	pager.token, pager.pages, pager.maxPages = token, delivered, c.MaxPages
+	return pager
+}
+
+// ScansPager returns a Pager over the organization's scans, fetched with opts'
+// page size and sort order, starting from opts.PageToken if set
+func (c *Client) ScansPager(orgID string, opts *PaginationOptions) *Pager[ApplicationScanResult] {
+	pageOpts := PaginationOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return newClientPager(c, c.scansPageFetcher(orgID, pageOpts), pageOpts.PageToken, 0)
+}
+
+// scansPageFetcher fetches pages of the organization's scans with opts, by token
+func (c *Client) scansPageFetcher(orgID string, opts PaginationOptions) PageFetcher[ApplicationScanResult] {
+	return func(ctx context.Context, token string) ([]ApplicationScanResult, string, error) {
+		pageOpts := opts
+		pageOpts.PageToken, pageOpts.Page = token, ""
+		scansResp, err := c.listOrganizationScansPage(orgID, &pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		return scansResp.ApplicationScanResults, scansResp.NextPageToken, nil
+	}
+}
+
+// AlertFindingsPager returns a Pager over the URIs where a scan found an alert
+func (c *Client) AlertFindingsPager(scanID, pluginID string) *Pager[ScanAlertFinding] {
+	return newClientPager(c, c.alertFindingsPageFetcher(scanID, pluginID, nil), "", 0)
+}
+
+// alertFindingsPageFetcher fetches pages of an alert's findings by token. When
+// first is not nil, it receives the first page's response, which carries the
+// alert's details.
+func (c *Client) alertFindingsPageFetcher(scanID, pluginID string, first **ScanAlertFindingsResponse) PageFetcher[ScanAlertFinding] {
+	endpoint := fmt.Sprintf("/api/v1/scan/%s/alert/%s", url.PathEscape(scanID), url.PathEscape(pluginID))
+
+	return func(ctx context.Context, token string) ([]ScanAlertFinding, string, error) {
+		resp, err := c.GetWithParams(endpoint, c.BuildStandardParams(map[string]string{"pageToken": token}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get alert findings: %w", err)
+		}
+		defer resp.Body.Close()
+
+		var findingsResp ScanAlertFindingsResponse
+		if err := c.decodeResponse(resp, &findingsResp); err != nil {
+			return nil, "", fmt.Errorf("failed to parse alert findings response: %w", err)
+		}
+		if first != nil && *first == nil {
+			*first = &findingsResp
+		}
+		return findingsResp.ApplicationScanAlertUris, findingsResp.NextPageToken, nil
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenPage is one page served by tokenPages
+type tokenPage struct {
+	items []int
+	next  string
+}
+
+// tokenPages serves pages keyed by the token that requests them, recording the
+// tokens requested
+func tokenPages(pages map[string]tokenPage, requested *[]string) PageFetcher[int] {
+	return func(ctx context.Context, token string) ([]int, string, error) {
+		*requested = append(*requested, token)
+		page, ok := pages[token]
+		if !ok {
+			return nil, "", errors.New("unknown token " + token)
+		}
+		return page.items, page.next, nil
+	}
+}
+
+func TestPager_FollowsTokens(t *testing.T) {
+	var requested []string
+	pager := NewPager(tokenPages(map[string]tokenPage{
+		"":  {items: []int{1, 2}, next: "b"},
+		"b": {items: []int{3}, next: "c"},
+		"c": {items: []int{4, 5}},
+	}, &requested))
+
+	var pages [][]int
+	for {
+		items, ok, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		pages = append(pages, items)
+	}
+	assert.Equal(t, [][]int{{1, 2}, {3}, {4, 5}}, pages)
+	assert.Equal(t, []string{"", "b", "c"}, requested)
+	assert.Equal(t, 3, pager.Pages())
+
+	// Finished pagers make no more requests
+	_, ok, err := pager.Next(context.Background())
+	assert.False(t, ok)
+	assert.NoError(t, err)
+	assert.Len(t, requested, 3)
+}
+
+func TestPager_StopsOnEmptyPage(t *testing.T) {
+	var requested []string
+	pager := NewPager(tokenPages(map[string]tokenPage{
+		"":  {items: []int{1}, next: "b"},
+		"b": {next: "c"},
+	}, &requested))
+
+	all, err := pager.All(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, all)
+	assert.Equal(t, []string{"", "b"}, requested)
+}
+
+func TestPager_RepeatedToken(t *testing.T) {
+	var requested []string
+	pager := NewPager(tokenPages(map[string]tokenPage{
+		"":  {items: []int{1}, next: "b"},
+		"b": {items: []int{2}, next: "b"},
+	}, &requested))
+
+	_, err := pager.All(context.Background())
+	assert.ErrorIs(t, err, ErrPaginationLoop)
+	assert.Contains(t, err.Error(), `"b" repeated on page 2`)
+	assert.Equal(t, []string{"", "b"}, requested)
+}
+
+func TestPager_ErrorsRetryTheSamePage(t *testing.T) {
+	failures := 1
+	var requested []string
+	pager := NewPager(func(ctx context.Context, token string) ([]int, string, error) {
+		requested = append(requested, token)
+		if token == "b" && failures > 0 {
+			failures--
+			return nil, "", errors.New("boom")
+		}
+		if token == "" {
+			return []int{1}, "b", nil
+		}
+		return []int{2}, "", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, _, err := pager.Next(ctx)
+	require.NoError(t, err)
+
+	cancel()
+	_, ok, err := pager.Next(ctx)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, _, err = pager.Next(context.Background())
+	assert.EqualError(t, err, "boom")
+
+	items, ok, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2}, items)
+	assert.Equal(t, []string{"", "b", "b"}, requested, "the canceled call makes no request")
+}
+
+func TestScansPager_Pages(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("pageToken")
+		tokens = append(tokens, token)
+		assert.Equal(t, "2", r.URL.Query().Get("pageSize"))
+
+		resp := OrganizationScansResponse{}
+		switch token {
+		case "":
+			resp.ApplicationScanResults = []ApplicationScanResult{{Scan: Scan{ID: "scan-1"}}, {Scan: Scan{ID: "scan-2"}}}
+			resp.NextPageToken = "page-2"
+		case "page-2":
+			resp.ApplicationScanResults = []ApplicationScanResult{{Scan: Scan{ID: "scan-3"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+
+	opts := &PaginationOptions{PageSize: 2}
+	pager := client.ScansPager("test-org-id", opts)
+
+	first, ok, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, first, 2)
+	assert.Equal(t, []string{""}, tokens, "pages are fetched one Next at a time")
+
+	rest, err := pager.All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "scan-3", rest[0].Scan.ID)
+	assert.Equal(t, []string{"", "page-2"}, tokens)
+	assert.Empty(t, opts.PageToken, "the caller's options are left alone")
+
+	client.ClearListCache()
+	client.MaxPages = 1
+	_, err = client.ScansPager("test-org-id", opts).All(context.Background())
+	assert.ErrorIs(t, err, ErrPaginationLoop)
+}
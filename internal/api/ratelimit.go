@@ -0,0 +1,119 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate limit response headers
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimitState is the API's rate limit as last reported in X-RateLimit-* headers
+type RateLimitState struct {
+	// Limit is the number of requests allowed per window, or 0 if not reported
+	Limit int
+	// Remaining is how many requests are left in the current window. The client
+	// counts down from the reported value as it sends requests.
+	Remaining int
+	// Reset is when the current window ends and Remaining returns to Limit
+	Reset time.Time
+}
+
+// String summarizes the state for debug output
+func (s RateLimitState) String() string {
+	limit := ""
+	if s.Limit > 0 {
+		limit = "/" + strconv.Itoa(s.Limit)
+	}
+	return fmt.Sprintf("%d%s remaining, resets in %s", s.Remaining, limit, time.Until(s.Reset).Round(time.Second))
+}
+
+// parseRateLimit reads the rate limit headers of a response received at now. The
+// reset may be epoch seconds, epoch milliseconds, or seconds from now, as APIs
+// differ; it returns false unless both the remaining count and reset are present.
+func parseRateLimit(header http.Header, now time.Time) (RateLimitState, bool) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get(HeaderRateLimitRemaining)))
+	if err != nil || remaining < 0 {
+		return RateLimitState{}, false
+	}
+	reset, err := strconv.ParseInt(strings.TrimSpace(header.Get(HeaderRateLimitReset)), 10, 64)
+	if err != nil || reset < 0 {
+		return RateLimitState{}, false
+	}
+
+	state := RateLimitState{Remaining: remaining}
+	switch {
+	case reset >= 1e12:
+		state.Reset = time.UnixMilli(reset)
+	case reset >= 1e9:
+		state.Reset = time.Unix(reset, 0)
+	default:
+		state.Reset = now.Add(time.Duration(reset) * time.Second)
+	}
+	if limit, err := strconv.Atoi(strings.TrimSpace(header.Get(HeaderRateLimitLimit))); err == nil && limit > 0 {
+		state.Limit = limit
+	}
+	return state, true
+}
+
+// RateLimit returns the rate limit the API last reported, with Remaining reduced by
+// the requests sent since. It returns false before any response has carried the
+// headers and once the reported window has ended.
+func (c *Client) RateLimit() (RateLimitState, bool) {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.currentRateLimitLocked(time.Now())
+}
+
+// currentRateLimitLocked returns the reported rate limit while its window lasts.
+// The caller holds rateMu.
+func (c *Client) currentRateLimitLocked(now time.Time) (RateLimitState, bool) {
+	if c.rateLimit == nil || !c.rateLimit.Reset.After(now) {
+		return RateLimitState{}, false
+	}
+	return *c.rateLimit, true
+}
+
+// recordRateLimit keeps the rate limit reported by resp, if any
+func (c *Client) recordRateLimit(req *http.Request, resp *http.Response) {
+	state, ok := parseRateLimit(resp.Header, time.Now())
+	if !ok {
+		c.debugf("%s %s → %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+		return
+	}
+
+	c.rateMu.Lock()
+	c.rateLimit = &state
+	c.rateMu.Unlock()
+	c.debugf("%s %s → %d (rate limit: %s)", req.Method, req.URL.Redacted(), resp.StatusCode, state)
+}
+
+// reportedPaceLocked spaces requests by the reported rate limit: the requests left
+// are spread over the rest of the window, and once none are left the next request
+// waits for the reset. It reserves one of the remaining requests and returns false
+// when no limit is known. The caller holds rateMu.
+func (c *Client) reportedPaceLocked(now time.Time) (interval time.Duration, waitUntil time.Time, ok bool) {
+	state, ok := c.currentRateLimitLocked(now)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	if state.Remaining <= 0 {
+		return 0, state.Reset, true
+	}
+	c.rateLimit.Remaining--
+	return state.Reset.Sub(now) / time.Duration(state.Remaining), time.Time{}, true
+}
+
+// debugf writes a line of debug output when Debug is set
+func (c *Client) debugf(format string, args ...interface{}) {
+	if c.Debug != nil {
+		fmt.Fprintf(c.Debug, "debug: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2025, 8, 30, 12, 0, 0, 0, time.UTC)
+	headers := func(remaining, reset, limit string) http.Header {
+		h := http.Header{}
+		for name, value := range map[string]string{HeaderRateLimitRemaining: remaining, HeaderRateLimitReset: reset, HeaderRateLimitLimit: limit} {
+			if value != "" {
+				h.Set(name, value)
+			}
+		}
+		return h
+	}
+
+	state, ok := parseRateLimit(headers("42", "30", "360"), now)
+	require.True(t, ok)
+	assert.Equal(t, RateLimitState{Limit: 360, Remaining: 42, Reset: now.Add(30 * time.Second)}, state)
+
+	state, ok = parseRateLimit(headers("5", strconv.FormatInt(now.Add(time.Minute).Unix(), 10), ""), now)
+	require.True(t, ok)
+	assert.True(t, state.Reset.Equal(now.Add(time.Minute)), "epoch seconds")
+	assert.Zero(t, state.Limit)
+
+	state, ok = parseRateLimit(headers("5", strconv.FormatInt(now.Add(1500*time.Millisecond).UnixMilli(), 10), ""), now)
+	require.True(t, ok)
+	assert.True(t, state.Reset.Equal(now.Add(1500*time.Millisecond)), "epoch milliseconds")
+
+	for _, h := range []http.Header{headers("", "30", ""), headers("5", "", ""), headers("many", "30", ""), headers("-1", "30", ""), headers("5", "soon", "")} {
+		_, ok := parseRateLimit(h, now)
+		assert.False(t, ok, "%v", h)
+	}
+}
+
+// rateLimitedClient returns a client for a server that reports remaining requests
+// and a reset time from the given function before each response
+func rateLimitedClient(t *testing.T, report func(w http.ResponseWriter)) (*Client, *[]time.Time) {
+	t.Helper()
+
+	var mu sync.Mutex
+	requestTimes := []time.Time{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		mu.Unlock()
+		report(w)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"teams": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(testClientConfig(server.URL))
+	client.SetBaseURL(server.URL)
+	return client, &requestTimes
+}
+
+func TestRateLimit_RecordsHeaders(t *testing.T) {
+	client, _ := rateLimitedClient(t, func(w http.ResponseWriter) {
+		w.Header().Set(HeaderRateLimitLimit, "360")
+		w.Header().Set(HeaderRateLimitRemaining, "200")
+		w.Header().Set(HeaderRateLimitReset, "60")
+	})
+	var debug bytes.Buffer
+	client.Debug = &debug
+
+	_, ok := client.RateLimit()
+	assert.False(t, ok, "nothing is known before the first response")
+
+	_, err := client.ListOrganizationTeams("test-org-id")
+	require.NoError(t, err)
+
+	state, ok := client.RateLimit()
+	require.True(t, ok)
+	assert.Equal(t, 360, state.Limit)
+	assert.Equal(t, 200, state.Remaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), state.Reset, 5*time.Second)
+	assert.Contains(t, debug.String(), "debug: GET ")
+	assert.Contains(t, debug.String(), "→ 200 (rate limit: 200/360 remaining, resets in ")
+}
+
+func TestRateLimit_PacesByReportedLimit(t *testing.T) {
+	// Plenty of requests left: the reported limit replaces the fixed interval
+	client, requestTimes := rateLimitedClient(t, func(w http.ResponseWriter) {
+		w.Header().Set(HeaderRateLimitRemaining, "1000")
+		w.Header().Set(HeaderRateLimitReset, "10")
+	})
+	client.MinRequestInterval = time.Second
+
+	start := time.Now()
+	for _, orgID := range []string{"org-1", "org-2", "org-3"} {
+		_, err := client.ListOrganizationTeams(orgID)
+		require.NoError(t, err)
+	}
+	assert.Len(t, *requestTimes, 3)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRateLimit_WaitsForResetWhenExhausted(t *testing.T) {
+	client, requestTimes := rateLimitedClient(t, func(w http.ResponseWriter) {
+		reset := time.Now().Add(300 * time.Millisecond)
+		w.Header().Set(HeaderRateLimitRemaining, "0")
+		w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(reset.UnixMilli(), 10))
+	})
+	client.MinRequestInterval = 0
+	var debug bytes.Buffer
+	client.Debug = &debug
+
+	for _, orgID := range []string{"org-1", "org-2"} {
+		_, err := client.ListOrganizationTeams(orgID)
+		require.NoError(t, err)
+	}
+	require.Len(t, *requestTimes, 2)
+	assert.GreaterOrEqual(t, (*requestTimes)[1].Sub((*requestTimes)[0]), 250*time.Millisecond)
+	assert.Contains(t, debug.String(), "rate limit exhausted; waiting")
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxRedirects matches the limit of Go's default redirect policy
+const maxRedirects = 10
+
+// ErrRedirect is wrapped by errors for redirects the client refused to follow
+var ErrRedirect = errors.New("redirect not followed")
+
+// credentialHeaders carry the API key or JWT and must not reach another host
+var credentialHeaders = []string{"Authorization", "X-ApiKey"}
+
+// checkRedirect is the redirect policy of the client's HTTPClient. With
+// FollowRedirects off, any redirect is returned as an ErrRedirect error so a
+// misconfigured base URL is noticed. Otherwise redirects are followed, but
+// credentials are dropped when the redirect leaves the original host or
+// downgrades from https to http.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	first := via[0]
+	if !c.FollowRedirects {
+		return fmt.Errorf("%w: %s %s redirected to %s - check the base URL", ErrRedirect, first.Method, first.URL, req.URL)
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", ErrRedirect, maxRedirects)
+	}
+
+	if req.URL.Host != first.URL.Host || (first.URL.Scheme == "https" && req.URL.Scheme != "https") {
+		for _, header := range credentialHeaders {
+			req.Header.Del(header)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectServers starts an API server that 302s every request to a second
+// server on another host, and returns the headers that second server received
+func redirectServers(t *testing.T) (*httptest.Server, *http.Header) {
+	t.Helper()
+
+	received := &http.Header{}
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(other.Close)
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+r.URL.Path, http.StatusFound)
+	}))
+	t.Cleanup(apiServer.Close)
+
+	return apiServer, received
+}
+
+// Test that following a redirect to another host drops the credentials
+func TestRedirect_CrossHostDropsCredentials(t *testing.T) {
+	apiServer, received := redirectServers(t)
+	client := newRetryTestClient(apiServer.URL)
+
+	resp, err := client.Get("/api/v1/user")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "hawkop-cli", received.Get("User-Agent"), "the redirect should have been followed")
+	assert.Empty(t, received.Get("Authorization"))
+	assert.Empty(t, received.Get("X-ApiKey"))
+}
+
+// Test that redirects within the same host keep the credentials
+func TestRedirect_SameHostKeepsCredentials(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/user/" {
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+	}))
+	defer server.Close()
+
+	resp, err := newRetryTestClient(server.URL).Get("/api/v1/user")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "Bearer test-jwt-token", authorization)
+}
+
+// Test that redirects become errors when following them is turned off
+func TestRedirect_NotFollowed(t *testing.T) {
+	apiServer, received := redirectServers(t)
+	client := newRetryTestClient(apiServer.URL)
+	client.FollowRedirects = false
+
+	_, err := client.Get("/api/v1/user")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRedirect)
+	assert.Contains(t, err.Error(), "check the base URL")
+	assert.Empty(t, *received, "the other host should not be contacted")
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SnapshotToken is the placeholder JWT used when replaying snapshots
+const SnapshotToken = "snapshot-jwt-token"
+
+var snapshotKeySanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SnapshotTransport serves API responses from a directory of JSON fixtures,
+// or records live responses into that directory when Record is set
+type SnapshotTransport struct {
+	Dir    string
+	Record bool
+	Next   http.RoundTripper
+}
+
+// NewSnapshotTransport creates a snapshot transport for the given directory
+func NewSnapshotTransport(dir string, record bool, next http.RoundTripper) *SnapshotTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &SnapshotTransport{
+		Dir:    dir,
+		Record: record,
+		Next:   next,
+	}
+}
+
+// SnapshotKey returns the fixture file name for a request, keyed by method, path and query
+func SnapshotKey(req *http.Request) string {
+	key := req.Method + " " + req.URL.Path
+	if query := req.URL.Query().Encode(); query != "" {
+		key += "?" + query
+	}
+	key = strings.Trim(snapshotKeySanitizer.ReplaceAllString(key, "_"), "_")
+	return key + ".json"
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *SnapshotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Record {
+		return t.record(req)
+	}
+	return t.replay(req)
+}
+
+// replay serves a response from the fixture file matching the request
+func (t *SnapshotTransport) replay(req *http.Request) (*http.Response, error) {
+	// Snapshots never contain credentials, so authentication is always answered locally
+	if req.URL.Path == AuthEndpoint {
+		body, err := json.Marshal(AuthResponse{
+			Token:     SnapshotToken,
+			ExpiresAt: time.Now().Add(30 * time.Minute),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build snapshot auth response: %w", err)
+		}
+		return newSnapshotResponse(req, http.StatusOK, body), nil
+	}
+
+	path := filepath.Join(t.Dir, SnapshotKey(req))
+	body, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newSnapshotResponse(req, http.StatusNotFound,
+			[]byte(fmt.Sprintf("no snapshot for %s %s (expected %s)", req.Method, req.URL.Path, path))), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return newSnapshotResponse(req, http.StatusOK, body), nil
+}
+
+// record performs the live request and saves successful responses to the snapshot directory
+func (t *SnapshotTransport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// Fixtures are replayed without a Content-Encoding, so store them decompressed
+	if err := decompressResponse(resp); err != nil {
+		return nil, err
+	}
+
+	// Never persist authentication responses or failures
+	if req.URL.Path == AuthEndpoint || resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for snapshot: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := os.MkdirAll(t.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(t.Dir, SnapshotKey(req)), body, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return resp, nil
+}
+
+func newSnapshotResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"hawkop/internal/config"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *SnapshotTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotKey() {
+	req, _ := http.NewRequest("GET", "https://api.stackhawk.com/api/v1/scan/org-1?pageSize=1000&sortDir=desc", nil)
+	assert.Equal(suite.T(), "GET_api_v1_scan_org-1_pageSize_1000_sortDir_desc.json", SnapshotKey(req))
+}
+
+func (suite *SnapshotTestSuite) TestRecordThenReplay() {
+	server := NewMockAPIServer()
+	defer server.Close()
+
+	// Record live responses
+	recorder := NewClient(testClientConfig(server.URL()))
+	recorder.SetBaseURL(server.URL())
+	recorder.UseSnapshot(suite.dir, true)
+
+	recorded, err := recorder.ListOrganizationScans("test-org-id")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), recorded, 1)
+
+	files, err := os.ReadDir(suite.dir)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), files, 1)
+
+	// Replay without a server or a JWT
+	replayer := NewClient(&config.Config{APIKey: "test-api-key"})
+	replayer.SetBaseURL("http://127.0.0.1:1")
+	replayer.UseSnapshot(suite.dir, false)
+
+	replayed, err := replayer.ListOrganizationScans("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), recorded, replayed)
+}
+
+func (suite *SnapshotTestSuite) TestReplay_MissingFixture() {
+	client := NewClient(&config.Config{APIKey: "test-api-key"})
+	client.UseSnapshot(suite.dir, false)
+
+	_, err := client.ListOrganizationTeams("test-org-id")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "no snapshot")
+}
+
+func (suite *SnapshotTestSuite) TestReplay_AuthIsLocal() {
+	cfg := &config.Config{APIKey: "test-api-key"}
+
+	teams := OrganizationTeamsResponse{Teams: []Team{{ID: "team-1", Name: "Snapshot Team"}}}
+	data, _ := json.Marshal(teams)
+	req := httptest.NewRequest("GET", "/api/v1/org/test-org-id/teams?pageSize=1000", nil)
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(suite.dir, SnapshotKey(req)), data, 0600))
+
+	client := NewClient(cfg)
+	client.UseSnapshot(suite.dir, false)
+
+	// Avoid writing the placeholder token to the real config file
+	transport := client.HTTPClient.Transport
+	resp, err := transport.RoundTrip(httptest.NewRequest("GET", AuthEndpoint, nil))
+	require.NoError(suite.T(), err)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var auth AuthResponse
+	require.NoError(suite.T(), json.NewDecoder(resp.Body).Decode(&auth))
+	assert.Equal(suite.T(), SnapshotToken, auth.Token)
+
+	// Replayed requests carry the placeholder token without storing it
+	result, err := client.ListOrganizationTeams("test-org-id")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Snapshot Team", result[0].Name)
+	assert.Empty(suite.T(), cfg.JWTs)
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
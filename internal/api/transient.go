@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"syscall"
+	"time"
+)
+
+const (
+	// MaxConnectRetriesDefault is how many times a request is resent after a
+	// transient connection failure
+	MaxConnectRetriesDefault = 3
+	// ConnectRetryBackoffDefault is the wait before the first resend; it doubles each time
+	ConnectRetryBackoffDefault = 500 * time.Millisecond
+)
+
+// RetryStatusesDefault are the response statuses retried unless configured otherwise
+var RetryStatusesDefault = []int{
+	http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout,
+}
+
+// ValidateRetryStatuses checks that every status is an HTTP error status, 400-599
+func ValidateRetryStatuses(statuses []int) error {
+	for _, status := range statuses {
+		if status < 400 || status > 599 {
+			return fmt.Errorf("%d is not an HTTP error status (400-599)", status)
+		}
+	}
+	return nil
+}
+
+// RetryConfig controls how a client resends requests after transient connection
+// failures
+type RetryConfig struct {
+	// MaxRetries is how many times a request is resent; 0 fails on the first error
+	MaxRetries int
+	// Delay is the wait before the first resend; it doubles for each one after
+	Delay time.Duration
+}
+
+// SetRetryConfig applies retry to requests made from now on
+func (c *Client) SetRetryConfig(retry RetryConfig) {
+	c.MaxConnectRetries = retry.MaxRetries
+	c.ConnectRetryBackoff = retry.Delay
+}
+
+// isTransientNetError reports whether err is a connection failure that may clear
+// up on its own, such as a DNS lookup failure or a refused or reset connection.
+// Resets and dropped connections are only transient for idempotent methods,
+// since the server may already have acted on the request.
+func isTransientNetError(err error, method string) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// The request never reached the server, so any method is safe to resend
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retriesStatus reports whether resp's status should be retried: it is one of
+// RetryStatuses other than 429, which has its own handling, and req is a GET or
+// HEAD, since a server that answers 502 or 504 may have acted on other requests
+func (c *Client) retriesStatus(req *http.Request, resp *http.Response) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return resp.StatusCode != http.StatusTooManyRequests && slices.Contains(c.RetryStatuses, resp.StatusCode)
+}
+
+// rewindBody resets the body of req, which an earlier attempt consumed, so it
+// can be sent again
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errors.New("request body cannot be resent")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	req.Body = body
+	return nil
+}
+
+// doWithConnectRetry sends req, resending it with exponential backoff while it
+// fails with a transient connection error. Other errors, such as a malformed URL,
+// are returned immediately.
+func (c *Client) doWithConnectRetry(req *http.Request) (*http.Response, error) {
+	backoff := c.ConnectRetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if err == nil || attempt >= c.MaxConnectRetries || !isTransientNetError(err, req.Method) {
+			return resp, err
+		}
+
+		// The failed attempt may have consumed the body
+		if rewindBody(req) != nil {
+			return nil, err
+		}
+
+		if sleepErr := c.sleep(backoff); sleepErr != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
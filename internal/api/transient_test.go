@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/config"
+)
+
+func TestIsTransientNetError(t *testing.T) {
+	dial := func(errno syscall.Errno) error {
+		return &url.Error{Op: "Get", URL: "http://api.example.com", Err: &net.OpError{
+			Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errno),
+		}}
+	}
+	read := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://api.example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: err}}
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		method string
+		want   bool
+	}{
+		{"connection refused", dial(syscall.ECONNREFUSED), http.MethodGet, true},
+		{"connection refused on POST", dial(syscall.ECONNREFUSED), http.MethodPost, true},
+		{"DNS failure", &url.Error{Op: "Get", Err: &net.DNSError{Err: "no such host", Name: "api.example.com"}}, http.MethodGet, true},
+		{"connection reset", read(os.NewSyscallError("read", syscall.ECONNRESET)), http.MethodGet, true},
+		{"connection reset on POST", read(os.NewSyscallError("read", syscall.ECONNRESET)), http.MethodPost, false},
+		{"server closed connection", &url.Error{Op: "Get", Err: io.EOF}, http.MethodGet, true},
+		{"unsupported scheme", &url.Error{Op: "Get", URL: "ftp://api.example.com", Err: errors.New(`unsupported protocol scheme "ftp"`)}, http.MethodGet, false},
+		{"cancelled", fmt.Errorf("request failed: %w", context.Canceled), http.MethodGet, false},
+		{"deadline", &url.Error{Op: "Get", Err: context.DeadlineExceeded}, http.MethodGet, false},
+		{"nil", nil, http.MethodGet, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isTransientNetError(tt.err, tt.method), tt.name)
+	}
+}
+
+// newRetryTestClient returns a client with a valid JWT and fast connection retries
+func newRetryTestClient(baseURL string) *Client {
+	client := NewClient(testClientConfig(baseURL))
+	client.SetBaseURL(baseURL)
+	client.ConnectRetryBackoff = 20 * time.Millisecond
+	return client
+}
+
+// Test that requests succeed once a server that was down starts listening
+func TestConnectRetry_ServerComesUp(t *testing.T) {
+	// Reserve a free port, then release it so connections are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client := newRetryTestClient("http://" + addr)
+	client.MaxConnectRetries = 10
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(UserResponse{User: User{StackhawkId: "late-user"}})
+	}))
+	defer server.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		server.Listener = listener
+		server.Start()
+	}()
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "late-user", user.StackhawkId)
+}
+
+// Test that retries stop after MaxConnectRetries attempts
+func TestConnectRetry_GivesUp(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	var attempts atomic.Int32
+	client := newRetryTestClient("http://" + addr)
+	client.MaxConnectRetries = 2
+	client.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	_, err = client.GetUser()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, syscall.ECONNREFUSED), "got %v", err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+// Test that SetRetryConfig with no retries fails on the first connection error
+func TestSetRetryConfig_NoRetries(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	var attempts atomic.Int32
+	client := newRetryTestClient("http://" + addr)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 0, Delay: time.Second})
+	client.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	_, err = client.GetUser()
+	require.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+	assert.Equal(t, time.Second, client.ConnectRetryBackoff)
+}
+
+// Test that errors that can't clear up are not retried
+func TestConnectRetry_FatalErrorsFailImmediately(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryTestClient("ftp://api.example.com")
+	client.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	_, err := client.GetUser()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported protocol scheme")
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+// flakyServer answers the first failures requests with status, then succeeds,
+// counting every request it receives
+func flakyServer(t *testing.T, status, failures int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(requests.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user":{"stackhawkId":"recovered"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// Test that a 503 is retried with backoff when it is a retry status
+func TestStatusRetry_Configured(t *testing.T) {
+	server, requests := flakyServer(t, http.StatusServiceUnavailable, 2)
+	client := newRetryTestClient(server.URL)
+	client.ConnectRetryBackoff = time.Millisecond
+
+	user, err := client.GetUser()
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", user.StackhawkId)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+// Test that a 503 fails at once when it is left out of the retry statuses
+func TestStatusRetry_Excluded(t *testing.T) {
+	server, requests := flakyServer(t, http.StatusServiceUnavailable, 2)
+	client := newRetryTestClient(server.URL)
+	client.RetryStatuses = []int{http.StatusBadGateway}
+
+	_, err := client.GetUser()
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// Test that retries stop after MaxConnectRetries and that requests which may have
+// changed data are never resent
+func TestStatusRetry_Limits(t *testing.T) {
+	server, requests := flakyServer(t, http.StatusBadGateway, 10)
+	client := newRetryTestClient(server.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 2, Delay: time.Millisecond})
+
+	_, err := client.GetUser()
+	require.Error(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	requests.Store(0)
+	resp, err := client.Post("/api/v1/app", map[string]string{"name": "x"})
+	if err == nil {
+		resp.Body.Close()
+	}
+	require.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// Test that a POST resent after an expired token or a 429 carries its full body
+func TestResend_RewindsBody(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusTooManyRequests} {
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == AuthEndpoint {
+				_ = json.NewEncoder(w).Encode(AuthResponse{Token: "fresh-jwt", ExpiresAt: time.Now().Add(time.Hour)})
+				return
+			}
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(data))
+			if len(bodies) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		client := newRetryTestClient(server.URL)
+		client.saveConfig = func(*config.Config) error { return nil }
+		resp, err := client.Post("/api/v1/app", map[string]string{"name": "web"})
+		require.NoError(t, err, http.StatusText(status))
+		resp.Body.Close()
+		server.Close()
+
+		require.Len(t, bodies, 2, http.StatusText(status))
+		assert.JSONEq(t, `{"name":"web"}`, bodies[0])
+		assert.Equal(t, bodies[0], bodies[1], http.StatusText(status))
+	}
+}
+
+// Test that a consumed body without GetBody is reported rather than resent empty
+func TestRewindBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/app", nil)
+	require.NoError(t, rewindBody(req))
+
+	req.Body = io.NopCloser(strings.NewReader("{}"))
+	assert.Error(t, rewindBody(req))
+}
+
+func TestValidateRetryStatuses(t *testing.T) {
+	assert.NoError(t, ValidateRetryStatuses(RetryStatusesDefault))
+	assert.NoError(t, ValidateRetryStatuses(nil))
+	assert.ErrorContains(t, ValidateRetryStatuses([]int{503, 200}), "200 is not an HTTP error status")
+	assert.Error(t, ValidateRetryStatuses([]int{600}))
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
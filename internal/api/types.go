@@ -1,6 +1,74 @@
 // Package api defines data structures for StackHawk API responses.
 package api
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"hawkop/internal/number"
+)
+
+// FlexString is a scalar value the API may send as either a JSON string or number.
+// Numeric values marshal as JSON numbers, so --format json keeps counts and
+// durations numeric however the API sent them; anything else marshals as a string.
+type FlexString string
+
+// UnmarshalJSON accepts JSON strings, numbers, booleans, and null
+func (f *FlexString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*f = ""
+		return nil
+	}
+
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = FlexString(s)
+		return nil
+	}
+
+	if data[0] == '{' || data[0] == '[' {
+		return fmt.Errorf("cannot unmarshal %s into a scalar value", string(data))
+	}
+
+	*f = FlexString(data)
+	return nil
+}
+
+// MarshalJSON writes numeric values as JSON numbers and the rest as strings
+func (f FlexString) MarshalJSON() ([]byte, error) {
+	if data := []byte(f); len(data) > 0 && (data[0] == '-' || (data[0] >= '0' && data[0] <= '9')) && json.Valid(data) {
+		return data, nil
+	}
+	return json.Marshal(string(f))
+}
+
+// JSONSchemaTypes names the JSON types FlexString marshals as, for schema output
+func (FlexString) JSONSchemaTypes() []string {
+	return []string{"number", "string"}
+}
+
+// String returns the value as a string
+func (f FlexString) String() string {
+	return string(f)
+}
+
+// Float64 parses the value as a number
+func (f FlexString) Float64() (float64, bool) {
+	return number.Parse(string(f))
+}
+
+// Count parses the value as a non-negative whole number, such as "42" or "1.2e4"
+func (f FlexString) Count() (int64, bool) {
+	return number.ParseCount(string(f))
+}
+
 // PaginationOptions represents pagination and sorting parameters
 type PaginationOptions struct {
 	PageSize  int    `json:"pageSize,omitempty"`
@@ -14,7 +82,7 @@ type PaginationOptions struct {
 type PaginationInfo struct {
 	NextPageToken string      `json:"nextPageToken,omitempty"`
 	PrevPageToken string      `json:"prevPageToken,omitempty"`
-	TotalCount    string      `json:"totalCount,omitempty"`
+	TotalCount    FlexString  `json:"totalCount,omitempty"`
 	HasNext       bool        `json:"hasNext,omitempty"`
 	HasPrev       bool        `json:"hasPrev,omitempty"`
 	CurrentPage   interface{} `json:"currentPage,omitempty"`
@@ -114,7 +182,7 @@ type Achievement struct {
 type OrganizationMembersResponse struct {
 	Users         []OrganizationMember `json:"users,omitempty"`
 	NextPageToken string               `json:"nextPageToken,omitempty"`
-	TotalCount    string               `json:"totalCount,omitempty"`
+	TotalCount    FlexString           `json:"totalCount,omitempty"`
 }
 
 // Team represents a StackHawk team within an organization
@@ -127,6 +195,14 @@ type Team struct {
 	CreatedTimestamp string               `json:"createdTimestamp,omitempty"`
 }
 
+// TeamRequest represents the request body for creating or updating a team
+type TeamRequest struct {
+	Name           string   `json:"name"`
+	OrganizationID string   `json:"organizationId"`
+	UserIDs        []string `json:"userIds"`
+	ApplicationIDs []string `json:"applicationIds"`
+}
+
 // Application represents a basic application reference in teams
 type Application struct {
 	ID   string `json:"id"`
@@ -135,27 +211,46 @@ type Application struct {
 
 // OrganizationTeamsResponse represents the response from the /api/v1/org/{orgId}/teams endpoint
 type OrganizationTeamsResponse struct {
-	Teams         []Team `json:"teams,omitempty"`
-	NextPageToken string `json:"nextPageToken,omitempty"`
-	TotalCount    string `json:"totalCount,omitempty"`
+	Teams         []Team     `json:"teams,omitempty"`
+	NextPageToken string     `json:"nextPageToken,omitempty"`
+	TotalCount    FlexString `json:"totalCount,omitempty"`
 }
 
 // AppApplication represents a StackHawk application
 type AppApplication struct {
-	ApplicationID     string      `json:"applicationId"`
-	Name              string      `json:"name"`
-	Env               string      `json:"env,omitempty"`
-	EnvID             string      `json:"envId,omitempty"`
-	ApplicationStatus string      `json:"applicationStatus,omitempty"`
-	OrganizationID    string      `json:"organizationId,omitempty"`
-	ApplicationType   string      `json:"applicationType,omitempty"`
-	CloudScanTarget   interface{} `json:"cloudScanTarget,omitempty"`
+	ApplicationID     string `json:"applicationId"`
+	Name              string `json:"name"`
+	Env               string `json:"env,omitempty"`
+	EnvID             string `json:"envId,omitempty"`
+	ApplicationStatus string `json:"applicationStatus,omitempty"`
+	OrganizationID    string `json:"organizationId,omitempty"`
+	ApplicationType   string `json:"applicationType,omitempty"`
+	// CloudScanTarget is free-form; encoding/json sorts object keys so its output is stable
+	CloudScanTarget interface{} `json:"cloudScanTarget,omitempty"`
+	// PolicyName is the scan policy of Env, when the API reports it
+	PolicyName string `json:"policyName,omitempty"`
+	// Environments lists every environment of the application when the API includes
+	// them, each with its own scan policy
+	Environments []AppEnvironment `json:"environments,omitempty"`
+}
+
+// AppEnvironment is one environment of an application and the scan policy it uses
+type AppEnvironment struct {
+	EnvID      string `json:"envId,omitempty"`
+	Env        string `json:"env"`
+	PolicyName string `json:"policyName,omitempty"`
+}
+
+// CreateApplicationRequest represents the request body for the /api/v1/org/{orgId}/app endpoint
+type CreateApplicationRequest struct {
+	Name string `json:"name"`
+	Env  string `json:"env"`
 }
 
 // OrganizationApplicationsResponse represents the response from the /api/v2/org/{orgId}/apps endpoint
 type OrganizationApplicationsResponse struct {
 	Applications  []AppApplication `json:"applications,omitempty"`
-	TotalCount    string           `json:"totalCount,omitempty"`
+	TotalCount    FlexString       `json:"totalCount,omitempty"`
 	CurrentPage   interface{}      `json:"currentPage,omitempty"`
 	HasNext       bool             `json:"hasNext,omitempty"`
 	NextPage      interface{}      `json:"nextPage,omitempty"`
@@ -175,17 +270,24 @@ type Scan struct {
 	Timestamp       string `json:"timestamp"`
 }
 
+// ScanTag represents a name/value tag attached to a scan
+type ScanTag struct {
+	Name  string     `json:"name"`
+	Value FlexString `json:"value,omitempty"`
+}
+
 // ApplicationScanResult represents a scan result with metadata
 type ApplicationScanResult struct {
 	Scan         Scan        `json:"scan"`
-	ScanDuration interface{} `json:"scanDuration,omitempty"`
-	URLCount     interface{} `json:"urlCount,omitempty"`
+	ScanDuration FlexString  `json:"scanDuration,omitempty"`
+	URLCount     FlexString  `json:"urlCount,omitempty"`
 	AlertStats   *AlertStats `json:"alertStats,omitempty"`
 	AppHost      string      `json:"appHost,omitempty"`
 	Timestamp    string      `json:"timestamp,omitempty"`
 	PolicyName   string      `json:"policyName,omitempty"`
-	Tags         interface{} `json:"tags,omitempty"`
-	Metadata     interface{} `json:"metadata,omitempty"`
+	Tags         []ScanTag   `json:"tags,omitempty"`
+	// Metadata is free-form; encoding/json sorts object keys so its output is stable
+	Metadata interface{} `json:"metadata,omitempty"`
 }
 
 // AlertStats represents alert statistics for a scan
@@ -201,7 +303,9 @@ type AlertStats struct {
 type OrganizationScansResponse struct {
 	ApplicationScanResults []ApplicationScanResult `json:"applicationScanResults,omitempty"`
 	NextPageToken          string                  `json:"nextPageToken,omitempty"`
-	TotalCount             string                  `json:"totalCount,omitempty"`
+	TotalCount             FlexString              `json:"totalCount,omitempty"`
+	// CurrentPage is set when the endpoint also accepts page numbers
+	CurrentPage FlexString `json:"currentPage,omitempty"`
 }
 
 // ScanAlert represents an alert/finding type in a scan
@@ -213,20 +317,102 @@ type ScanAlert struct {
 	References  []string `json:"references,omitempty"`
 	URICount    int      `json:"uriCount,omitempty"`
 	CWEID       string   `json:"cweId,omitempty"`
+	// Suppressed is set locally when the alert matches the suppression list
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// UnmarshalJSON decodes an alert with its severity normalized, so filtering,
+// ordering, and display don't depend on how the API capitalized it. The URI count
+// may arrive as a string, a float, or in scientific notation; one that isn't a
+// whole number decodes as 0 rather than failing the whole alert list.
+func (a *ScanAlert) UnmarshalJSON(data []byte) error {
+	// scanAlertFields has ScanAlert's fields without this method
+	type scanAlertFields ScanAlert
+	fields := struct {
+		*scanAlertFields
+		URICount FlexString `json:"uriCount,omitempty"`
+	}{scanAlertFields: (*scanAlertFields)(a)}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	a.Severity = NormalizeSeverity(a.Severity)
+	a.URICount = 0
+	if n, ok := fields.URICount.Count(); ok && n <= math.MaxInt {
+		a.URICount = int(n)
+	}
+	return nil
+}
+
+// Canonical alert severities, most severe first
+const (
+	SeverityHigh   = "High"
+	SeverityMedium = "Medium"
+	SeverityLow    = "Low"
+	SeverityInfo   = "Info"
+)
+
+// Severities lists the canonical severities, most severe first
+var Severities = []string{SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// NormalizeSeverity returns the canonical form of a severity in any case, e.g.
+// "HIGH" becomes "High" and "informational" becomes "Info". Unrecognized values
+// are returned trimmed but otherwise unchanged.
+func NormalizeSeverity(severity string) string {
+	severity = strings.TrimSpace(severity)
+	switch strings.ToLower(severity) {
+	case "high":
+		return SeverityHigh
+	case "medium":
+		return SeverityMedium
+	case "low":
+		return SeverityLow
+	case "info", "informational":
+		return SeverityInfo
+	default:
+		return severity
+	}
+}
+
+// ScanAlertsResult represents one scan's entry in the alerts response
+type ScanAlertsResult struct {
+	Scan              Scan        `json:"scan,omitempty"`
+	ApplicationAlerts []ScanAlert `json:"applicationAlerts,omitempty"`
 }
 
 // ScanAlertsResponse represents the response from the /api/v1/scan/{scanId}/alerts endpoint
 type ScanAlertsResponse struct {
-	ApplicationScanResults []struct {
-		ApplicationAlerts []ScanAlert `json:"applicationAlerts,omitempty"`
-	} `json:"applicationScanResults,omitempty"`
-	NextPageToken string `json:"nextPageToken,omitempty"`
+	ApplicationScanResults []ScanAlertsResult `json:"applicationScanResults,omitempty"`
+	NextPageToken          string             `json:"nextPageToken,omitempty"`
+}
+
+// CollectAlertsOptions controls org-wide alert collection
+type CollectAlertsOptions struct {
+	Concurrency int      `json:"concurrency,omitempty"`
+	Envs        []string `json:"envs,omitempty"` // only collect scans in these environments (case-insensitive)
+	// Progress is called once the scans are listed and after each scan's alerts
+	// are fetched; see FetchScanAlerts
+	Progress func(done, total int) `json:"-"`
+}
+
+// OrgScanAlerts represents the alerts collected for one scan during org-wide collection
+type OrgScanAlerts struct {
+	Scan   ApplicationScanResult `json:"scan"`
+	Alerts []ScanAlert           `json:"alerts"`
+	Err    error                 `json:"-"`
+}
+
+// AlertFindings is one alert and the findings fetched for it by FetchAlertFindings
+type AlertFindings struct {
+	Alert    ScanAlert
+	Findings []ScanAlertFinding
+	Err      error
 }
 
 // ScanAlertFinding represents a specific finding instance
 type ScanAlertFinding struct {
 	PluginID      string `json:"pluginId"`
 	URI           string `json:"uri"`
+	Param         string `json:"param,omitempty"`
 	RequestMethod string `json:"requestMethod"`
 	Status        string `json:"status"`
 	MsgID         string `json:"msgId"`
@@ -238,7 +424,7 @@ type ScanAlertFindingsResponse struct {
 	Category                 string             `json:"category,omitempty"`
 	ApplicationScanAlertUris []ScanAlertFinding `json:"applicationScanAlertUris,omitempty"`
 	AppHost                  string             `json:"appHost,omitempty"`
-	TotalCount               string             `json:"totalCount,omitempty"`
+	TotalCount               FlexString         `json:"totalCount,omitempty"`
 	NextPageToken            string             `json:"nextPageToken,omitempty"`
 }
 
@@ -259,3 +445,27 @@ type ScanMessageResponse struct {
 	Description string      `json:"description,omitempty"`
 	Param       string      `json:"param,omitempty"`
 }
+
+// Policy represents a scan policy: the set of plugins a scan runs and how
+// aggressively they test
+type Policy struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	DisplayName    string         `json:"displayName,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	OrganizationID string         `json:"organizationId,omitempty"`
+	Plugins        []PolicyPlugin `json:"plugins,omitempty"`
+}
+
+// PolicyPlugin represents a plugin enabled by a scan policy
+type PolicyPlugin struct {
+	PluginID       string `json:"pluginId"`
+	Name           string `json:"name,omitempty"`
+	AlertThreshold string `json:"alertThreshold,omitempty"`
+	AttackStrength string `json:"attackStrength,omitempty"`
+}
+
+// PoliciesResponse represents the response from the /api/v1/policy/{orgId}/list endpoint
+type PoliciesResponse struct {
+	ScanPolicies []Policy `json:"scanPolicies,omitempty"`
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TypesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TypesTestSuite) TestFlexString_Unmarshal() {
+	var result ApplicationScanResult
+
+	err := json.Unmarshal([]byte(`{"scanDuration": 45, "urlCount": "10"}`), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), FlexString("45"), result.ScanDuration)
+	assert.Equal(suite.T(), FlexString("10"), result.URLCount)
+
+	err = json.Unmarshal([]byte(`{"scanDuration": null}`), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), FlexString(""), result.ScanDuration)
+
+	err = json.Unmarshal([]byte(`{"scanDuration": {"seconds": 45}}`), &result)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TypesTestSuite) TestFlexString_Float64() {
+	d, ok := FlexString("45.5").Float64()
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 45.5, d)
+
+	_, ok = FlexString("").Float64()
+	assert.False(suite.T(), ok)
+}
+
+func (suite *TypesTestSuite) TestFlexString_Count() {
+	n, ok := FlexString("1.2e4").Count()
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), int64(12000), n)
+
+	_, ok = FlexString("3.5").Count()
+	assert.False(suite.T(), ok)
+}
+
+func (suite *TypesTestSuite) TestFlexString_Marshal() {
+	data, err := json.Marshal(ApplicationScanResult{ScanDuration: "45", URLCount: "1.2e4"})
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"scanDuration":45`)
+	assert.Contains(suite.T(), string(data), `"urlCount":1.2e4`)
+
+	for value, want := range map[FlexString]string{
+		"-3.5":  `-3.5`,
+		"N/A":   `"N/A"`,
+		"0123":  `"0123"`,
+		"true":  `"true"`,
+		"":      `""`,
+		"12abc": `"12abc"`,
+	} {
+		data, err := json.Marshal(value)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), want, string(data), string(value))
+	}
+}
+
+func (suite *TypesTestSuite) TestScanTag_NonStringValues() {
+	var result ApplicationScanResult
+	err := json.Unmarshal([]byte(`{"tags": [{"name": "build", "value": 42}, {"name": "nightly", "value": true}, {"name": "branch", "value": "main"}]}`), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []ScanTag{{Name: "build", Value: "42"}, {Name: "nightly", Value: "true"}, {Name: "branch", Value: "main"}}, result.Tags)
+}
+
+func (suite *TypesTestSuite) TestNormalizeSeverity() {
+	for input, want := range map[string]string{
+		"HIGH": "High", "high": "High", " High ": "High",
+		"MEDIUM": "Medium", "low": "Low",
+		"INFO": "Info", "Informational": "Info",
+		"": "", "Critical": "Critical",
+	} {
+		assert.Equal(suite.T(), want, NormalizeSeverity(input), "input %q", input)
+	}
+}
+
+func (suite *TypesTestSuite) TestScanAlert_UnmarshalNormalizesSeverity() {
+	var resp ScanAlertsResponse
+	err := json.Unmarshal([]byte(`{"applicationScanResults": [{"applicationAlerts": [
+		{"pluginId": "1", "name": "SQL Injection", "severity": "HIGH", "uriCount": 2},
+		{"pluginId": "2", "severity": "high"},
+		{"pluginId": "3", "severity": "informational"}
+	]}]}`), &resp)
+	assert.NoError(suite.T(), err)
+
+	alerts := resp.ApplicationScanResults[0].ApplicationAlerts
+	assert.Equal(suite.T(), ScanAlert{PluginID: "1", Name: "SQL Injection", Severity: "High", URICount: 2}, alerts[0])
+	assert.Equal(suite.T(), "High", alerts[1].Severity)
+	assert.Equal(suite.T(), "Info", alerts[2].Severity)
+}
+
+func (suite *TypesTestSuite) TestScanAlert_UnmarshalURICountForms() {
+	var alerts []ScanAlert
+	err := json.Unmarshal([]byte(`[
+		{"pluginId": "1", "uriCount": "12"},
+		{"pluginId": "2", "uriCount": 3.0},
+		{"pluginId": "3", "uriCount": 1e5},
+		{"pluginId": "4", "uriCount": null},
+		{"pluginId": "5", "uriCount": "unknown"},
+		{"pluginId": "6", "uriCount": 2.5}
+	]`), &alerts)
+	assert.NoError(suite.T(), err)
+
+	counts := make([]int, len(alerts))
+	for i, alert := range alerts {
+		counts[i] = alert.URICount
+	}
+	assert.Equal(suite.T(), []int{12, 3, 100000, 0, 0, 0}, counts)
+}
+
+func TestTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(TypesTestSuite))
+}
@@ -3,19 +3,54 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// BuiltinInstances maps well-known instance names to StackHawk API base URLs
+var BuiltinInstances = map[string]string{
+	"prod": "https://api.stackhawk.com",
+}
+
 // Config represents the hawkop configuration
 type Config struct {
-	APIKey string `json:"api_key,omitempty" yaml:"api_key,omitempty"`
-	OrgID  string `json:"org_id,omitempty" yaml:"org_id,omitempty"`
-	JWT    *JWT   `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+	APIKey         string            `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	OrgID          string            `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	BaseURL        string            `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	OutputFormat   string            `json:"output_format,omitempty" yaml:"output_format,omitempty"`
+	RequestTimeout string            `json:"request_timeout,omitempty" yaml:"request_timeout,omitempty"`
+	MaxRetries     string            `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+	RetryDelay     string            `json:"retry_delay,omitempty" yaml:"retry_delay,omitempty"`
+	Proxy          string            `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	Timezone       string            `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	Instances      map[string]string `json:"instances,omitempty" yaml:"instances,omitempty"`
+	Baselines      []Baseline        `json:"baselines,omitempty" yaml:"baselines,omitempty"`
+	OrgEnvs        map[string]string `json:"org_envs,omitempty" yaml:"org_envs,omitempty"`
+
+	// JWTs caches a token per API base URL, so a token issued by one instance is
+	// never sent to another
+	JWTs map[string]*JWT `json:"jwts,omitempty" yaml:"jwts,omitempty"`
+	// JWT is the single token older versions cached. Load files it under the
+	// configured base URL; nothing else reads it.
+	JWT *JWT `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+
+	// templates holds the original ${VAR} references of expanded fields; see expandEnv
+	templates map[string]envTemplate
+}
+
+// Baseline pins a scan as the comparison baseline for an application environment
+type Baseline struct {
+	App    string `json:"app" yaml:"app"`
+	Env    string `json:"env" yaml:"env"`
+	ScanID string `json:"scan_id" yaml:"scan_id"`
 }
 
 // JWT represents a JSON Web Token with expiration
@@ -70,10 +105,16 @@ func Load() (*Config, error) {
 	}
 
 	// Check if config file exists
-	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+	info, err := os.Stat(configFile)
+	if os.IsNotExist(err) {
 		// Return empty config if file doesn't exist
 		return &Config{}, nil
 	}
+	if err == nil {
+		if err := enforcePermissions(configFile, info); err != nil {
+			return nil, err
+		}
+	}
 
 	// Read config file
 	data, err := os.ReadFile(configFile)
@@ -87,9 +128,38 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Resolve ${VAR} references so templates can inject secrets at runtime
+	config.expandEnv()
+	config.migrateJWT()
+
 	return &config, nil
 }
 
+// ErrUnknownFields is wrapped by CheckFile errors for settings this version of
+// hawkop doesn't recognize, such as ones written by a newer version
+var ErrUnknownFields = errors.New("unrecognized settings")
+
+// CheckFile parses config file contents the way Load does, then again strictly, so
+// settings Load would silently ignore are reported as an error wrapping
+// ErrUnknownFields. The config file has no version field; its fields are the schema.
+func CheckFile(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnknownFields, err)
+	}
+	return nil
+}
+
 // Save writes the configuration to the config file
 func (c *Config) Save() error {
 	// Ensure config directory exists
@@ -97,8 +167,8 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Marshal to YAML for readability
-	data, err := yaml.Marshal(c)
+	// Marshal to YAML for readability, keeping ${VAR} references rather than their values
+	data, err := yaml.Marshal(c.withTemplates())
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -107,6 +177,10 @@ func (c *Config) Save() error {
 	if err := os.WriteFile(configFile, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	// WriteFile keeps the mode of an existing file, which may have been created looser
+	if err := os.Chmod(configFile, 0600); err != nil {
+		return fmt.Errorf("failed to restrict config file permissions: %w", err)
+	}
 
 	return nil
 }
@@ -114,7 +188,8 @@ func (c *Config) Save() error {
 // SetAPIKey updates the API key in the configuration
 func (c *Config) SetAPIKey(apiKey string) {
 	c.APIKey = apiKey
-	// Clear JWT when API key changes
+	// Clear JWTs when API key changes
+	c.JWTs = nil
 	c.JWT = nil
 }
 
@@ -123,16 +198,45 @@ func (c *Config) SetOrgID(orgID string) {
 	c.OrgID = orgID
 }
 
-// SetJWT updates the JWT token and expiration in the configuration
-func (c *Config) SetJWT(token string, expiresAt time.Time) {
-	c.JWT = &JWT{
+// jwtKey normalizes a base URL for looking up its JWT
+func jwtKey(baseURL string) string {
+	return strings.TrimRight(baseURL, "/")
+}
+
+// JWTFor returns the JWT cached for the API at baseURL, or nil if there is none
+func (c *Config) JWTFor(baseURL string) *JWT {
+	return c.JWTs[jwtKey(baseURL)]
+}
+
+// SetJWT caches the JWT issued by the API at baseURL, replacing only that API's token
+func (c *Config) SetJWT(baseURL string, token string, expiresAt time.Time) {
+	if c.JWTs == nil {
+		c.JWTs = make(map[string]*JWT)
+	}
+	c.JWTs[jwtKey(baseURL)] = &JWT{
 		Token:     token,
 		ExpiresAt: expiresAt,
 	}
 }
 
-// ClearJWT removes the JWT token from the configuration
-func (c *Config) ClearJWT() {
+// ClearJWT removes the JWT cached for the API at baseURL
+func (c *Config) ClearJWT(baseURL string) {
+	delete(c.JWTs, jwtKey(baseURL))
+}
+
+// migrateJWT files a token cached by an older version under the base URL it was
+// issued for: the configured base_url, or prod when none is set
+func (c *Config) migrateJWT() {
+	if c.JWT == nil {
+		return
+	}
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = BuiltinInstances["prod"]
+	}
+	if c.JWTFor(baseURL) == nil {
+		c.SetJWT(baseURL, c.JWT.Token, c.JWT.ExpiresAt)
+	}
 	c.JWT = nil
 }
 
@@ -141,7 +245,90 @@ func (c *Config) HasValidCredentials() bool {
 	return c.APIKey != ""
 }
 
-// NeedsJWTRefresh checks if a new JWT token should be obtained
-func (c *Config) NeedsJWTRefresh() bool {
-	return c.HasValidCredentials() && (c.JWT == nil || c.JWT.IsExpired())
+// NeedsJWTRefresh checks if a new JWT token should be obtained for the API at baseURL
+func (c *Config) NeedsJWTRefresh(baseURL string) bool {
+	return c.HasValidCredentials() && c.JWTFor(baseURL).IsExpired()
+}
+
+// SetBaseline pins scanID as the baseline for an application (name or ID) and
+// environment, replacing any existing baseline for the pair
+func (c *Config) SetBaseline(app, env, scanID string) {
+	for i, baseline := range c.Baselines {
+		if strings.EqualFold(baseline.App, app) && strings.EqualFold(baseline.Env, env) {
+			c.Baselines[i].ScanID = scanID
+			return
+		}
+	}
+	c.Baselines = append(c.Baselines, Baseline{App: app, Env: env, ScanID: scanID})
+}
+
+// FindBaseline returns the baseline for a scan's environment, matching the
+// application by either ID or name
+func (c *Config) FindBaseline(appID, appName, env string) (Baseline, bool) {
+	for _, baseline := range c.Baselines {
+		if !strings.EqualFold(baseline.Env, env) {
+			continue
+		}
+		if (appID != "" && strings.EqualFold(baseline.App, appID)) ||
+			(appName != "" && strings.EqualFold(baseline.App, appName)) {
+			return baseline, true
+		}
+	}
+	return Baseline{}, false
+}
+
+// SetDefaultEnv stores the default environment for an organization. An empty
+// env removes the default.
+func (c *Config) SetDefaultEnv(orgID, env string) {
+	if env == "" {
+		delete(c.OrgEnvs, orgID)
+		return
+	}
+	if c.OrgEnvs == nil {
+		c.OrgEnvs = make(map[string]string)
+	}
+	c.OrgEnvs[orgID] = env
+}
+
+// DefaultEnv returns the default environment for an organization, or "" if none is set
+func (c *Config) DefaultEnv(orgID string) string {
+	return c.OrgEnvs[orgID]
+}
+
+// ResolveBaseURL determines the API base URL to use. Precedence is an explicit
+// base URL, then a named instance, then the configured base_url. An empty result
+// means the client default should be used.
+func (c *Config) ResolveBaseURL(baseURL, instance string) (string, error) {
+	if baseURL != "" {
+		return strings.TrimRight(baseURL, "/"), nil
+	}
+
+	if instance != "" {
+		if url, ok := c.Instances[instance]; ok && url != "" {
+			return strings.TrimRight(url, "/"), nil
+		}
+		if url, ok := BuiltinInstances[instance]; ok {
+			return url, nil
+		}
+		return "", fmt.Errorf("unknown instance %q (known: %s)", instance, strings.Join(c.InstanceNames(), ", "))
+	}
+
+	return strings.TrimRight(c.BaseURL, "/"), nil
+}
+
+// InstanceNames returns the sorted names of all built-in and configured instances
+func (c *Config) InstanceNames() []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(BuiltinInstances)+len(c.Instances))
+	for name := range BuiltinInstances {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range c.Instances {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
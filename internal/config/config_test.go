@@ -8,6 +8,9 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// testBaseURL is the API the test tokens are issued by
+const testBaseURL = "https://api.stackhawk.com"
+
 type ConfigTestSuite struct {
 	suite.Suite
 }
@@ -16,15 +19,12 @@ func (suite *ConfigTestSuite) TestNewConfig() {
 	cfg := &Config{
 		APIKey: "test-api-key",
 		OrgID:  "test-org-id",
-		JWT: &JWT{
-			Token:     "test-jwt-token",
-			ExpiresAt: time.Now().Add(1 * time.Hour),
-		},
 	}
+	cfg.SetJWT(testBaseURL, "test-jwt-token", time.Now().Add(1*time.Hour))
 
 	assert.Equal(suite.T(), "test-api-key", cfg.APIKey)
 	assert.Equal(suite.T(), "test-org-id", cfg.OrgID)
-	assert.NotNil(suite.T(), cfg.JWT)
+	assert.NotNil(suite.T(), cfg.JWTFor(testBaseURL))
 }
 
 func (suite *ConfigTestSuite) TestJWT_IsExpired() {
@@ -51,21 +51,46 @@ func (suite *ConfigTestSuite) TestConfig_NeedsJWTRefresh() {
 	cfg := &Config{APIKey: "test-key"}
 
 	// No JWT
-	assert.True(suite.T(), cfg.NeedsJWTRefresh())
+	assert.True(suite.T(), cfg.NeedsJWTRefresh(testBaseURL))
 
 	// Expired JWT
-	cfg.JWT = &JWT{
-		Token:     "expired",
-		ExpiresAt: time.Now().Add(-1 * time.Hour),
-	}
-	assert.True(suite.T(), cfg.NeedsJWTRefresh())
+	cfg.SetJWT(testBaseURL, "expired", time.Now().Add(-1*time.Hour))
+	assert.True(suite.T(), cfg.NeedsJWTRefresh(testBaseURL))
 
 	// Valid JWT
-	cfg.JWT = &JWT{
-		Token:     "valid",
-		ExpiresAt: time.Now().Add(1 * time.Hour),
-	}
-	assert.False(suite.T(), cfg.NeedsJWTRefresh())
+	cfg.SetJWT(testBaseURL, "valid", time.Now().Add(1*time.Hour))
+	assert.False(suite.T(), cfg.NeedsJWTRefresh(testBaseURL))
+
+	// A token for one API isn't used for another
+	assert.True(suite.T(), cfg.NeedsJWTRefresh("https://eu.api.example.com"))
+}
+
+func (suite *ConfigTestSuite) TestJWT_PerBaseURL() {
+	cfg := &Config{APIKey: "test-key"}
+	cfg.SetJWT(testBaseURL+"/", "prod-token", time.Now().Add(time.Hour))
+	cfg.SetJWT("https://eu.api.example.com", "eu-token", time.Now().Add(time.Hour))
+
+	assert.Equal(suite.T(), "prod-token", cfg.JWTFor(testBaseURL).Token, "trailing slashes don't matter")
+	assert.Equal(suite.T(), "eu-token", cfg.JWTFor("https://eu.api.example.com").Token)
+	assert.Nil(suite.T(), cfg.JWTFor("https://other.example.com"))
+
+	cfg.ClearJWT("https://eu.api.example.com")
+	assert.Nil(suite.T(), cfg.JWTFor("https://eu.api.example.com"))
+	assert.Equal(suite.T(), "prod-token", cfg.JWTFor(testBaseURL).Token, "clearing one API's token keeps the others")
+}
+
+func (suite *ConfigTestSuite) TestMigrateJWT() {
+	legacy := &JWT{Token: "old-token", ExpiresAt: time.Now().Add(time.Hour)}
+
+	cfg := &Config{APIKey: "test-key", JWT: legacy}
+	cfg.migrateJWT()
+	assert.Nil(suite.T(), cfg.JWT)
+	assert.Equal(suite.T(), "old-token", cfg.JWTFor(BuiltinInstances["prod"]).Token)
+
+	cfg = &Config{APIKey: "test-key", BaseURL: "https://eu.api.example.com/", JWT: legacy}
+	cfg.migrateJWT()
+	assert.Equal(suite.T(), "old-token", cfg.JWTFor("https://eu.api.example.com").Token)
+	assert.Nil(suite.T(), cfg.JWTFor(BuiltinInstances["prod"]))
 }
 
 func (suite *ConfigTestSuite) TestConfig_HasValidCredentials() {
@@ -79,33 +104,22 @@ func (suite *ConfigTestSuite) TestConfig_HasValidCredentials() {
 	assert.True(suite.T(), cfg.HasValidCredentials())
 
 	// API key with expired JWT - still valid credentials (JWT state doesn't matter)
-	cfg.JWT = &JWT{
-		Token:     "expired",
-		ExpiresAt: time.Now().Add(-1 * time.Hour),
-	}
+	cfg.SetJWT(testBaseURL, "expired", time.Now().Add(-1*time.Hour))
 	assert.True(suite.T(), cfg.HasValidCredentials())
 
 	// API key with valid JWT - still valid credentials
-	cfg.JWT = &JWT{
-		Token:     "valid",
-		ExpiresAt: time.Now().Add(1 * time.Hour),
-	}
+	cfg.SetJWT(testBaseURL, "valid", time.Now().Add(1*time.Hour))
 	assert.True(suite.T(), cfg.HasValidCredentials())
 }
 
 func (suite *ConfigTestSuite) TestSetAPIKey() {
-	cfg := &Config{
-		APIKey: "old-key",
-		JWT: &JWT{
-			Token:     "old-token",
-			ExpiresAt: time.Now().Add(1 * time.Hour),
-		},
-	}
+	cfg := &Config{APIKey: "old-key"}
+	cfg.SetJWT(testBaseURL, "old-token", time.Now().Add(1*time.Hour))
 
 	cfg.SetAPIKey("new-key")
 
 	assert.Equal(suite.T(), "new-key", cfg.APIKey)
-	assert.Nil(suite.T(), cfg.JWT) // JWT should be cleared when API key changes
+	assert.Nil(suite.T(), cfg.JWTFor(testBaseURL)) // JWTs should be cleared when API key changes
 }
 
 func (suite *ConfigTestSuite) TestOrgIDManagement() {
@@ -131,6 +145,103 @@ func (suite *ConfigTestSuite) TestGetConfigPaths() {
 	assert.Contains(suite.T(), configFile, "config.yaml")
 }
 
+func (suite *ConfigTestSuite) TestResolveBaseURL_Precedence() {
+	cfg := &Config{
+		BaseURL: "https://config.example.com/",
+		Instances: map[string]string{
+			"eu":      "https://eu.example.com",
+			"staging": "https://staging.example.com/",
+		},
+	}
+
+	// Explicit base URL wins over everything
+	url, err := cfg.ResolveBaseURL("https://explicit.example.com", "eu")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://explicit.example.com", url)
+
+	// Named instance wins over the config default
+	url, err = cfg.ResolveBaseURL("", "staging")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://staging.example.com", url)
+
+	// Built-in instances are always available
+	url, err = cfg.ResolveBaseURL("", "prod")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://api.stackhawk.com", url)
+
+	// Config default applies when no flags are given
+	url, err = cfg.ResolveBaseURL("", "")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://config.example.com", url)
+
+	// Nothing configured leaves the client default in place
+	url, err = (&Config{}).ResolveBaseURL("", "")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), url)
+}
+
+func (suite *ConfigTestSuite) TestResolveBaseURL_UnknownInstance() {
+	cfg := &Config{Instances: map[string]string{"eu": "https://eu.example.com"}}
+
+	_, err := cfg.ResolveBaseURL("", "mars")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "unknown instance")
+	assert.Contains(suite.T(), err.Error(), "eu, prod")
+}
+
+func (suite *ConfigTestSuite) TestCheckFile() {
+	assert.NoError(suite.T(), CheckFile(nil))
+	assert.NoError(suite.T(), CheckFile([]byte("api_key: key\norg_envs:\n  org-1: production\n")))
+
+	err := CheckFile([]byte("api_key: key\nprofiles: {}\n"))
+	assert.ErrorIs(suite.T(), err, ErrUnknownFields)
+	assert.Contains(suite.T(), err.Error(), "profiles")
+
+	err = CheckFile([]byte("api_key: [\n"))
+	assert.Error(suite.T(), err)
+	assert.NotErrorIs(suite.T(), err, ErrUnknownFields)
+}
+
+func (suite *ConfigTestSuite) TestBaselines() {
+	cfg := &Config{}
+
+	cfg.SetBaseline("Billing API", "Production", "scan-1")
+	cfg.SetBaseline("app-2", "dev", "scan-2")
+
+	// Matches by name or ID, case-insensitively
+	baseline, ok := cfg.FindBaseline("app-1", "billing api", "production")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "scan-1", baseline.ScanID)
+
+	baseline, ok = cfg.FindBaseline("app-2", "Other Name", "Dev")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "scan-2", baseline.ScanID)
+
+	_, ok = cfg.FindBaseline("app-1", "Billing API", "staging")
+	assert.False(suite.T(), ok)
+
+	// Setting the same app/env replaces the pinned scan
+	cfg.SetBaseline("billing api", "PRODUCTION", "scan-3")
+	assert.Len(suite.T(), cfg.Baselines, 2)
+	baseline, _ = cfg.FindBaseline("", "Billing API", "Production")
+	assert.Equal(suite.T(), "scan-3", baseline.ScanID)
+}
+
+func (suite *ConfigTestSuite) TestDefaultEnv() {
+	cfg := &Config{}
+	assert.Equal(suite.T(), "", cfg.DefaultEnv("org-1"))
+
+	cfg.SetDefaultEnv("org-1", "Production")
+	cfg.SetDefaultEnv("org-2", "Staging")
+	assert.Equal(suite.T(), "Production", cfg.DefaultEnv("org-1"))
+	assert.Equal(suite.T(), "Staging", cfg.DefaultEnv("org-2"))
+
+	// An empty env removes the default
+	cfg.SetDefaultEnv("org-1", "")
+	assert.Equal(suite.T(), "", cfg.DefaultEnv("org-1"))
+	assert.Len(suite.T(), cfg.OrgEnvs, 1)
+}
+
 func TestConfigTestSuite(t *testing.T) {
 	suite.Run(t, new(ConfigTestSuite))
 }
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// envTemplate remembers a config value that referenced environment variables,
+// so Save can write the reference back instead of the expanded secret
+type envTemplate struct {
+	raw      string
+	expanded string
+}
+
+// expandableFields returns the string fields that may reference environment
+// variables, keyed by their config file name
+func (c *Config) expandableFields() map[string]*string {
+	return map[string]*string{
+		"api_key":         &c.APIKey,
+		"org_id":          &c.OrgID,
+		"base_url":        &c.BaseURL,
+		"output_format":   &c.OutputFormat,
+		"request_timeout": &c.RequestTimeout,
+		"max_retries":     &c.MaxRetries,
+		"retry_delay":     &c.RetryDelay,
+		"proxy":           &c.Proxy,
+		"timezone":        &c.Timezone,
+	}
+}
+
+// expandEnv replaces ${VAR} and $VAR references in the expandable fields with
+// values from the environment. Unset variables expand to "". Values without a
+// "$" are left untouched.
+func (c *Config) expandEnv() {
+	for name, field := range c.expandableFields() {
+		if !strings.Contains(*field, "$") {
+			continue
+		}
+
+		expanded := os.ExpandEnv(*field)
+		if c.templates == nil {
+			c.templates = make(map[string]envTemplate)
+		}
+		c.templates[name] = envTemplate{raw: *field, expanded: expanded}
+		*field = expanded
+	}
+}
+
+// withTemplates returns a copy of the config with expanded values replaced by
+// their original references. Fields changed since Load keep their new value.
+func (c *Config) withTemplates() *Config {
+	saved := *c
+	fields := saved.expandableFields()
+	for name, tmpl := range c.templates {
+		if field := fields[name]; *field == tmpl.expanded {
+			*field = tmpl.raw
+		}
+	}
+	return &saved
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EnvTestSuite struct {
+	suite.Suite
+	origDir  string
+	origFile string
+}
+
+func (suite *EnvTestSuite) SetupTest() {
+	// Point Load and Save at a temporary config file
+	suite.origDir, suite.origFile = configDir, configFile
+	configDir = suite.T().TempDir()
+	configFile = filepath.Join(configDir, "config.yaml")
+}
+
+func (suite *EnvTestSuite) TearDownTest() {
+	configDir, configFile = suite.origDir, suite.origFile
+}
+
+func (suite *EnvTestSuite) writeConfig(content string) {
+	require.NoError(suite.T(), os.WriteFile(configFile, []byte(content), 0600))
+}
+
+func (suite *EnvTestSuite) TestLoad_ExpandsSetVariables() {
+	suite.T().Setenv("HAWKOP_TEST_API_KEY", "secret-key")
+	suite.T().Setenv("HAWKOP_TEST_HOST", "eu.api.example.com")
+	suite.writeConfig("api_key: ${HAWKOP_TEST_API_KEY}\nbase_url: https://$HAWKOP_TEST_HOST\norg_id: literal-org\n")
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "secret-key", cfg.APIKey)
+	assert.Equal(suite.T(), "https://eu.api.example.com", cfg.BaseURL)
+	assert.Equal(suite.T(), "literal-org", cfg.OrgID)
+}
+
+func (suite *EnvTestSuite) TestLoad_UnsetVariablesExpandToEmpty() {
+	suite.T().Setenv("HAWKOP_TEST_UNSET", "")
+	require.NoError(suite.T(), os.Unsetenv("HAWKOP_TEST_UNSET"))
+	suite.writeConfig("api_key: ${HAWKOP_TEST_UNSET}\n")
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", cfg.APIKey)
+	assert.False(suite.T(), cfg.HasValidCredentials())
+}
+
+func (suite *EnvTestSuite) TestSave_KeepsReferences() {
+	suite.T().Setenv("HAWKOP_TEST_API_KEY", "secret-key")
+	suite.writeConfig("api_key: ${HAWKOP_TEST_API_KEY}\nbase_url: ${HAWKOP_TEST_BASE_URL}\n")
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+
+	// Unchanged fields keep their reference; changed fields are saved as set
+	cfg.SetOrgID("new-org")
+	cfg.BaseURL = "https://api.example.com"
+	require.NoError(suite.T(), cfg.Save())
+
+	data, err := os.ReadFile(configFile)
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), "api_key: ${HAWKOP_TEST_API_KEY}")
+	assert.Contains(suite.T(), string(data), "base_url: https://api.example.com")
+	assert.Contains(suite.T(), string(data), "org_id: new-org")
+	assert.NotContains(suite.T(), string(data), "secret-key")
+
+	// The in-memory config still holds the expanded value
+	assert.Equal(suite.T(), "secret-key", cfg.APIKey)
+}
+
+func TestEnvTestSuite(t *testing.T) {
+	suite.Run(t, new(EnvTestSuite))
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"hawkop/internal/format"
+)
+
+// ErrUnknownKey is returned by Get and Set for keys that are not settable
+var ErrUnknownKey = errors.New("unknown config key")
+
+// OutputFormats are the accepted values for the output_format key
+var OutputFormats = []string{"table", "json", "tsv", "csv", "markdown"}
+
+// Limits on the max_retries and retry_delay settings and the matching flags
+const (
+	MaxRetriesLimit = 10
+	MaxRetryDelay   = 30 * time.Second
+)
+
+// configKey describes a settable configuration key
+type configKey struct {
+	get      func(c *Config) string
+	set      func(c *Config, value string)
+	validate func(value string) error // optional
+}
+
+// configKeys maps key names to their accessors and validation
+var configKeys = map[string]configKey{
+	"org_id": {
+		// Organization ID format is validated by the API package before Set
+		get: func(c *Config) string { return c.OrgID },
+		set: func(c *Config, value string) { c.OrgID = value },
+	},
+	"base_url": {
+		get:      func(c *Config) string { return c.BaseURL },
+		set:      func(c *Config, value string) { c.BaseURL = strings.TrimRight(value, "/") },
+		validate: validateBaseURL,
+	},
+	"output_format": {
+		get: func(c *Config) string { return c.OutputFormat },
+		set: func(c *Config, value string) { c.OutputFormat = strings.ToLower(value) },
+		validate: func(value string) error {
+			for _, format := range OutputFormats {
+				if strings.EqualFold(value, format) {
+					return nil
+				}
+			}
+			return fmt.Errorf("must be one of: %s", strings.Join(OutputFormats, ", "))
+		},
+	},
+	"request_timeout": {
+		get: func(c *Config) string { return c.RequestTimeout },
+		set: func(c *Config, value string) { c.RequestTimeout = value },
+		validate: func(value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("must be a positive duration such as 30s or 2m")
+			}
+			return nil
+		},
+	},
+	"max_retries": {
+		get: func(c *Config) string { return c.MaxRetries },
+		set: func(c *Config, value string) { c.MaxRetries = value },
+		validate: func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("must be a whole number from 0 to %d", MaxRetriesLimit)
+			}
+			return ValidateRetries(n)
+		},
+	},
+	"retry_delay": {
+		get: func(c *Config) string { return c.RetryDelay },
+		set: func(c *Config, value string) { c.RetryDelay = value },
+		validate: func(value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("must be a duration such as 500ms or 2s, up to %s", MaxRetryDelay)
+			}
+			return ValidateRetryDelay(d)
+		},
+	},
+	"proxy": {
+		get:      func(c *Config) string { return c.Proxy },
+		set:      func(c *Config, value string) { c.Proxy = value },
+		validate: validateProxyURL,
+	},
+	"timezone": {
+		get: func(c *Config) string { return c.Timezone },
+		set: func(c *Config, value string) { c.Timezone = value },
+		validate: func(value string) error {
+			if _, err := time.LoadLocation(value); err != nil {
+				return fmt.Errorf("must be an IANA time zone such as UTC or America/New_York")
+			}
+			return nil
+		},
+	},
+}
+
+// Keys returns the names of the keys supported by Get and Set, in display order
+func Keys() []string {
+	return []string{"org_id", "base_url", "output_format", "request_timeout", "max_retries", "retry_delay", "proxy", "timezone"}
+}
+
+// Get returns the value of a configuration key
+func (c *Config) Get(key string) (string, error) {
+	k, ok := configKeys[key]
+	if !ok {
+		return "", unknownKeyError(key)
+	}
+	return k.get(c), nil
+}
+
+// Set validates and updates a configuration key. An empty value clears the key.
+func (c *Config) Set(key, value string) error {
+	k, ok := configKeys[key]
+	if !ok {
+		return unknownKeyError(key)
+	}
+
+	if value != "" && k.validate != nil {
+		if err := k.validate(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+
+	k.set(c, value)
+	return nil
+}
+
+// Timeout returns the configured request timeout, or zero if none is set
+func (c *Config) Timeout() time.Duration {
+	if c.RequestTimeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.RequestTimeout)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// ValidateRetries checks a retry count against the 0 to MaxRetriesLimit range
+func ValidateRetries(n int) error {
+	if n < 0 || n > MaxRetriesLimit {
+		return fmt.Errorf("must be from 0 to %d, got %d", MaxRetriesLimit, n)
+	}
+	return nil
+}
+
+// ValidateRetryDelay checks a retry delay against the 0 to MaxRetryDelay range
+func ValidateRetryDelay(d time.Duration) error {
+	if d < 0 || d > MaxRetryDelay {
+		return fmt.Errorf("must be from 0s to %s, got %s", MaxRetryDelay, d)
+	}
+	return nil
+}
+
+// Retries returns the configured max_retries. ok is false when it is unset or
+// invalid, so the default applies.
+func (c *Config) Retries() (n int, ok bool) {
+	n, err := strconv.Atoi(c.MaxRetries)
+	if err != nil || ValidateRetries(n) != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// RetryBackoff returns the configured retry_delay. ok is false when it is unset
+// or invalid, so the default applies.
+func (c *Config) RetryBackoff() (d time.Duration, ok bool) {
+	d, err := time.ParseDuration(c.RetryDelay)
+	if err != nil || ValidateRetryDelay(d) != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+func unknownKeyError(key string) error {
+	if suggestion, ok := format.Suggest(key, Keys()); ok {
+		return fmt.Errorf("%w %q; did you mean %q? (supported: %s)", ErrUnknownKey, key, suggestion, strings.Join(Keys(), ", "))
+	}
+	return fmt.Errorf("%w %q (supported: %s)", ErrUnknownKey, key, strings.Join(Keys(), ", "))
+}
+
+func validateProxyURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("must be a proxy URL such as http://proxy.example.com:8080")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return nil
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q; use http, https, or socks5", u.Scheme)
+	}
+}
+
+func validateBaseURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an http or https URL such as https://api.stackhawk.com")
+	}
+	return nil
+}
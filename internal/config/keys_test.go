@@ -0,0 +1,181 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeysTestSuite struct {
+	suite.Suite
+	origDir  string
+	origFile string
+}
+
+func (suite *KeysTestSuite) SetupTest() {
+	// Point Load and Save at a temporary config file
+	suite.origDir, suite.origFile = configDir, configFile
+	configDir = suite.T().TempDir()
+	configFile = filepath.Join(configDir, "config.yaml")
+}
+
+func (suite *KeysTestSuite) TearDownTest() {
+	configDir, configFile = suite.origDir, suite.origFile
+}
+
+func (suite *KeysTestSuite) TestSetAndGet() {
+	cfg := &Config{}
+
+	require.NoError(suite.T(), cfg.Set("org_id", "test-org-id"))
+	require.NoError(suite.T(), cfg.Set("base_url", "https://eu.api.example.com/"))
+	require.NoError(suite.T(), cfg.Set("output_format", "JSON"))
+	require.NoError(suite.T(), cfg.Set("request_timeout", "45s"))
+	require.NoError(suite.T(), cfg.Set("proxy", "socks5://proxy.example.com:1080"))
+	require.NoError(suite.T(), cfg.Set("timezone", "UTC"))
+
+	for key, want := range map[string]string{
+		"org_id":          "test-org-id",
+		"base_url":        "https://eu.api.example.com",
+		"output_format":   "json",
+		"request_timeout": "45s",
+		"proxy":           "socks5://proxy.example.com:1080",
+		"timezone":        "UTC",
+	} {
+		got, err := cfg.Get(key)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), want, got, key)
+	}
+	assert.Equal(suite.T(), 45*time.Second, cfg.Timeout())
+
+	// Formats only some commands support are accepted too
+	for _, format := range []string{"tsv", "csv", "markdown"} {
+		assert.NoError(suite.T(), cfg.Set("output_format", format))
+	}
+
+	// An empty value clears the key
+	require.NoError(suite.T(), cfg.Set("output_format", ""))
+	assert.Empty(suite.T(), cfg.OutputFormat)
+}
+
+func (suite *KeysTestSuite) TestSet_Validation() {
+	cfg := &Config{}
+
+	assert.Error(suite.T(), cfg.Set("base_url", "api.stackhawk.com"))
+	assert.Error(suite.T(), cfg.Set("base_url", "ftp://api.stackhawk.com"))
+	assert.Error(suite.T(), cfg.Set("output_format", "xml"))
+	assert.Error(suite.T(), cfg.Set("request_timeout", "30"))
+	assert.Error(suite.T(), cfg.Set("request_timeout", "-5s"))
+	assert.Error(suite.T(), cfg.Set("max_retries", "11"))
+	assert.Error(suite.T(), cfg.Set("max_retries", "-1"))
+	assert.Error(suite.T(), cfg.Set("max_retries", "three"))
+	assert.Error(suite.T(), cfg.Set("retry_delay", "31s"))
+	assert.Error(suite.T(), cfg.Set("retry_delay", "500"))
+	assert.Error(suite.T(), cfg.Set("proxy", "proxy.example.com:8080"))
+	assert.Error(suite.T(), cfg.Set("proxy", "ftp://proxy.example.com"))
+	assert.Error(suite.T(), cfg.Set("timezone", "Mars/Olympus_Mons"))
+
+	// Rejected values leave the config untouched
+	assert.Empty(suite.T(), cfg.BaseURL)
+	assert.Empty(suite.T(), cfg.OutputFormat)
+	assert.Zero(suite.T(), cfg.Timeout())
+	assert.Empty(suite.T(), cfg.MaxRetries)
+	assert.Empty(suite.T(), cfg.RetryDelay)
+	assert.Empty(suite.T(), cfg.Proxy)
+	assert.Empty(suite.T(), cfg.Timezone)
+}
+
+func (suite *KeysTestSuite) TestRetrySettings() {
+	cfg := &Config{}
+
+	_, ok := cfg.Retries()
+	assert.False(suite.T(), ok, "unset max_retries leaves the default")
+	_, ok = cfg.RetryBackoff()
+	assert.False(suite.T(), ok, "unset retry_delay leaves the default")
+
+	require.NoError(suite.T(), cfg.Set("max_retries", "0"))
+	require.NoError(suite.T(), cfg.Set("retry_delay", "30s"))
+
+	n, ok := cfg.Retries()
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 0, n)
+	d, ok := cfg.RetryBackoff()
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 30*time.Second, d)
+
+	// A hand-edited value out of range is ignored rather than used
+	cfg.MaxRetries = "50"
+	_, ok = cfg.Retries()
+	assert.False(suite.T(), ok)
+}
+
+func (suite *KeysTestSuite) TestUnknownKey() {
+	cfg := &Config{}
+
+	err := cfg.Set("api_secret", "value")
+	assert.ErrorIs(suite.T(), err, ErrUnknownKey)
+	assert.Contains(suite.T(), err.Error(), "request_timeout")
+
+	_, err = cfg.Get("nope")
+	assert.ErrorIs(suite.T(), err, ErrUnknownKey)
+}
+
+func (suite *KeysTestSuite) TestUnknownKey_Suggestion() {
+	cfg := &Config{}
+
+	err := cfg.Set("org_di", "value")
+	assert.ErrorIs(suite.T(), err, ErrUnknownKey)
+	assert.Contains(suite.T(), err.Error(), `did you mean "org_id"?`)
+
+	_, err = cfg.Get("nope")
+	assert.NotContains(suite.T(), err.Error(), "did you mean")
+}
+
+func (suite *KeysTestSuite) TestSet_RoundTripPreservesOtherFields() {
+	original := &Config{
+		APIKey:    "test-api-key",
+		OrgID:     "test-org-id",
+		Instances: map[string]string{"eu": "https://eu.api.example.com"},
+	}
+	original.SetJWT(testBaseURL, "test-jwt-token", time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+	require.NoError(suite.T(), original.Save())
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), cfg.Set("request_timeout", "2m"))
+	require.NoError(suite.T(), cfg.Save())
+
+	reloaded, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2m", reloaded.RequestTimeout)
+	assert.Equal(suite.T(), original.APIKey, reloaded.APIKey)
+	assert.Equal(suite.T(), original.OrgID, reloaded.OrgID)
+	assert.Equal(suite.T(), original.Instances, reloaded.Instances)
+	assert.Equal(suite.T(), original.JWTFor(testBaseURL).Token, reloaded.JWTFor(testBaseURL).Token)
+	assert.True(suite.T(), original.JWTFor(testBaseURL).ExpiresAt.Equal(reloaded.JWTFor(testBaseURL).ExpiresAt))
+}
+
+func (suite *KeysTestSuite) TestLoad_MigratesSingleJWT() {
+	legacy := "api_key: test-api-key\njwt:\n  token: old-token\n  expires_at: 2030-01-01T00:00:00Z\n"
+	require.NoError(suite.T(), os.WriteFile(configFile, []byte(legacy), 0600))
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), cfg.JWT)
+	require.NotNil(suite.T(), cfg.JWTFor(BuiltinInstances["prod"]))
+	assert.Equal(suite.T(), "old-token", cfg.JWTFor(BuiltinInstances["prod"]).Token)
+
+	require.NoError(suite.T(), cfg.Save())
+	data, err := os.ReadFile(configFile)
+	require.NoError(suite.T(), err)
+	assert.NotContains(suite.T(), string(data), "\njwt:")
+	assert.Contains(suite.T(), string(data), "jwts:")
+}
+
+func TestKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(KeysTestSuite))
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// ErrInsecurePermissions is wrapped by Load errors when StrictPermissions is set and
+// other users can access the config file
+var ErrInsecurePermissions = errors.New("config file is accessible by other users")
+
+var (
+	// StrictPermissions makes Load refuse a config file other users can access,
+	// instead of warning about it
+	StrictPermissions bool
+	// Warnings receives Load's warnings about the config file
+	Warnings io.Writer = os.Stderr
+)
+
+// warnedPermissions records the files already warned about, so a command that loads
+// the configuration several times warns once
+var (
+	warnedPermissions   = make(map[string]bool)
+	warnedPermissionsMu sync.Mutex
+)
+
+// checkPermissions returns an error wrapping ErrInsecurePermissions if the file's mode
+// grants any access to its group or other users. Platforms without Unix permissions
+// always pass.
+func checkPermissions(path string, info os.FileInfo) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		return fmt.Errorf("%w: %s has mode %04o; it holds your API key, so run: chmod 600 %s",
+			ErrInsecurePermissions, path, perm, path)
+	}
+	return nil
+}
+
+// enforcePermissions applies checkPermissions for Load: an error under
+// StrictPermissions, otherwise a warning written to Warnings once per file
+func enforcePermissions(path string, info os.FileInfo) error {
+	err := checkPermissions(path, info)
+	if err == nil {
+		return nil
+	}
+	if StrictPermissions {
+		return err
+	}
+
+	warnedPermissionsMu.Lock()
+	defer warnedPermissionsMu.Unlock()
+	if !warnedPermissions[path] {
+		warnedPermissions[path] = true
+		fmt.Fprintf(Warnings, "⚠️  %v (or pass --strict-perms to refuse to load it)\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermsTestSuite struct {
+	suite.Suite
+	origDir  string
+	origFile string
+	warnings *bytes.Buffer
+}
+
+func (suite *PermsTestSuite) SetupTest() {
+	if runtime.GOOS == "windows" {
+		suite.T().Skip("config file permissions need Unix file modes")
+	}
+
+	// Point Load and Save at a temporary config file and capture warnings
+	suite.origDir, suite.origFile = configDir, configFile
+	configDir = suite.T().TempDir()
+	configFile = filepath.Join(configDir, "config.yaml")
+
+	origWarnings := Warnings
+	suite.warnings = &bytes.Buffer{}
+	Warnings = suite.warnings
+	suite.T().Cleanup(func() {
+		Warnings = origWarnings
+		StrictPermissions = false
+		delete(warnedPermissions, configFile)
+	})
+}
+
+func (suite *PermsTestSuite) TearDownTest() {
+	configDir, configFile = suite.origDir, suite.origFile
+}
+
+func (suite *PermsTestSuite) writeConfig(mode os.FileMode) {
+	require.NoError(suite.T(), os.WriteFile(configFile, []byte("api_key: secret\n"), mode))
+	// WriteFile's mode is subject to the umask
+	require.NoError(suite.T(), os.Chmod(configFile, mode))
+}
+
+func (suite *PermsTestSuite) TestLoad_PrivateFile() {
+	suite.writeConfig(0600)
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "secret", cfg.APIKey)
+	assert.Empty(suite.T(), suite.warnings.String())
+}
+
+func (suite *PermsTestSuite) TestLoad_WarnsOnceAboutLooseFile() {
+	suite.writeConfig(0644)
+
+	cfg, err := Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "secret", cfg.APIKey)
+	assert.Contains(suite.T(), suite.warnings.String(), "has mode 0644")
+	assert.Contains(suite.T(), suite.warnings.String(), "chmod 600 "+configFile)
+
+	_, err = Load()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, bytes.Count(suite.warnings.Bytes(), []byte("\n")), "each file is warned about once")
+}
+
+func (suite *PermsTestSuite) TestLoad_StrictRefusesLooseFile() {
+	StrictPermissions = true
+
+	for _, mode := range []os.FileMode{0640, 0604, 0620} {
+		suite.writeConfig(mode)
+		cfg, err := Load()
+		assert.Nil(suite.T(), cfg)
+		assert.ErrorIs(suite.T(), err, ErrInsecurePermissions, "mode %04o", mode)
+	}
+
+	suite.writeConfig(0600)
+	_, err := Load()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.warnings.String())
+}
+
+func (suite *PermsTestSuite) TestSave_RestrictsExistingFile() {
+	suite.writeConfig(0666)
+
+	require.NoError(suite.T(), (&Config{APIKey: "new"}).Save())
+
+	info, err := os.Stat(configFile)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestPermsTestSuite(t *testing.T) {
+	suite.Run(t, new(PermsTestSuite))
+}
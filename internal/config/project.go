@@ -0,0 +1,66 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the file hawkop looks for in the working directory and its
+// parents for defaults specific to a project, such as the repository of one app
+const ProjectFileName = ".hawkop.yaml"
+
+// Project holds the defaults a project file sets. They apply below flags given on
+// the command line and above the settings in the config file.
+type Project struct {
+	Org string `yaml:"org,omitempty"`
+	App string `yaml:"app,omitempty"`
+	Env string `yaml:"env,omitempty"`
+
+	// Path is the project file the defaults were read from
+	Path string `yaml:"-"`
+}
+
+// FindProject reads the project file in dir or the nearest of its parent
+// directories that has one. It returns nil when no directory up to the root does.
+func FindProject(dir string) (*Project, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return LoadProject(path)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProject reads the project file at path. Keys hawkop doesn't recognize are an
+// error, so a misspelled setting isn't silently ignored.
+func LoadProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	project := Project{Path: path}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&project); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse project file %s: %w", path, err)
+	}
+	return &project, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProjectTestSuite struct {
+	suite.Suite
+	root string
+}
+
+func (suite *ProjectTestSuite) SetupTest() {
+	suite.root = suite.T().TempDir()
+}
+
+// writeProject writes a project file with content in dir, below the suite's root
+func (suite *ProjectTestSuite) writeProject(dir, content string) string {
+	path := filepath.Join(suite.root, dir, ProjectFileName)
+	require.NoError(suite.T(), os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(suite.T(), os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func (suite *ProjectTestSuite) TestFindProject_WalksUp() {
+	path := suite.writeProject("repo", "org: org-1\napp: Billing API\nenv: Production\n")
+	nested := filepath.Join(suite.root, "repo", "services", "billing")
+	require.NoError(suite.T(), os.MkdirAll(nested, 0755))
+
+	project, err := FindProject(nested)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), project)
+	assert.Equal(suite.T(), Project{Org: "org-1", App: "Billing API", Env: "Production", Path: path}, *project)
+}
+
+func (suite *ProjectTestSuite) TestFindProject_NearestWins() {
+	suite.writeProject("repo", "app: Monorepo\n")
+	path := suite.writeProject("repo/checkout", "app: Checkout\n")
+
+	project, err := FindProject(filepath.Join(suite.root, "repo", "checkout"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Checkout", project.App)
+	assert.Equal(suite.T(), path, project.Path)
+}
+
+func (suite *ProjectTestSuite) TestFindProject_NoneOrEmpty() {
+	// A directory named like the project file isn't one
+	require.NoError(suite.T(), os.MkdirAll(filepath.Join(suite.root, "a", ProjectFileName), 0755))
+	project, err := FindProject(filepath.Join(suite.root, "a"))
+	require.NoError(suite.T(), err)
+	if project != nil {
+		// The temporary directory's ancestors are outside the test's control
+		assert.NotContains(suite.T(), project.Path, suite.root)
+	}
+
+	suite.writeProject("b", "")
+	project, err = FindProject(filepath.Join(suite.root, "b"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Project{Path: filepath.Join(suite.root, "b", ProjectFileName)}, *project)
+}
+
+func (suite *ProjectTestSuite) TestLoadProject_RejectsUnknownKeys() {
+	path := suite.writeProject("repo", "application: Billing API\n")
+
+	_, err := LoadProject(path)
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "failed to parse project file")
+	assert.Contains(suite.T(), err.Error(), "application")
+}
+
+func TestProjectTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectTestSuite))
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue replaces secrets in redacted exports. Import treats it as unset.
+const RedactedValue = "[REDACTED]"
+
+// Export returns the configuration as indented JSON for moving it to another
+// machine. ${VAR} references are exported as written rather than expanded. With
+// redact, the API key and any proxy password are replaced by RedactedValue and the
+// cached JWTs are left out.
+func (c *Config) Export(redact bool) ([]byte, error) {
+	exported := c.withTemplates()
+	if redact {
+		if exported.APIKey != "" {
+			exported.APIKey = RedactedValue
+		}
+		if u, err := url.Parse(exported.Proxy); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				exported.Proxy = RedactedValue
+			}
+		}
+		exported.JWTs = nil
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// ParseExport decodes a configuration written by Export and validates it the same
+// way Set does. Redacted values are dropped, and unknown fields are rejected so a
+// typo doesn't silently lose a setting.
+func ParseExport(data []byte) (*Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var imported Config
+	if err := decoder.Decode(&imported); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	imported.migrateJWT()
+
+	for _, field := range imported.expandableFields() {
+		if *field == RedactedValue {
+			*field = ""
+		}
+	}
+
+	for _, key := range Keys() {
+		value := configKeys[key].get(&imported)
+		// ${VAR} references are only known once expanded on the target machine
+		if value == "" || strings.Contains(value, "$") {
+			continue
+		}
+		if err := imported.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, instanceURL := range imported.Instances {
+		if err := validateBaseURL(instanceURL); err != nil {
+			return nil, fmt.Errorf("invalid URL for instance %q: %w", name, err)
+		}
+	}
+
+	for _, baseline := range imported.Baselines {
+		if baseline.App == "" || baseline.Env == "" || baseline.ScanID == "" {
+			return nil, fmt.Errorf("invalid baseline: app, env, and scan_id are all required")
+		}
+	}
+
+	return &imported, nil
+}
+
+// Merge copies the settings in other over c. Empty values in other leave c's value
+// in place; instances, baselines, and default environments are added or replaced
+// one by one. Changing the API key drops the cached JWTs; other's JWTs are added.
+func (c *Config) Merge(other *Config) {
+	if other.APIKey != "" && other.APIKey != c.APIKey {
+		c.SetAPIKey(other.APIKey)
+	}
+	for baseURL, jwt := range other.JWTs {
+		c.SetJWT(baseURL, jwt.Token, jwt.ExpiresAt)
+	}
+
+	fields := c.expandableFields()
+	for name, value := range other.expandableFields() {
+		if name != "api_key" && *value != "" {
+			*fields[name] = *value
+		}
+	}
+
+	for name, instanceURL := range other.Instances {
+		if c.Instances == nil {
+			c.Instances = make(map[string]string)
+		}
+		c.Instances[name] = instanceURL
+	}
+	for _, baseline := range other.Baselines {
+		c.SetBaseline(baseline.App, baseline.Env, baseline.ScanID)
+	}
+	for orgID, env := range other.OrgEnvs {
+		c.SetDefaultEnv(orgID, env)
+	}
+}
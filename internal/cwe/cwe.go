@@ -0,0 +1,96 @@
+// Package cwe provides human-readable titles for common CWE identifiers
+// so alert output can show more than a bare number.
+package cwe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// titles is a curated subset of CWE entries commonly reported by DAST scans.
+// Unknown IDs fall back to the bare identifier.
+var titles = map[string]string{
+	"16":   "Configuration",
+	"20":   "Improper Input Validation",
+	"22":   "Path Traversal",
+	"74":   "Injection",
+	"77":   "Command Injection",
+	"78":   "OS Command Injection",
+	"79":   "Cross-site Scripting",
+	"89":   "SQL Injection",
+	"90":   "LDAP Injection",
+	"91":   "XML Injection",
+	"93":   "CRLF Injection",
+	"94":   "Code Injection",
+	"98":   "PHP Remote File Inclusion",
+	"113":  "HTTP Response Splitting",
+	"134":  "Format String",
+	"200":  "Information Exposure",
+	"209":  "Information Exposure Through Error Message",
+	"264":  "Permissions, Privileges, and Access Controls",
+	"284":  "Improper Access Control",
+	"285":  "Improper Authorization",
+	"287":  "Improper Authentication",
+	"295":  "Improper Certificate Validation",
+	"311":  "Missing Encryption of Sensitive Data",
+	"319":  "Cleartext Transmission of Sensitive Information",
+	"326":  "Inadequate Encryption Strength",
+	"327":  "Broken or Risky Cryptographic Algorithm",
+	"345":  "Insufficient Verification of Data Authenticity",
+	"352":  "Cross-Site Request Forgery",
+	"359":  "Exposure of Private Personal Information",
+	"384":  "Session Fixation",
+	"434":  "Unrestricted File Upload",
+	"436":  "Interpretation Conflict",
+	"502":  "Deserialization of Untrusted Data",
+	"524":  "Sensitive Information in Cache",
+	"525":  "Browser Cache Containing Sensitive Information",
+	"538":  "Sensitive Information in Externally-Accessible File",
+	"548":  "Directory Listing",
+	"565":  "Cookie Without Validation and Integrity Checking",
+	"601":  "Open Redirect",
+	"611":  "XML External Entity Reference",
+	"613":  "Insufficient Session Expiration",
+	"614":  "Sensitive Cookie Without Secure Attribute",
+	"615":  "Sensitive Information in Source Code Comments",
+	"639":  "Authorization Bypass Through User-Controlled Key",
+	"643":  "XPath Injection",
+	"693":  "Protection Mechanism Failure",
+	"732":  "Incorrect Permission Assignment",
+	"776":  "XML Entity Expansion",
+	"798":  "Use of Hard-coded Credentials",
+	"829":  "Inclusion of Functionality from Untrusted Control Sphere",
+	"863":  "Incorrect Authorization",
+	"917":  "Expression Language Injection",
+	"918":  "Server-Side Request Forgery",
+	"942":  "Permissive Cross-domain Policy",
+	"943":  "NoSQL Injection",
+	"1004": "Sensitive Cookie Without HttpOnly Flag",
+	"1021": "Clickjacking",
+	"1275": "Sensitive Cookie With Improper SameSite Attribute",
+}
+
+// normalize strips an optional "CWE-" prefix so "CWE-89" and "89" match
+func normalize(id string) string {
+	id = strings.TrimSpace(id)
+	if len(id) > 4 && strings.EqualFold(id[:4], "CWE-") {
+		id = id[4:]
+	}
+	return id
+}
+
+// Title returns the name of a CWE, accepting IDs with or without the "CWE-" prefix
+func Title(id string) (string, bool) {
+	title, ok := titles[normalize(id)]
+	return title, ok
+}
+
+// Label formats a CWE ID with its title, e.g. "CWE-89 (SQL Injection)".
+// Unknown IDs are returned unchanged.
+func Label(id string) string {
+	title, ok := Title(id)
+	if !ok {
+		return id
+	}
+	return fmt.Sprintf("CWE-%s (%s)", normalize(id), title)
+}
@@ -0,0 +1,30 @@
+package cwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitle(t *testing.T) {
+	title, ok := Title("89")
+	assert.True(t, ok)
+	assert.Equal(t, "SQL Injection", title)
+
+	title, ok = Title("cwe-79")
+	assert.True(t, ok)
+	assert.Equal(t, "Cross-site Scripting", title)
+
+	_, ok = Title("99999")
+	assert.False(t, ok)
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "CWE-89 (SQL Injection)", Label("89"))
+	assert.Equal(t, "CWE-89 (SQL Injection)", Label("CWE-89"))
+
+	// Unknown or missing IDs fall back to the bare value
+	assert.Equal(t, "99999", Label("99999"))
+	assert.Equal(t, "CWE-99999", Label("CWE-99999"))
+	assert.Equal(t, "", Label(""))
+}
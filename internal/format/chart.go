@@ -0,0 +1,94 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BarBlock is the character used to draw chart bars
+const BarBlock = "█"
+
+// MinChartWidth is the narrowest width a chart will be rendered at
+const MinChartWidth = 20
+
+// BarChart renders a horizontal bar chart of labeled counts
+type BarChart struct {
+	width  int
+	labels []string
+	values []int
+}
+
+// NewBarChart creates a new bar chart that fits within the given total width
+func NewBarChart(width int) *BarChart {
+	if width < MinChartWidth {
+		width = MinChartWidth
+	}
+	return &BarChart{
+		width:  width,
+		labels: make([]string, 0),
+		values: make([]int, 0),
+	}
+}
+
+// AddBar adds a labeled bar to the chart
+func (c *BarChart) AddBar(label string, value int) {
+	if value < 0 {
+		value = 0
+	}
+	c.labels = append(c.labels, label)
+	c.values = append(c.values, value)
+}
+
+// ScaleBar returns the bar length for value relative to max within width columns.
+// Non-zero values always get at least one block so they remain visible.
+func ScaleBar(value, max, width int) int {
+	if value <= 0 || max <= 0 || width <= 0 {
+		return 0
+	}
+	if value >= max {
+		return width
+	}
+
+	length := (value*width + max/2) / max
+	if length == 0 {
+		length = 1
+	}
+	return length
+}
+
+// Render returns the formatted chart as a string
+func (c *BarChart) Render() string {
+	if len(c.labels) == 0 {
+		return ""
+	}
+
+	// Calculate label and count column widths
+	labelWidth := 0
+	countWidth := 0
+	maxValue := 0
+	for i, label := range c.labels {
+		if len(label) > labelWidth {
+			labelWidth = len(label)
+		}
+		if n := len(fmt.Sprintf("%d", c.values[i])); n > countWidth {
+			countWidth = n
+		}
+		if c.values[i] > maxValue {
+			maxValue = c.values[i]
+		}
+	}
+
+	// Remaining space goes to the bars: "<label>  <bar> <count>"
+	barWidth := c.width - labelWidth - countWidth - 3
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	var result strings.Builder
+	for i, label := range c.labels {
+		bar := strings.Repeat(BarBlock, ScaleBar(c.values[i], maxValue, barWidth))
+		result.WriteString(fmt.Sprintf("%-*s  %s %d\n", labelWidth, label, bar, c.values[i]))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,62 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChartTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ChartTestSuite) TestScaleBar() {
+	// Max value fills the width
+	assert.Equal(suite.T(), 40, ScaleBar(10, 10, 40))
+
+	// Proportional scaling with rounding
+	assert.Equal(suite.T(), 20, ScaleBar(5, 10, 40))
+	assert.Equal(suite.T(), 13, ScaleBar(1, 3, 40))
+
+	// Small non-zero values stay visible
+	assert.Equal(suite.T(), 1, ScaleBar(1, 1000, 40))
+
+	// Zero and invalid inputs
+	assert.Equal(suite.T(), 0, ScaleBar(0, 10, 40))
+	assert.Equal(suite.T(), 0, ScaleBar(5, 0, 40))
+	assert.Equal(suite.T(), 0, ScaleBar(5, 10, 0))
+}
+
+func (suite *ChartTestSuite) TestRender_EmptyChart() {
+	chart := NewBarChart(80)
+	assert.Equal(suite.T(), "", chart.Render())
+}
+
+func (suite *ChartTestSuite) TestRender_WithData() {
+	chart := NewBarChart(40)
+	chart.AddBar("High", 10)
+	chart.AddBar("Medium", 5)
+	chart.AddBar("Low", 0)
+
+	lines := strings.Split(strings.TrimSuffix(chart.Render(), "\n"), "\n")
+	assert.Len(suite.T(), lines, 3)
+
+	// Bar width is 40 - label(6) - count(2) - 3 = 29
+	assert.Equal(suite.T(), 29, strings.Count(lines[0], BarBlock))
+	assert.Equal(suite.T(), 15, strings.Count(lines[1], BarBlock))
+	assert.Equal(suite.T(), 0, strings.Count(lines[2], BarBlock))
+
+	assert.True(suite.T(), strings.HasPrefix(lines[0], "High    "))
+	assert.True(suite.T(), strings.HasSuffix(lines[0], " 10"))
+}
+
+func (suite *ChartTestSuite) TestNewBarChart_MinimumWidth() {
+	chart := NewBarChart(5)
+	assert.Equal(suite.T(), MinChartWidth, chart.width)
+}
+
+func TestChartTestSuite(t *testing.T) {
+	suite.Run(t, new(ChartTestSuite))
+}
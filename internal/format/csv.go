@@ -0,0 +1,21 @@
+package format
+
+import (
+	"encoding/csv"
+	"strings"
+)
+
+// CSV returns the table as comma-separated values with a header line, quoting
+// cells that contain commas, quotes, or line breaks. Footers are left out.
+func (t *TableWriter) CSV() string {
+	var result strings.Builder
+	writer := csv.NewWriter(&result)
+
+	// Writing to a strings.Builder can't fail
+	_ = writer.Write(t.displayHeaders())
+	for _, row := range t.rows {
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+	return result.String()
+}
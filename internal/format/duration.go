@@ -0,0 +1,74 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"hawkop/internal/number"
+)
+
+// ParseDuration normalizes a duration the API may send as seconds, in any form
+// number.Parse accepts, or as a Go duration string such as "1m30s".
+func ParseDuration(value interface{}) (time.Duration, bool) {
+	if d, ok := value.(time.Duration); ok {
+		return d, true
+	}
+	if seconds, ok := number.Parse(value); ok {
+		return secondsToDuration(seconds)
+	}
+	if s, ok := stringForm(value); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// secondsToDuration converts seconds to a Duration, rejecting NaN, infinities, and
+// values too large for a Duration
+func secondsToDuration(seconds float64) (time.Duration, bool) {
+	nanos := seconds * float64(time.Second)
+	if math.IsNaN(nanos) || math.IsInf(nanos, 0) || math.Abs(nanos) >= float64(math.MaxInt64) {
+		return 0, false
+	}
+	return time.Duration(nanos), true
+}
+
+// FormatDuration renders a duration rounded to whole seconds, omitting zero
+// units, e.g. "45s", "1m30s", "2h", "1h5m"
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d <= 0 {
+		return "0s"
+	}
+
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+
+	var result strings.Builder
+	if hours > 0 {
+		result.WriteString(fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 {
+		result.WriteString(fmt.Sprintf("%dm", minutes))
+	}
+	if seconds > 0 {
+		result.WriteString(fmt.Sprintf("%ds", seconds))
+	}
+	return result.String()
+}
+
+// stringForm returns the text of strings and fmt.Stringer values such as json.Number
+func stringForm(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case fmt.Stringer:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
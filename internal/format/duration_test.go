@@ -0,0 +1,67 @@
+package format
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// stringer mimics API scalar types that expose their raw value via String()
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+type DurationTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DurationTestSuite) TestParseDuration_Shapes() {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  time.Duration
+	}{
+		{"float seconds", 90.0, 90 * time.Second},
+		{"fractional seconds", 1.5, 1500 * time.Millisecond},
+		{"int seconds", 45, 45 * time.Second},
+		{"numeric string", "90", 90 * time.Second},
+		{"numeric string with spaces", " 12.5 ", 12500 * time.Millisecond},
+		{"go duration string", "1m30s", 90 * time.Second},
+		{"go duration seconds", "90s", 90 * time.Second},
+		{"json number", json.Number("30"), 30 * time.Second},
+		{"scientific notation", "1.2e2", 2 * time.Minute},
+		{"stringer", stringer("2m"), 2 * time.Minute},
+		{"duration", 5 * time.Second, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseDuration(tt.input)
+		assert.True(suite.T(), ok, tt.name)
+		assert.Equal(suite.T(), tt.want, got, tt.name)
+	}
+}
+
+func (suite *DurationTestSuite) TestParseDuration_Invalid() {
+	for _, input := range []interface{}{nil, "", "soon", math.NaN(), math.Inf(1), []int{1}, stringer(""), "1e300"} {
+		_, ok := ParseDuration(input)
+		assert.False(suite.T(), ok, "%v", input)
+	}
+}
+
+func (suite *DurationTestSuite) TestFormatDuration() {
+	assert.Equal(suite.T(), "0s", FormatDuration(0))
+	assert.Equal(suite.T(), "45s", FormatDuration(45*time.Second))
+	assert.Equal(suite.T(), "1m30s", FormatDuration(90*time.Second))
+	assert.Equal(suite.T(), "2m", FormatDuration(2*time.Minute+400*time.Millisecond))
+	assert.Equal(suite.T(), "1h", FormatDuration(time.Hour))
+	assert.Equal(suite.T(), "1h5m", FormatDuration(65*time.Minute))
+	assert.Equal(suite.T(), "2h1s", FormatDuration(2*time.Hour+time.Second))
+}
+
+func TestDurationTestSuite(t *testing.T) {
+	suite.Run(t, new(DurationTestSuite))
+}
@@ -0,0 +1,36 @@
+package format
+
+// Group is the items sharing one key, as returned by GroupBy
+type Group[T any] struct {
+	Key   string
+	Items []T
+}
+
+// GroupBy splits items into groups by key. Groups are in the order their key first
+// appears, and items keep their order within a group, so sorted input stays sorted.
+func GroupBy[T any](items []T, key func(T) string) []Group[T] {
+	index := make(map[string]int)
+	groups := []Group[T]{}
+
+	for _, item := range items {
+		k := key(item)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group[T]{Key: k})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	return groups
+}
+
+// GroupMap returns the groups as a map from key to items, for JSON output
+func GroupMap[T any](groups []Group[T]) map[string][]T {
+	m := make(map[string][]T, len(groups))
+	for _, group := range groups {
+		m[group.Key] = group.Items
+	}
+	return m
+}
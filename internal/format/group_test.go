@@ -0,0 +1,26 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBy(t *testing.T) {
+	words := []string{"bravo", "alpha", "beta", "charlie", "apple"}
+
+	groups := GroupBy(words, func(w string) string { return w[:1] })
+	assert.Equal(t, []Group[string]{
+		{Key: "b", Items: []string{"bravo", "beta"}},
+		{Key: "a", Items: []string{"alpha", "apple"}},
+		{Key: "c", Items: []string{"charlie"}},
+	}, groups)
+
+	assert.Equal(t, map[string][]string{
+		"a": {"alpha", "apple"},
+		"b": {"bravo", "beta"},
+		"c": {"charlie"},
+	}, GroupMap(groups))
+
+	assert.Empty(t, GroupBy([]string{}, func(w string) string { return w }))
+}
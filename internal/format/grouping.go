@@ -0,0 +1,101 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NumberGrouping is the separator written between groups of three digits in
+// displayed numbers. The empty grouping writes plain digits.
+type NumberGrouping string
+
+const (
+	// GroupComma writes 1234567 as 1,234,567
+	GroupComma NumberGrouping = ","
+	// GroupPlain writes 1234567 as is, for machine-readable output
+	GroupPlain NumberGrouping = ""
+)
+
+// localeGroupings maps locales, by language or language_TERRITORY, to the
+// separators they group digits with. Locales not listed group with commas.
+var localeGroupings = map[string]NumberGrouping{
+	"de": ".", "da": ".", "es": ".", "id": ".", "it": ".", "nl": ".", "pt": ".", "tr": ".",
+	"cs": " ", "fi": " ", "fr": " ", "nb": " ", "pl": " ", "ru": " ", "sk": " ", "sv": " ", "uk": " ",
+	"de_CH": "'", "it_CH": "'",
+}
+
+// LocaleGrouping returns the digit grouping for a locale name such as en_US,
+// de_DE.UTF-8, or fr-CA. "plain" disables grouping; an empty name, C, POSIX, and
+// locales without a known separator group with commas.
+func LocaleGrouping(locale string) NumberGrouping {
+	if strings.EqualFold(locale, "plain") {
+		return GroupPlain
+	}
+
+	// Drop the encoding and modifier, as in de_DE.UTF-8@euro
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	language, territory, _ := strings.Cut(strings.ReplaceAll(locale, "-", "_"), "_")
+	language = strings.ToLower(language)
+
+	if grouping, ok := localeGroupings[language+"_"+strings.ToUpper(territory)]; ok {
+		return grouping
+	}
+	if grouping, ok := localeGroupings[language]; ok {
+		return grouping
+	}
+	return GroupComma
+}
+
+// Format writes n with its digits grouped in threes
+func (g NumberGrouping) Format(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	if g == GroupPlain {
+		return digits
+	}
+
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= 3 {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	lead := len(digits) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	b.WriteString(digits[:lead])
+	for i := lead; i < len(digits); i += 3 {
+		b.WriteString(string(g))
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// parse reads an integer written by Format, or as plain digits
+func (g NumberGrouping) parse(value string) (int64, bool) {
+	if g != GroupPlain {
+		value = strings.ReplaceAll(value, string(g), "")
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	return n, err == nil
+}
+
+// numberGrouping is the grouping Number uses; see SetNumberGrouping
+var numberGrouping = GroupComma
+
+// SetNumberGrouping sets the digit grouping Number and table totals use
+func SetNumberGrouping(grouping NumberGrouping) {
+	numberGrouping = grouping
+}
+
+// Number formats a count for table output, grouping its digits as set with
+// SetNumberGrouping (commas by default)
+func Number(n int64) string {
+	return numberGrouping.Format(n)
+}
@@ -0,0 +1,92 @@
+package format
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *GroupingTestSuite) TearDownTest() {
+	SetNumberGrouping(GroupComma)
+}
+
+func (suite *GroupingTestSuite) TestFormat_Magnitudes() {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0"},
+		{7, "7"},
+		{999, "999"},
+		{1000, "1,000"},
+		{12345, "12,345"},
+		{123456, "123,456"},
+		{1234567, "1,234,567"},
+		{-1234, "-1,234"},
+		{-999, "-999"},
+		{math.MaxInt64, "9,223,372,036,854,775,807"},
+		{math.MinInt64, "-9,223,372,036,854,775,808"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, GroupComma.Format(tt.n), "%d", tt.n)
+	}
+	assert.Equal(suite.T(), "1234567", GroupPlain.Format(1234567))
+	assert.Equal(suite.T(), "1.234.567", NumberGrouping(".").Format(1234567))
+}
+
+func (suite *GroupingTestSuite) TestLocaleGrouping() {
+	tests := []struct {
+		locale string
+		want   NumberGrouping
+	}{
+		{"", GroupComma},
+		{"C", GroupComma},
+		{"POSIX", GroupComma},
+		{"en_US.UTF-8", GroupComma},
+		{"de_DE.UTF-8@euro", "."},
+		{"de_CH", "'"},
+		{"de-at", "."},
+		{"fr_FR", " "},
+		{"pt_BR.utf8", "."},
+		{"xx_YY", GroupComma},
+		{"plain", GroupPlain},
+		{"PLAIN", GroupPlain},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, LocaleGrouping(tt.locale), tt.locale)
+	}
+}
+
+func (suite *GroupingTestSuite) TestNumber_UsesSetGrouping() {
+	assert.Equal(suite.T(), "123,456", Number(123456))
+
+	SetNumberGrouping(GroupPlain)
+	assert.Equal(suite.T(), "123456", Number(123456))
+
+	SetNumberGrouping(LocaleGrouping("de_DE"))
+	assert.Equal(suite.T(), "123.456", Number(123456))
+}
+
+func (suite *GroupingTestSuite) TestTotals_GroupedCells() {
+	SetNumberGrouping(LocaleGrouping("de_DE"))
+
+	table := NewTable("NAME", "URIS")
+	table.AddRow("a", Number(1500))
+	table.AddRow("b", Number(2500))
+	table.AddRow("c", "7")
+
+	assert.True(suite.T(), table.IsNumericColumn(1))
+	assert.Equal(suite.T(), []string{"TOTAL", "4.007"}, table.Totals("TOTAL", "URIS"))
+}
+
+func TestGroupingTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupingTestSuite))
+}
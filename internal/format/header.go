@@ -0,0 +1,83 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// HeaderStyle selects how table header names are written
+type HeaderStyle string
+
+const (
+	// HeaderUpper keeps headers as uppercase words, e.g. "SCAN ID"
+	HeaderUpper HeaderStyle = "upper"
+	// HeaderTitle capitalizes each word, e.g. "Scan Id"
+	HeaderTitle HeaderStyle = "title"
+	// HeaderSnake joins lowercase words with underscores, e.g. "scan_id"
+	HeaderSnake HeaderStyle = "snake"
+	// HeaderCamel joins words in lower camel case, e.g. "scanId"
+	HeaderCamel HeaderStyle = "camel"
+)
+
+// ParseHeaderStyle converts a style name to a HeaderStyle
+func ParseHeaderStyle(name string) (HeaderStyle, error) {
+	switch HeaderStyle(strings.ToLower(name)) {
+	case HeaderUpper:
+		return HeaderUpper, nil
+	case HeaderTitle:
+		return HeaderTitle, nil
+	case HeaderSnake:
+		return HeaderSnake, nil
+	case HeaderCamel:
+		return HeaderCamel, nil
+	default:
+		return "", fmt.Errorf("unknown header style: %s. %sUse 'upper', 'title', 'snake', or 'camel'", name,
+			didYouMean(name, string(HeaderUpper), string(HeaderTitle), string(HeaderSnake), string(HeaderCamel)))
+	}
+}
+
+// headerWords splits a header into words on spaces, underscores, and hyphens
+func headerWords(header string) []string {
+	return strings.FieldsFunc(header, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '-'
+	})
+}
+
+// capitalize returns word in lowercase with its first letter uppercased
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+// ApplyHeaderStyle rewrites a header name in the given style. Unknown styles
+// leave the header unchanged.
+func ApplyHeaderStyle(header string, style HeaderStyle) string {
+	words := headerWords(header)
+
+	switch style {
+	case HeaderUpper:
+		return strings.ToUpper(strings.Join(words, " "))
+	case HeaderTitle:
+		for i, word := range words {
+			words[i] = capitalize(word)
+		}
+		return strings.Join(words, " ")
+	case HeaderSnake:
+		return strings.ToLower(strings.Join(words, "_"))
+	case HeaderCamel:
+		for i, word := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(word)
+			} else {
+				words[i] = capitalize(word)
+			}
+		}
+		return strings.Join(words, "")
+	default:
+		return header
+	}
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HeaderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *HeaderTestSuite) TestApplyHeaderStyle() {
+	tests := []struct {
+		header string
+		style  HeaderStyle
+		want   string
+	}{
+		{"SCAN ID", HeaderUpper, "SCAN ID"},
+		{"scan_id", HeaderUpper, "SCAN ID"},
+		{"SCAN ID", HeaderTitle, "Scan Id"},
+		{"plugin-ids", HeaderTitle, "Plugin Ids"},
+		{"SCAN ID", HeaderSnake, "scan_id"},
+		{"GO TYPE", HeaderSnake, "go_type"},
+		{"SCAN ID", HeaderCamel, "scanId"},
+		{"PLUGIN IDS", HeaderCamel, "pluginIds"},
+		{"ALERTS", HeaderCamel, "alerts"},
+		{"SCAN ID", HeaderStyle(""), "SCAN ID"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, ApplyHeaderStyle(tt.header, tt.style), "%q as %s", tt.header, tt.style)
+	}
+}
+
+func (suite *HeaderTestSuite) TestParseHeaderStyle() {
+	for _, name := range []string{"upper", "title", "snake", "camel"} {
+		style, err := ParseHeaderStyle(name)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), HeaderStyle(name), style)
+	}
+
+	style, err := ParseHeaderStyle("Snake")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), HeaderSnake, style)
+
+	_, err = ParseHeaderStyle("kebab")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *HeaderTestSuite) TestRender_HeaderStyle() {
+	table := NewTable("SCAN ID", "URIS")
+	table.AddRow("scan-1", "3")
+	table.SetHeaderStyle(HeaderSnake)
+
+	// Columns are still found by their original names
+	table.AddFooter(table.Totals("TOTAL", "URIS")...)
+
+	expected := "" +
+		"scan_id  uris\n" +
+		"-------  ----\n" +
+		"scan-1   3   \n" +
+		"-------  ----\n" +
+		"TOTAL    3   \n"
+	assert.Equal(suite.T(), expected, table.Render())
+
+	table.SetHeaderStyle(HeaderCamel)
+	assert.Contains(suite.T(), table.RenderBordered(), "| scanId | uris |")
+}
+
+func TestHeaderTestSuite(t *testing.T) {
+	suite.Run(t, new(HeaderTestSuite))
+}
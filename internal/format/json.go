@@ -0,0 +1,70 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONArrayWriter streams a JSON array one element at a time so large results
+// start printing immediately without holding the whole slice in memory.
+// Output is identical to json.MarshalIndent(slice, "", "  ") plus a newline.
+type JSONArrayWriter struct {
+	w     io.Writer
+	count int
+	err   error
+}
+
+// NewJSONArrayWriter creates a streaming JSON array writer on w
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return &JSONArrayWriter{w: w}
+}
+
+// Write encodes v as the next element of the array
+func (a *JSONArrayWriter) Write(v interface{}) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		a.err = err
+		return err
+	}
+
+	sep := ",\n  "
+	if a.count == 0 {
+		sep = "[\n  "
+	}
+
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		a.err = err
+		return err
+	}
+	if _, err := a.w.Write(data); err != nil {
+		a.err = err
+		return err
+	}
+
+	a.count++
+	return nil
+}
+
+// Count returns the number of elements written so far
+func (a *JSONArrayWriter) Count() int {
+	return a.count
+}
+
+// Close writes the closing bracket, or an empty array if nothing was written
+func (a *JSONArrayWriter) Close() error {
+	if a.err != nil {
+		return a.err
+	}
+
+	closing := "\n]\n"
+	if a.count == 0 {
+		closing = "[]\n"
+	}
+
+	_, err := io.WriteString(a.w, closing)
+	return err
+}
@@ -0,0 +1,52 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONArrayWriterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *JSONArrayWriterTestSuite) TestMatchesMarshalIndent() {
+	items := []map[string]interface{}{
+		{"id": "scan-1", "stats": map[string]int{"high": 2}},
+		{"id": "scan-2", "tags": []string{"a", "b"}},
+	}
+
+	var buf bytes.Buffer
+	writer := NewJSONArrayWriter(&buf)
+	for _, item := range items {
+		require.NoError(suite.T(), writer.Write(item))
+	}
+	require.NoError(suite.T(), writer.Close())
+
+	want, err := json.MarshalIndent(items, "", "  ")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), string(want)+"\n", buf.String())
+	assert.Equal(suite.T(), 2, writer.Count())
+}
+
+func (suite *JSONArrayWriterTestSuite) TestEmptyArray() {
+	var buf bytes.Buffer
+	writer := NewJSONArrayWriter(&buf)
+	require.NoError(suite.T(), writer.Close())
+	assert.Equal(suite.T(), "[]\n", buf.String())
+}
+
+func (suite *JSONArrayWriterTestSuite) TestWriteError() {
+	var buf bytes.Buffer
+	writer := NewJSONArrayWriter(&buf)
+	assert.Error(suite.T(), writer.Write(make(chan int)))
+	assert.Error(suite.T(), writer.Close())
+}
+
+func TestJSONArrayWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONArrayWriterTestSuite))
+}
@@ -0,0 +1,37 @@
+package format
+
+import "strings"
+
+// Markdown returns the table as a GitHub-flavored Markdown table. Pipes in cells
+// are escaped, line breaks become spaces, and numeric columns are right-aligned.
+// Footers are left out.
+func (t *TableWriter) Markdown() string {
+	var result strings.Builder
+	headers := t.displayHeaders()
+	writeMarkdownRow(&result, headers)
+
+	result.WriteString("|")
+	for col := range headers {
+		if t.IsNumericColumn(col) {
+			result.WriteString(" ---: |")
+		} else {
+			result.WriteString(" --- |")
+		}
+	}
+	result.WriteString("\n")
+
+	for _, row := range t.rows {
+		writeMarkdownRow(&result, row)
+	}
+	return result.String()
+}
+
+// writeMarkdownRow writes cells as one line of a Markdown table
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(cell)
+		b.WriteString(" " + cell + " |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,134 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SelectPath is a parsed --select path such as scan.id, environments[0].env, or
+// environments[*].env. Fields are separated by dots, [n] picks an array element,
+// and * (as a field or [*]) stands for every element of an array or every value of
+// an object, flattening them into the result.
+type SelectPath []selectStep
+
+// selectStep is one step of a SelectPath: a field, an array index, or a wildcard
+type selectStep struct {
+	field    string
+	index    int
+	indexed  bool
+	wildcard bool
+}
+
+// ParseSelectPath parses a --select path
+func ParseSelectPath(expr string) (SelectPath, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	var path SelectPath
+	for _, part := range strings.Split(expr, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("empty field in %q", expr)
+		}
+
+		name, brackets := part, ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name, brackets = part[:i], part[i:]
+		}
+		switch name {
+		case "":
+		case "*":
+			path = append(path, selectStep{wildcard: true})
+		default:
+			path = append(path, selectStep{field: name})
+		}
+
+		for brackets != "" {
+			end := strings.IndexByte(brackets, ']')
+			if brackets[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("malformed index in %q", part)
+			}
+			inner := brackets[1:end]
+			brackets = brackets[end+1:]
+
+			if inner == "*" {
+				path = append(path, selectStep{wildcard: true})
+				continue
+			}
+			n, err := strconv.Atoi(inner)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("index %q in %q is not a whole number or *", inner, part)
+			}
+			path = append(path, selectStep{index: n, indexed: true})
+		}
+	}
+	return path, nil
+}
+
+// Select returns the values at the path within v, a value decoded from JSON into
+// interface{}. A path step that doesn't match, such as a missing field or an index
+// past the end of an array, contributes no values.
+func (p SelectPath) Select(v interface{}) []interface{} {
+	values := []interface{}{v}
+	for _, step := range p {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, step.apply(value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+// apply returns the values the step selects from v
+func (s selectStep) apply(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, v[key])
+			}
+			return values
+		}
+		if value, ok := v[s.field]; ok && !s.indexed {
+			return []interface{}{value}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if s.indexed && s.index < len(v) {
+			return []interface{}{v[s.index]}
+		}
+	}
+	return nil
+}
+
+// SelectedValue formats a value chosen by a SelectPath for one line of output:
+// strings as they are, null as an empty line, and anything else as compact JSON
+func SelectedValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,90 @@
+package format
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SelectPathTestSuite struct {
+	suite.Suite
+}
+
+const selectApp = `{
+	"name": "Mock App",
+	"scan": {"id": "scan-1", "counts": {"high": 2, "low": 7}},
+	"environments": [
+		{"env": "development", "policyName": "DEFAULT"},
+		{"env": "production"}
+	],
+	"owner": null
+}`
+
+func (suite *SelectPathTestSuite) selectLines(expr, doc string) []string {
+	path, err := ParseSelectPath(expr)
+	require.NoError(suite.T(), err)
+
+	decoder := json.NewDecoder(strings.NewReader(doc))
+	decoder.UseNumber()
+	var v interface{}
+	require.NoError(suite.T(), decoder.Decode(&v))
+
+	var lines []string
+	for _, value := range path.Select(v) {
+		line, err := SelectedValue(value)
+		require.NoError(suite.T(), err)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (suite *SelectPathTestSuite) TestNestedPaths() {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"name", []string{"Mock App"}},
+		{"scan.id", []string{"scan-1"}},
+		{"scan.counts.high", []string{"2"}},
+		{"scan.counts", []string{`{"high":2,"low":7}`}},
+		{"environments[1].env", []string{"production"}},
+		{"environments[*].env", []string{"development", "production"}},
+		{"environments.*.env", []string{"development", "production"}},
+		{"scan.counts.*", []string{"2", "7"}},
+		{"owner", []string{""}},
+	}
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, suite.selectLines(tt.expr, selectApp), tt.expr)
+	}
+}
+
+func (suite *SelectPathTestSuite) TestMissingFields() {
+	for _, expr := range []string{
+		"missing",
+		"scan.missing",
+		"name.first",
+		"environments[5].env",
+		"environments.env",
+		"scan[0]",
+	} {
+		assert.Empty(suite.T(), suite.selectLines(expr, selectApp), expr)
+	}
+
+	// Elements without the field are skipped, the rest still print
+	assert.Equal(suite.T(), []string{"DEFAULT"}, suite.selectLines("environments[*].policyName", selectApp))
+}
+
+func (suite *SelectPathTestSuite) TestParseErrors() {
+	for _, expr := range []string{"", "scan..id", ".id", "environments[", "environments[x]", "environments[-1]", "environments[0]x"} {
+		_, err := ParseSelectPath(expr)
+		assert.Error(suite.T(), err, expr)
+	}
+}
+
+func TestSelectPathTestSuite(t *testing.T) {
+	suite.Run(t, new(SelectPathTestSuite))
+}
@@ -0,0 +1,121 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// spinnerFrames are drawn in turn, one per spinnerInterval
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// IsTerminal reports whether w writes to an interactive terminal
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// Spinner animates a one-line status, such as "Fetching scans...", while a long
+// operation runs. A disabled spinner draws nothing, so callers can use one
+// unconditionally. It is safe for concurrent use.
+type Spinner struct {
+	w       io.Writer
+	enabled bool
+
+	mu      sync.Mutex
+	message string
+	frame   int
+	drawn   bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewSpinner returns a spinner that draws on w when enabled, which is typically
+// whether w is a terminal the user hasn't asked to keep quiet
+func NewSpinner(w io.Writer, enabled bool) *Spinner {
+	return &Spinner{w: w, enabled: enabled}
+}
+
+// Start begins animating message. The first frame is drawn after one interval, so
+// operations that finish quickly don't flash a status line.
+func (s *Spinner) Start(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.message = message
+	if !s.enabled || s.stop != nil {
+		return
+	}
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+// Update replaces the status message; it is shown from the next frame
+func (s *Spinner) Update(message string) {
+	s.mu.Lock()
+	s.message = message
+	s.mu.Unlock()
+}
+
+// Stop ends the animation and clears the status line. Stopping a spinner that
+// isn't running does nothing.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+
+	s.mu.Lock()
+	s.clear()
+	s.mu.Unlock()
+}
+
+// Write clears the status line, then writes p to the spinner's writer, so
+// messages printed while the spinner runs aren't mixed into its line. The status
+// is redrawn on the next frame.
+func (s *Spinner) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.clear()
+	return s.w.Write(p)
+}
+
+func (s *Spinner) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			fmt.Fprintf(s.w, "\r%s %s\x1b[K", spinnerFrames[s.frame%len(spinnerFrames)], s.message)
+			s.frame++
+			s.drawn = true
+			s.mu.Unlock()
+		}
+	}
+}
+
+// clear erases the status line if one is drawn; s.mu must be held
+func (s *Spinner) clear() {
+	if s.drawn {
+		fmt.Fprint(s.w, "\r\x1b[K")
+		s.drawn = false
+	}
+}
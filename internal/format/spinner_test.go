@@ -0,0 +1,71 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer that the spinner's goroutine and the test can share
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSpinner_DisabledWritesNothing(t *testing.T) {
+	var out bytes.Buffer
+	assert.False(t, IsTerminal(&out))
+
+	spinner := NewSpinner(&out, IsTerminal(&out))
+	spinner.Start("Fetching scans...")
+	spinner.Update("Fetching alerts for scan 1/2...")
+	time.Sleep(3 * spinnerInterval)
+	spinner.Stop()
+	assert.Empty(t, out.String())
+
+	// Messages still pass through
+	fmt.Fprintln(spinner, "⚠️  warning")
+	assert.Equal(t, "⚠️  warning\n", out.String())
+}
+
+func TestSpinner_DrawsAndClears(t *testing.T) {
+	out := &syncBuffer{}
+	spinner := NewSpinner(out, true)
+	spinner.Start("Fetching scans...")
+	assert.Eventually(t, func() bool { return strings.Contains(out.String(), "Fetching scans...") }, time.Second, 10*time.Millisecond)
+
+	spinner.Update("Fetching alerts for scan 2/3...")
+	assert.Eventually(t, func() bool { return strings.Contains(out.String(), "scan 2/3") }, time.Second, 10*time.Millisecond)
+
+	fmt.Fprintln(spinner, "warning")
+	assert.Contains(t, out.String(), "\r\x1b[Kwarning\n", "the status line is cleared before other output")
+
+	// Redrawn on the next frame, then erased by Stop
+	assert.Eventually(t, func() bool {
+		s := out.String()
+		return strings.LastIndex(s, "scan 2/3") > strings.Index(s, "warning")
+	}, time.Second, 10*time.Millisecond)
+	spinner.Stop()
+	assert.True(t, strings.HasSuffix(out.String(), "\r\x1b[K"))
+	stopped := out.String()
+	time.Sleep(2 * spinnerInterval)
+	assert.Equal(t, stopped, out.String(), "nothing is drawn after Stop")
+	spinner.Stop()
+}
@@ -0,0 +1,57 @@
+package format
+
+import "strings"
+
+// Levenshtein returns the edit distance between a and b: the fewest single
+// character insertions, deletions, and substitutions that turn one into the other
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Keep one row of the distance matrix; prev[j] is the distance from the
+	// first i-1 runes of a to the first j runes of b
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
+
+// Suggest returns the candidate closest to value, ignoring case, if it is close
+// enough to be a likely typo: at most half of value's length away. Ties go to the
+// earlier candidate.
+func Suggest(value string, candidates []string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	value = strings.ToLower(value)
+	limit := max(len([]rune(value))/2, 1)
+
+	best, bestDistance := "", limit+1
+	for _, candidate := range candidates {
+		if distance := Levenshtein(value, strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, best != ""
+}
+
+// didYouMean returns a "Did you mean 'x'? " prefix for an error message when name
+// looks like a typo of one of candidates, and "" otherwise
+func didYouMean(name string, candidates ...string) string {
+	if suggestion, ok := Suggest(name, candidates); ok {
+		return "Did you mean '" + suggestion + "'? "
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuggestTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SuggestTestSuite) TestLevenshtein() {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "json", 4},
+		{"json", "json", 0},
+		{"sevirity", "severity", 1},
+		{"jsno", "json", 2},
+		{"kitten", "sitting", 3},
+		{"héader", "header", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, Levenshtein(tt.a, tt.b), "%q -> %q", tt.a, tt.b)
+		assert.Equal(suite.T(), tt.want, Levenshtein(tt.b, tt.a), "%q -> %q", tt.b, tt.a)
+	}
+}
+
+func (suite *SuggestTestSuite) TestSuggest_CloseMatch() {
+	fields := []string{"severity", "status", "name"}
+
+	suggestion, ok := Suggest("sevirity", fields)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "severity", suggestion)
+
+	// Case is ignored
+	suggestion, ok = Suggest("STATSU", fields)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "status", suggestion)
+}
+
+func (suite *SuggestTestSuite) TestSuggest_FarMatch() {
+	_, ok := Suggest("timestamp", []string{"severity", "status", "name"})
+	assert.False(suite.T(), ok)
+
+	_, ok = Suggest("", []string{"a"})
+	assert.False(suite.T(), ok)
+
+	_, ok = Suggest("json", nil)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *SuggestTestSuite) TestParseStyle_Suggestion() {
+	_, err := ParseTableStyle("boredred")
+	assert.ErrorContains(suite.T(), err, "Did you mean 'bordered'?")
+
+	_, err = ParseHeaderStyle("kebab")
+	assert.NotContains(suite.T(), err.Error(), "Did you mean")
+}
+
+func TestSuggestTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestTestSuite))
+}
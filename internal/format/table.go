@@ -11,6 +11,10 @@ import (
 type TableWriter struct {
 	headers []string
 	rows    [][]string
+	footer  []string
+
+	// headerStyle rewrites header names when rendering; empty keeps them as given
+	headerStyle HeaderStyle
 }
 
 // NewTable creates a new table with the specified headers
@@ -33,22 +37,174 @@ func (t *TableWriter) AddRow(values ...string) {
 	t.rows = append(t.rows, row)
 }
 
-// Render returns the formatted table as a string
-func (t *TableWriter) Render() string {
-	if len(t.headers) == 0 {
-		return ""
+// AddFooter sets a summary row rendered after the data rows, separated by a rule
+func (t *TableWriter) AddFooter(values ...string) {
+	footer := make([]string, len(t.headers))
+	for i, value := range values {
+		if i < len(footer) {
+			footer[i] = value
+		}
 	}
+	t.footer = footer
+}
 
-	// Calculate column widths
+// SetHeaderStyle sets how header names are written when the table is rendered.
+// Rows and Totals still refer to columns by the names passed to NewTable.
+func (t *TableWriter) SetHeaderStyle(style HeaderStyle) {
+	t.headerStyle = style
+}
+
+// displayHeaders returns the headers as they are rendered
+func (t *TableWriter) displayHeaders() []string {
+	if t.headerStyle == "" {
+		return t.headers
+	}
+	headers := make([]string, len(t.headers))
+	for i, header := range t.headers {
+		headers[i] = ApplyHeaderStyle(header, t.headerStyle)
+	}
+	return headers
+}
+
+// IsEmptyColumn reports whether every data row leaves the column blank or "N/A".
+// A footer value, such as a total, keeps the column. Tables without rows have no
+// empty columns.
+func (t *TableWriter) IsEmptyColumn(col int) bool {
+	if col < 0 || col >= len(t.headers) || len(t.rows) == 0 {
+		return false
+	}
+	if t.footer != nil && t.footer[col] != "" {
+		return false
+	}
+	for _, row := range t.rows {
+		if value := strings.TrimSpace(row[col]); value != "" && value != "N/A" {
+			return false
+		}
+	}
+	return true
+}
+
+// HideEmptyColumns removes the columns reported by IsEmptyColumn, so values that
+// are missing for every row don't take up width. If every column is empty the
+// table is left as is.
+func (t *TableWriter) HideEmptyColumns() {
+	keep := []int{}
+	for col := range t.headers {
+		if !t.IsEmptyColumn(col) {
+			keep = append(keep, col)
+		}
+	}
+	if len(keep) == 0 || len(keep) == len(t.headers) {
+		return
+	}
+
+	pick := func(cells []string) []string {
+		kept := make([]string, len(keep))
+		for i, col := range keep {
+			kept[i] = cells[col]
+		}
+		return kept
+	}
+
+	t.headers = pick(t.headers)
+	for i, row := range t.rows {
+		t.rows[i] = pick(row)
+	}
+	if t.footer != nil {
+		t.footer = pick(t.footer)
+	}
+}
+
+// IsNumericColumn reports whether every non-empty cell in the column is an integer,
+// plain or written by Number. Columns with no values are not numeric.
+func (t *TableWriter) IsNumericColumn(col int) bool {
+	if col < 0 || col >= len(t.headers) {
+		return false
+	}
+
+	found := false
+	for _, row := range t.rows {
+		if row[col] == "" {
+			continue
+		}
+		if _, ok := numberGrouping.parse(row[col]); !ok {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// Totals builds a footer with label in the first column and the sum of each
+// named numeric column. Named columns that are missing or not numeric stay blank.
+func (t *TableWriter) Totals(label string, headers ...string) []string {
+	footer := make([]string, len(t.headers))
+	if len(footer) > 0 {
+		footer[0] = label
+	}
+
+	for _, name := range headers {
+		for col, header := range t.headers {
+			if header != name || !t.IsNumericColumn(col) {
+				continue
+			}
+			var sum int64
+			for _, row := range t.rows {
+				n, _ := numberGrouping.parse(row[col])
+				sum += n
+			}
+			footer[col] = Number(sum)
+		}
+	}
+
+	return footer
+}
+
+// TableStyle selects how a table is drawn
+type TableStyle string
+
+const (
+	// StyleMinimal separates columns with spaces and underlines the header
+	StyleMinimal TableStyle = "minimal"
+	// StyleBordered draws ASCII borders (+---+, |) around every cell
+	StyleBordered TableStyle = "bordered"
+)
+
+// ParseTableStyle converts a style name to a TableStyle
+func ParseTableStyle(name string) (TableStyle, error) {
+	switch TableStyle(strings.ToLower(name)) {
+	case StyleMinimal:
+		return StyleMinimal, nil
+	case StyleBordered:
+		return StyleBordered, nil
+	default:
+		return "", fmt.Errorf("unknown table style: %s. %sUse 'minimal' or 'bordered'", name, didYouMean(name, string(StyleMinimal), string(StyleBordered)))
+	}
+}
+
+// RenderStyle returns the table formatted in the given style
+func (t *TableWriter) RenderStyle(style TableStyle) string {
+	if style == StyleBordered {
+		return t.RenderBordered()
+	}
+	return t.Render()
+}
+
+// columnWidths returns the width of each column, sized to its widest cell
+func (t *TableWriter) columnWidths() []int {
 	colWidths := make([]int, len(t.headers))
 
 	// Start with header widths
-	for i, header := range t.headers {
+	for i, header := range t.displayHeaders() {
 		colWidths[i] = len(header)
 	}
 
-	// Check row widths
-	for _, row := range t.rows {
+	// Check row and footer widths
+	rows := t.rows
+	if t.footer != nil {
+		rows = append(rows[:len(rows):len(rows)], t.footer)
+	}
+	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(colWidths) && len(cell) > colWidths[i] {
 				colWidths[i] = len(cell)
@@ -56,10 +212,22 @@ func (t *TableWriter) Render() string {
 		}
 	}
 
+	return colWidths
+}
+
+// Render returns the formatted table as a string
+func (t *TableWriter) Render() string {
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	// Calculate column widths
+	colWidths := t.columnWidths()
+
 	var result strings.Builder
 
 	// Write headers
-	for i, header := range t.headers {
+	for i, header := range t.displayHeaders() {
 		if i > 0 {
 			result.WriteString("  ")
 		}
@@ -67,17 +235,18 @@ func (t *TableWriter) Render() string {
 	}
 	result.WriteString("\n")
 
-	// Write separator
-	for i := range t.headers {
-		if i > 0 {
-			result.WriteString("  ")
+	// Separator line, also used above the footer
+	writeSeparator := func() {
+		for i := range t.headers {
+			if i > 0 {
+				result.WriteString("  ")
+			}
+			result.WriteString(strings.Repeat("-", colWidths[i]))
 		}
-		result.WriteString(strings.Repeat("-", colWidths[i]))
+		result.WriteString("\n")
 	}
-	result.WriteString("\n")
 
-	// Write rows
-	for _, row := range t.rows {
+	writeRow := func(row []string) {
 		for i, cell := range row {
 			if i > 0 {
 				result.WriteString("  ")
@@ -89,5 +258,66 @@ func (t *TableWriter) Render() string {
 		result.WriteString("\n")
 	}
 
+	writeSeparator()
+
+	// Write rows
+	for _, row := range t.rows {
+		writeRow(row)
+	}
+
+	// Write footer
+	if t.footer != nil {
+		writeSeparator()
+		writeRow(t.footer)
+	}
+
+	return result.String()
+}
+
+// RenderBordered returns the table with ASCII borders so cells containing
+// spaces remain unambiguous
+func (t *TableWriter) RenderBordered() string {
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	colWidths := t.columnWidths()
+
+	// Border line, e.g. +------+-----+
+	var border strings.Builder
+	border.WriteString("+")
+	for _, width := range colWidths {
+		border.WriteString(strings.Repeat("-", width+2))
+		border.WriteString("+")
+	}
+	border.WriteString("\n")
+
+	writeRow := func(result *strings.Builder, cells []string) {
+		result.WriteString("|")
+		for i, width := range colWidths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			result.WriteString(fmt.Sprintf(" %-*s |", width, cell))
+		}
+		result.WriteString("\n")
+	}
+
+	var result strings.Builder
+	result.WriteString(border.String())
+	writeRow(&result, t.displayHeaders())
+	result.WriteString(border.String())
+	for _, row := range t.rows {
+		writeRow(&result, row)
+	}
+	if len(t.rows) > 0 {
+		result.WriteString(border.String())
+	}
+	if t.footer != nil {
+		writeRow(&result, t.footer)
+		result.WriteString(border.String())
+	}
+
 	return result.String()
 }
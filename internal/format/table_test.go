@@ -149,6 +149,140 @@ func (suite *TableTestSuite) TestRender_ColumnAlignment() {
 	assert.Equal(suite.T(), shortHeaderPos, dataAPos)
 }
 
+func (suite *TableTestSuite) TestRenderBordered() {
+	table := NewTable("ID", "NAME")
+	table.AddRow("1", "Test App")
+	table.AddRow("22", "")
+
+	expected := "" +
+		"+----+----------+\n" +
+		"| ID | NAME     |\n" +
+		"+----+----------+\n" +
+		"| 1  | Test App |\n" +
+		"| 22 |          |\n" +
+		"+----+----------+\n"
+	assert.Equal(suite.T(), expected, table.RenderBordered())
+	assert.Equal(suite.T(), expected, table.RenderStyle(StyleBordered))
+	assert.Equal(suite.T(), table.Render(), table.RenderStyle(StyleMinimal))
+}
+
+func (suite *TableTestSuite) TestRenderBordered_NoRows() {
+	table := NewTable("ID")
+	assert.Equal(suite.T(), "+----+\n| ID |\n+----+\n", table.RenderBordered())
+
+	assert.Equal(suite.T(), "", NewTable().RenderBordered())
+}
+
+func (suite *TableTestSuite) TestParseTableStyle() {
+	style, err := ParseTableStyle("Bordered")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StyleBordered, style)
+
+	_, err = ParseTableStyle("fancy")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TableTestSuite) TestRender_Footer() {
+	table := NewTable("ID", "ALERTS")
+	table.AddRow("a", "5")
+	table.AddRow("b", "12")
+	table.AddFooter("TOTAL", "17")
+
+	expected := "" +
+		"ID     ALERTS\n" +
+		"-----  ------\n" +
+		"a      5     \n" +
+		"b      12    \n" +
+		"-----  ------\n" +
+		"TOTAL  17    \n"
+	assert.Equal(suite.T(), expected, table.Render())
+}
+
+func (suite *TableTestSuite) TestRenderBordered_Footer() {
+	table := NewTable("ID", "URIS")
+	table.AddRow("1", "3")
+	table.AddFooter("TOTAL", "3")
+
+	expected := "" +
+		"+-------+------+\n" +
+		"| ID    | URIS |\n" +
+		"+-------+------+\n" +
+		"| 1     | 3    |\n" +
+		"+-------+------+\n" +
+		"| TOTAL | 3    |\n" +
+		"+-------+------+\n"
+	assert.Equal(suite.T(), expected, table.RenderBordered())
+}
+
+func (suite *TableTestSuite) TestIsNumericColumn() {
+	table := NewTable("ID", "COUNT", "EMPTY", "MIXED")
+	table.AddRow("abc", "1", "", "2")
+	table.AddRow("def", "", "", "n/a")
+
+	assert.False(suite.T(), table.IsNumericColumn(0))
+	assert.True(suite.T(), table.IsNumericColumn(1))
+	assert.False(suite.T(), table.IsNumericColumn(2))
+	assert.False(suite.T(), table.IsNumericColumn(3))
+	assert.False(suite.T(), table.IsNumericColumn(4))
+}
+
+func (suite *TableTestSuite) TestTotals() {
+	table := NewTable("PLUGIN ID", "NAME", "URIS")
+	table.AddRow("10020", "Header Missing", "4")
+	table.AddRow("40012", "XSS", "")
+	table.AddRow("10021", "Content Type", "6")
+
+	// Only the named columns are summed, even if others look numeric
+	assert.Equal(suite.T(), []string{"TOTAL", "", "10"}, table.Totals("TOTAL", "URIS"))
+
+	// Non-numeric and unknown columns stay blank
+	assert.Equal(suite.T(), []string{"TOTAL", "", ""}, table.Totals("TOTAL", "NAME", "MISSING"))
+}
+
+func (suite *TableTestSuite) TestIsEmptyColumn() {
+	table := NewTable("ID", "BLANK", "NA", "SOME")
+	table.AddRow("1", "", "N/A", "")
+	table.AddRow("2", "  ", "N/A", "x")
+
+	assert.False(suite.T(), table.IsEmptyColumn(0))
+	assert.True(suite.T(), table.IsEmptyColumn(1))
+	assert.True(suite.T(), table.IsEmptyColumn(2))
+	assert.False(suite.T(), table.IsEmptyColumn(3))
+	assert.False(suite.T(), table.IsEmptyColumn(4))
+
+	// Without rows nothing counts as empty
+	assert.False(suite.T(), NewTable("ID").IsEmptyColumn(0))
+}
+
+func (suite *TableTestSuite) TestHideEmptyColumns() {
+	table := NewTable("ID", "HOST", "NAME")
+	table.AddRow("1", "N/A", "App")
+	table.AddRow("2", "", "Other")
+	table.HideEmptyColumns()
+
+	expected := "ID  NAME \n--  -----\n1   App  \n2   Other\n"
+	assert.Equal(suite.T(), expected, table.Render())
+}
+
+func (suite *TableTestSuite) TestHideEmptyColumns_FooterKeepsColumn() {
+	table := NewTable("ID", "URIS", "NOTE")
+	table.AddRow("1", "", "")
+	table.AddFooter("TOTAL", "0", "")
+	table.HideEmptyColumns()
+
+	assert.Equal(suite.T(), []string{"ID", "URIS"}, table.headers)
+	assert.Equal(suite.T(), []string{"1", ""}, table.rows[0])
+	assert.Equal(suite.T(), []string{"TOTAL", "0"}, table.footer)
+}
+
+func (suite *TableTestSuite) TestHideEmptyColumns_AllEmpty() {
+	table := NewTable("A", "B")
+	table.AddRow("", "N/A")
+	table.HideEmptyColumns()
+
+	assert.Equal(suite.T(), []string{"A", "B"}, table.headers)
+}
+
 func TestTableTestSuite(t *testing.T) {
 	suite.Run(t, new(TableTestSuite))
 }
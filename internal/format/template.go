@@ -0,0 +1,69 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"hawkop/internal/number"
+)
+
+// NewTemplate parses text as a Go text/template with the helper functions from
+// TemplateFuncs, rendering times in loc
+func NewTemplate(text string, loc *time.Location) (*template.Template, error) {
+	return template.New("output").Funcs(TemplateFuncs(loc)).Parse(text)
+}
+
+// TemplateFuncs returns the helper functions available to output templates:
+//
+//	json        the value as compact JSON
+//	date        a timestamp in TimestampLayout
+//	dateFormat  a timestamp in a Go time layout, e.g. {{dateFormat "2006-01-02" .Scan.Timestamp}}
+//	upper       the string in upper case
+//	lower       the string in lower case
+//	join        a list of strings joined by a separator, e.g. {{join ", " .References}}
+//
+// Timestamps may be time.Time values or epoch milliseconds, as the API reports
+// times, in any form number.ParseCount accepts.
+func TemplateFuncs(loc *time.Location) template.FuncMap {
+	if loc == nil {
+		loc = time.Local
+	}
+	return template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"date": func(v interface{}) (string, error) {
+			t, err := templateTime(v, loc)
+			return t.Format(TimestampLayout), err
+		},
+		"dateFormat": func(layout string, v interface{}) (string, error) {
+			t, err := templateTime(v, loc)
+			return t.Format(layout), err
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+	}
+}
+
+// templateTime converts a template argument to a time in loc
+func templateTime(v interface{}, loc *time.Location) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t.In(loc), nil
+	case *time.Time:
+		if t != nil {
+			return t.In(loc), nil
+		}
+	}
+	if ms, ok := number.ParseCount(v); ok {
+		return TimeIn(ms, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("%v is not a timestamp", v)
+}
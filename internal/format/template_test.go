@@ -0,0 +1,55 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TemplateTestSuite) execute(text string, data interface{}) (string, error) {
+	tmpl, err := NewTemplate(text, time.UTC)
+	require.NoError(suite.T(), err)
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func (suite *TemplateTestSuite) TestFuncs() {
+	data := map[string]interface{}{
+		"name":  "Mock App",
+		"tags":  []string{"a", "b"},
+		"at":    "1756596062834",
+		"when":  time.Date(2025, 8, 30, 23, 21, 0, 0, time.UTC),
+		"count": 3,
+	}
+
+	got, err := suite.execute(`{{upper .name}}|{{lower .name}}|{{join ", " .tags}}|{{json .tags}}|{{date .at}}|{{dateFormat "2006-01-02" .when}}|{{.at | date}}`, data)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `MOCK APP|mock app|a, b|["a","b"]|2025-08-30 23:21|2025-08-30|2025-08-30 23:21`, got)
+}
+
+func (suite *TemplateTestSuite) TestDate_NotATimestamp() {
+	_, err := suite.execute(`{{date .}}`, "soon")
+	assert.ErrorContains(suite.T(), err, "soon is not a timestamp")
+}
+
+func (suite *TemplateTestSuite) TestNewTemplate_ParseError() {
+	_, err := NewTemplate(`{{range .}}`, time.UTC)
+	assert.Error(suite.T(), err)
+
+	_, err = NewTemplate(`{{nosuchfunc .}}`, time.UTC)
+	assert.ErrorContains(suite.T(), err, "nosuchfunc")
+}
+
+func TestTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplateTestSuite))
+}
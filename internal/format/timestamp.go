@@ -0,0 +1,38 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	// Embed the IANA zone database so --timezone works on systems without one
+	_ "time/tzdata"
+)
+
+// TimestampLayout is how FormatTimestamp renders a point in time
+const TimestampLayout = "2006-01-02 15:04"
+
+// TimeIn converts epoch milliseconds to a time in loc, or in the local zone when loc is nil
+func TimeIn(ms int64, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.UnixMilli(ms).In(loc)
+}
+
+// FormatTimestamp renders epoch milliseconds, as the API reports times, in loc
+func FormatTimestamp(ms int64, loc *time.Location) string {
+	return TimeIn(ms, loc).Format(TimestampLayout)
+}
+
+// LoadLocation resolves an IANA time zone name such as "UTC" or "America/New_York".
+// An empty name or "Local" is the local zone.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q; use an IANA name such as UTC or America/New_York", name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimestampTestSuite struct {
+	suite.Suite
+}
+
+// 2025-08-30 23:21:02.834 UTC
+const knownEpochMillis = 1756596062834
+
+func (suite *TimestampTestSuite) TestFormatTimestamp_UTC() {
+	assert.Equal(suite.T(), "2025-08-30 23:21", FormatTimestamp(knownEpochMillis, time.UTC))
+}
+
+func (suite *TimestampTestSuite) TestFormatTimestamp_NamedZone() {
+	newYork, err := LoadLocation("America/New_York")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2025-08-30 19:21", FormatTimestamp(knownEpochMillis, newYork))
+
+	tokyo, err := LoadLocation("Asia/Tokyo")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2025-08-31 08:21", FormatTimestamp(knownEpochMillis, tokyo))
+}
+
+func (suite *TimestampTestSuite) TestTimeIn_DefaultsToLocal() {
+	t := TimeIn(knownEpochMillis, nil)
+	assert.Equal(suite.T(), time.Local, t.Location())
+	assert.Equal(suite.T(), int64(knownEpochMillis), t.UnixMilli())
+}
+
+func (suite *TimestampTestSuite) TestLoadLocation() {
+	loc, err := LoadLocation("")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Local, loc)
+
+	loc, err = LoadLocation("UTC")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.UTC, loc)
+
+	_, err = LoadLocation("Mars/Olympus_Mons")
+	assert.ErrorContains(suite.T(), err, `unknown timezone "Mars/Olympus_Mons"`)
+}
+
+func TestTimestampTestSuite(t *testing.T) {
+	suite.Run(t, new(TimestampTestSuite))
+}
@@ -0,0 +1,71 @@
+package format
+
+import "strings"
+
+// TSVWriter formats rows as tab-separated values with a header line and no
+// alignment padding, for pasting into spreadsheets or processing with cut and awk
+type TSVWriter struct {
+	headers []string
+	rows    [][]string
+}
+
+// NewTSV creates TSV output with the specified headers
+func NewTSV(headers ...string) *TSVWriter {
+	return &TSVWriter{
+		headers: headers,
+		rows:    make([][]string, 0),
+	}
+}
+
+// AddRow adds a row of data, padded or truncated to the number of headers
+func (w *TSVWriter) AddRow(values ...string) {
+	row := make([]string, len(w.headers))
+	for i, value := range values {
+		if i < len(row) {
+			row[i] = value
+		}
+	}
+	w.rows = append(w.rows, row)
+}
+
+// Render returns the header line and rows, each terminated by a newline
+func (w *TSVWriter) Render() string {
+	if len(w.headers) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	writeLine := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				result.WriteString("\t")
+			}
+			result.WriteString(escapeTSV(cell))
+		}
+		result.WriteString("\n")
+	}
+
+	writeLine(w.headers)
+	for _, row := range w.rows {
+		writeLine(row)
+	}
+	return result.String()
+}
+
+// tsvEscaper writes backslashes, tabs, and line breaks as escape sequences so
+// every value stays within its cell and line
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func escapeTSV(value string) string {
+	return tsvEscaper.Replace(value)
+}
+
+// TSV returns the table's headers, as rendered with its header style, and rows
+// as TSV output. The footer is left out so every line is a record.
+func (t *TableWriter) TSV() *TSVWriter {
+	tsv := NewTSV(t.displayHeaders()...)
+	for _, row := range t.rows {
+		tsv.AddRow(row...)
+	}
+	return tsv
+}
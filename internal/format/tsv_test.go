@@ -0,0 +1,75 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TSVTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TSVTestSuite) TestRender() {
+	tsv := NewTSV("ID", "NAME", "STATUS")
+	tsv.AddRow("1", "Short", "ACTIVE")
+	tsv.AddRow("2", "A much longer name")
+
+	expected := "" +
+		"ID\tNAME\tSTATUS\n" +
+		"1\tShort\tACTIVE\n" +
+		"2\tA much longer name\t\n"
+	assert.Equal(suite.T(), expected, tsv.Render())
+
+	assert.Equal(suite.T(), "", NewTSV().Render())
+}
+
+func (suite *TSVTestSuite) TestRender_Escaping() {
+	tsv := NewTSV("NAME", "REASON")
+	tsv.AddRow("tab\there", "line one\nline two\r\n")
+	tsv.AddRow(`C:\path`, "")
+
+	expected := "" +
+		"NAME\tREASON\n" +
+		`tab\there` + "\t" + `line one\nline two\r\n` + "\n" +
+		`C:\\path` + "\t\n"
+	assert.Equal(suite.T(), expected, tsv.Render())
+}
+
+func (suite *TSVTestSuite) TestTableTSV() {
+	table := NewTable("SCAN ID", "ALERTS")
+	table.AddRow("scan-1", "3")
+	table.AddRow("scan-2", "4")
+	table.AddFooter(table.Totals("TOTAL", "ALERTS")...)
+	table.SetHeaderStyle(HeaderSnake)
+
+	// Header style applies; the totals footer is left out
+	expected := "scan_id\talerts\nscan-1\t3\nscan-2\t4\n"
+	assert.Equal(suite.T(), expected, table.TSV().Render())
+}
+
+func (suite *TSVTestSuite) TestTableCSV() {
+	table := NewTable("NAME", "TEAMS")
+	table.AddRow("Jane", "Platform, Security")
+	table.AddRow(`Bob "B"`, "")
+	table.AddFooter("TOTAL", "2")
+	table.SetHeaderStyle(HeaderSnake)
+
+	expected := "name,teams\nJane,\"Platform, Security\"\n\"Bob \"\"B\"\"\",\n"
+	assert.Equal(suite.T(), expected, table.CSV())
+}
+
+func (suite *TSVTestSuite) TestTableMarkdown() {
+	table := NewTable("NAME", "URIS")
+	table.AddRow("a | b", "1,234")
+	table.AddRow("line\nbreak", "7")
+	table.AddFooter("TOTAL", "1,241")
+
+	expected := "| NAME | URIS |\n| --- | ---: |\n| a \\| b | 1,234 |\n| line break | 7 |\n"
+	assert.Equal(suite.T(), expected, table.Markdown())
+}
+
+func TestTSVTestSuite(t *testing.T) {
+	suite.Run(t, new(TSVTestSuite))
+}
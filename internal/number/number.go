@@ -0,0 +1,91 @@
+// Package number coerces the loosely typed numbers the API sends, which may arrive
+// as JSON numbers, numeric strings, or json.Number values.
+package number
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Parse coerces a number the API may send as a JSON number of any notation
+// (including "1.5e3"), a Go integer or float, a numeric string, or a value
+// implementing fmt.Stringer such as json.Number. NaN, infinities, and anything else
+// report false rather than a misleading zero.
+func Parse(value interface{}) (float64, bool) {
+	var f float64
+	switch v := value.(type) {
+	case nil:
+		return 0, false
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	case int32:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint:
+		f = float64(v)
+	case uint32:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	default:
+		s, ok := stringForm(value)
+		if !ok {
+			return 0, false
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return 0, false
+		}
+		f = parsed
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// ParseCount coerces a count, such as a number of URLs, in any form Parse
+// accepts. Integer strings are parsed exactly, so counts beyond float64 precision
+// survive; negative, fractional, and out-of-range values report false.
+func ParseCount(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), v >= 0
+	case int64:
+		return v, v >= 0
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	}
+	if s, ok := stringForm(value); ok {
+		if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+			return n, n >= 0
+		}
+	}
+
+	f, ok := Parse(value)
+	// float64(math.MaxInt64) rounds up to 2^63, which is already out of range
+	if !ok || f < 0 || f != math.Trunc(f) || f >= float64(math.MaxInt64) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// stringForm returns the text of strings and fmt.Stringer values such as json.Number
+func stringForm(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case fmt.Stringer:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
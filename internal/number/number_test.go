@@ -0,0 +1,86 @@
+package number
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// stringer mimics API scalar types that expose their raw value via String()
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+type NumberTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NumberTestSuite) TestParse_Shapes() {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  float64
+	}{
+		{"float", 12.5, 12.5},
+		{"int", 42, 42},
+		{"int64", int64(1) << 53, 1 << 53},
+		{"uint64", uint64(7), 7},
+		{"numeric string", "42", 42},
+		{"string with spaces", " 3.25 ", 3.25},
+		{"float string", "1.5", 1.5},
+		{"scientific notation", "1.5e3", 1500},
+		{"negative", "-4", -4},
+		{"json number", json.Number("2E2"), 200},
+		{"stringer", stringer("8"), 8},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.input)
+		assert.True(suite.T(), ok, tt.name)
+		assert.Equal(suite.T(), tt.want, got, tt.name)
+	}
+}
+
+func (suite *NumberTestSuite) TestParse_Invalid() {
+	for _, input := range []interface{}{nil, "", "many", "1m30s", "NaN", "Inf", math.NaN(), math.Inf(-1), true, []int{1}} {
+		_, ok := Parse(input)
+		assert.False(suite.T(), ok, "%v", input)
+	}
+}
+
+func (suite *NumberTestSuite) TestParseCount() {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  int64
+	}{
+		{"int", 3, 3},
+		{"zero", "0", 0},
+		{"float", 12.0, 12},
+		{"float string", "12.0", 12},
+		{"scientific notation", "1e6", 1000000},
+		{"json number", json.Number("2.5e1"), 25},
+		{"beyond float64 precision", "9007199254740993", 9007199254740993},
+		{"max int64", "9223372036854775807", math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseCount(tt.input)
+		assert.True(suite.T(), ok, tt.name)
+		assert.Equal(suite.T(), tt.want, got, tt.name)
+	}
+}
+
+func (suite *NumberTestSuite) TestParseCount_Invalid() {
+	for _, input := range []interface{}{nil, "", "lots", "-1", -1, 1.5, "2.5", "1e19", "9223372036854775808", math.Inf(1)} {
+		_, ok := ParseCount(input)
+		assert.False(suite.T(), ok, "%v", input)
+	}
+}
+
+func TestNumberTestSuite(t *testing.T) {
+	suite.Run(t, new(NumberTestSuite))
+}
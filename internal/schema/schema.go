@@ -0,0 +1,187 @@
+// Package schema generates JSON Schema documents from Go types using reflection,
+// following the same field rules as encoding/json.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema
+type Schema map[string]interface{}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// typeNamer is implemented by types with custom marshaling that know which JSON
+// types they produce, such as a value written as either a number or a string
+type typeNamer interface {
+	JSONSchemaTypes() []string
+}
+
+var typeNamerType = reflect.TypeOf((*typeNamer)(nil)).Elem()
+
+// generator tracks named struct definitions so nested and recursive types
+// are emitted once under $defs and referenced
+type generator struct {
+	defs map[string]Schema
+}
+
+// Generate returns a JSON Schema describing how v's type marshals to JSON
+func Generate(v interface{}) Schema {
+	return GenerateType(reflect.TypeOf(v))
+}
+
+// GenerateType returns a JSON Schema describing how t marshals to JSON
+func GenerateType(t reflect.Type) Schema {
+	g := &generator{defs: make(map[string]Schema)}
+
+	t = indirect(t)
+	var root Schema
+	if t.Kind() == reflect.Struct {
+		root = g.structSchema(t)
+	} else {
+		root = g.schemaFor(t)
+	}
+
+	root["$schema"] = Draft
+	root["title"] = t.Name()
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+	return root
+}
+
+// schemaFor returns the subschema for a type, registering named structs in $defs
+func (g *generator) schemaFor(t reflect.Type) Schema {
+	t = indirect(t)
+
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t.Implements(typeNamerType):
+		return Schema{"type": reflect.Zero(t).Interface().(typeNamer).JSONSchemaTypes()}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Custom marshaling can produce any JSON value
+		return Schema{}
+	case t.Implements(textMarshalerType):
+		return Schema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte marshals as a base64 string
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			// Reserve the name first so recursive references terminate
+			g.defs[t.Name()] = Schema{}
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return Schema{"$ref": "#/$defs/" + t.Name()}
+	default:
+		// interface{} and other dynamic values accept anything
+		return Schema{}
+	}
+}
+
+// structSchema describes a struct's JSON object, flattening embedded structs
+// the way encoding/json does
+func (g *generator) structSchema(t reflect.Type) Schema {
+	properties := make(map[string]Schema)
+	required := []string{}
+	g.addFields(t, properties, &required)
+
+	s := Schema{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) addFields(t reflect.Type, properties map[string]Schema, required *[]string) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Untagged embedded structs are flattened into the parent object
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			g.addFields(indirect(field.Type), properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaFor(field.Type)
+		if !hasOption(opts, "omitempty") {
+			*required = append(*required, name)
+
+			// Nil pointers, slices, and maps marshal as null unless omitted
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map:
+				property = Schema{"anyOf": []Schema{property, {"type": "null"}}}
+			}
+		}
+		properties[name] = property
+	}
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// indirect strips pointer types
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testNode struct {
+	Name     string     `json:"name"`
+	Children []testNode `json:"children,omitempty"`
+}
+
+// testScalar marshals as a number or a string
+type testScalar string
+
+func (testScalar) MarshalJSON() ([]byte, error) { return []byte(`0`), nil }
+func (testScalar) JSONSchemaTypes() []string    { return []string{"number", "string"} }
+
+type testDoc struct {
+	testBase
+	Count    int                    `json:"count"`
+	Score    float64                `json:"score,omitempty"`
+	Enabled  bool                   `json:"enabled"`
+	Created  time.Time              `json:"created"`
+	Tags     []string               `json:"tags"`
+	Labels   map[string]string      `json:"labels,omitempty"`
+	Extra    interface{}            `json:"extra,omitempty"`
+	Parent   *testNode              `json:"parent,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Scalar   testScalar             `json:"scalar,omitempty"`
+	Secret   string                 `json:"-"`
+	Untagged string
+	internal string
+}
+
+func (suite *SchemaTestSuite) generate() map[string]interface{} {
+	// Round-trip through JSON to inspect the schema as consumers see it
+	data, err := json.Marshal(Generate(testDoc{}))
+	require.NoError(suite.T(), err)
+
+	var doc map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal(data, &doc))
+	return doc
+}
+
+func (suite *SchemaTestSuite) TestGenerate_Document() {
+	doc := suite.generate()
+
+	assert.Equal(suite.T(), Draft, doc["$schema"])
+	assert.Equal(suite.T(), "testDoc", doc["title"])
+	assert.Equal(suite.T(), "object", doc["type"])
+}
+
+func (suite *SchemaTestSuite) TestGenerate_Properties() {
+	props := suite.generate()["properties"].(map[string]interface{})
+
+	// Embedded fields are flattened; "-" and unexported fields are skipped
+	assert.Contains(suite.T(), props, "id")
+	assert.Contains(suite.T(), props, "Untagged")
+	assert.NotContains(suite.T(), props, "Secret")
+	assert.NotContains(suite.T(), props, "internal")
+	assert.NotContains(suite.T(), props, "testBase")
+
+	assert.Equal(suite.T(), map[string]interface{}{"type": "integer"}, props["count"])
+	assert.Equal(suite.T(), map[string]interface{}{"type": "number"}, props["score"])
+	assert.Equal(suite.T(), map[string]interface{}{"type": "boolean"}, props["enabled"])
+	assert.Equal(suite.T(), map[string]interface{}{"type": "string", "format": "date-time"}, props["created"])
+	assert.Equal(suite.T(), map[string]interface{}{}, props["extra"])
+	assert.Equal(suite.T(), map[string]interface{}{"type": []interface{}{"number", "string"}}, props["scalar"])
+	assert.Equal(suite.T(), map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}}, props["labels"])
+
+	// Nil slices marshal as null when not omitted
+	tags := props["tags"].(map[string]interface{})
+	assert.Len(suite.T(), tags["anyOf"], 2)
+}
+
+func (suite *SchemaTestSuite) TestGenerate_Required() {
+	doc := suite.generate()
+	assert.ElementsMatch(suite.T(), []interface{}{"id", "count", "enabled", "created", "tags", "Untagged"}, doc["required"])
+}
+
+func (suite *SchemaTestSuite) TestGenerate_RecursiveTypesUseDefs() {
+	doc := suite.generate()
+
+	props := doc["properties"].(map[string]interface{})
+	assert.Equal(suite.T(), map[string]interface{}{"$ref": "#/$defs/testNode"}, props["parent"])
+
+	defs := doc["$defs"].(map[string]interface{})
+	node := defs["testNode"].(map[string]interface{})
+	children := node["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	assert.Equal(suite.T(), map[string]interface{}{"$ref": "#/$defs/testNode"}, children["items"])
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
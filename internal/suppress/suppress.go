@@ -0,0 +1,128 @@
+// Package suppress manages the alert suppression list used to hide or annotate
+// accepted-risk findings in scan alert output.
+package suppress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"hawkop/internal/config"
+)
+
+// FileName is the name of the suppression file within the config directory
+const FileName = "suppressions.yaml"
+
+// Rule suppresses a plugin, optionally scoped to an application and environment
+type Rule struct {
+	PluginID  string `json:"pluginId" yaml:"plugin_id"`
+	App       string `json:"app,omitempty" yaml:"app,omitempty"`
+	Env       string `json:"env,omitempty" yaml:"env,omitempty"`
+	Reason    string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty" yaml:"created_at,omitempty"`
+}
+
+// List represents the contents of the suppression file
+type List struct {
+	Rules []Rule `json:"suppressions" yaml:"suppressions"`
+
+	path string
+}
+
+// GetFile returns the suppression file path
+func GetFile() string {
+	return filepath.Join(config.GetConfigDir(), FileName)
+}
+
+// Load reads the suppression file from the config directory
+func Load() (*List, error) {
+	return LoadFile(GetFile())
+}
+
+// LoadFile reads a suppression file, returning an empty list if it doesn't exist
+func LoadFile(path string) (*List, error) {
+	list := &List{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return list, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppression file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("failed to parse suppression file: %w", err)
+	}
+
+	for i, rule := range list.Rules {
+		if strings.TrimSpace(rule.PluginID) == "" {
+			return nil, fmt.Errorf("invalid suppression file: entry %d has no plugin_id", i+1)
+		}
+	}
+
+	return list, nil
+}
+
+// Save writes the suppression list back to the file it was loaded from
+func (l *List) Save() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suppressions: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write suppression file: %w", err)
+	}
+
+	return nil
+}
+
+// Add appends a rule, returning an error if an identical rule already exists
+func (l *List) Add(rule Rule) error {
+	rule.PluginID = strings.TrimSpace(rule.PluginID)
+	if rule.PluginID == "" {
+		return fmt.Errorf("plugin ID is required")
+	}
+
+	for _, existing := range l.Rules {
+		if existing.PluginID == rule.PluginID &&
+			strings.EqualFold(existing.App, rule.App) &&
+			strings.EqualFold(existing.Env, rule.Env) {
+			return fmt.Errorf("plugin %s is already suppressed for this scope", rule.PluginID)
+		}
+	}
+
+	if rule.CreatedAt == "" {
+		rule.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	l.Rules = append(l.Rules, rule)
+	return nil
+}
+
+// Match returns the first rule suppressing pluginID for the given application and
+// environment. A rule's app matches either the application ID or name.
+func (l *List) Match(pluginID, appID, appName, env string) (Rule, bool) {
+	for _, rule := range l.Rules {
+		if rule.PluginID != pluginID {
+			continue
+		}
+		if rule.App != "" && !strings.EqualFold(rule.App, appID) && !strings.EqualFold(rule.App, appName) {
+			continue
+		}
+		if rule.Env != "" && !strings.EqualFold(rule.Env, env) {
+			continue
+		}
+		return rule, true
+	}
+	return Rule{}, false
+}
@@ -0,0 +1,98 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuppressTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func (suite *SuppressTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), FileName)
+}
+
+func (suite *SuppressTestSuite) TestLoadFile_Missing() {
+	list, err := LoadFile(suite.path)
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), list.Rules)
+}
+
+func (suite *SuppressTestSuite) TestLoadFile_Parse() {
+	data := `suppressions:
+  - plugin_id: "10020"
+    reason: accepted risk
+  - plugin_id: "40012"
+    app: Billing API
+    env: Production
+`
+	require.NoError(suite.T(), os.WriteFile(suite.path, []byte(data), 0600))
+
+	list, err := LoadFile(suite.path)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), list.Rules, 2)
+	assert.Equal(suite.T(), "accepted risk", list.Rules[0].Reason)
+	assert.Equal(suite.T(), "Production", list.Rules[1].Env)
+}
+
+func (suite *SuppressTestSuite) TestLoadFile_MissingPluginID() {
+	require.NoError(suite.T(), os.WriteFile(suite.path, []byte("suppressions:\n  - app: foo\n"), 0600))
+
+	_, err := LoadFile(suite.path)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "no plugin_id")
+}
+
+func (suite *SuppressTestSuite) TestAddAndSave() {
+	list, err := LoadFile(suite.path)
+	require.NoError(suite.T(), err)
+
+	require.NoError(suite.T(), list.Add(Rule{PluginID: "10020"}))
+	require.NoError(suite.T(), list.Add(Rule{PluginID: "10020", Env: "Staging"}))
+	assert.Error(suite.T(), list.Add(Rule{PluginID: "10020"}))
+	assert.Error(suite.T(), list.Add(Rule{PluginID: " "}))
+	require.NoError(suite.T(), list.Save())
+
+	reloaded, err := LoadFile(suite.path)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), reloaded.Rules, 2)
+	assert.NotEmpty(suite.T(), reloaded.Rules[0].CreatedAt)
+}
+
+func (suite *SuppressTestSuite) TestMatch_Scoping() {
+	list := &List{Rules: []Rule{
+		{PluginID: "10020"},
+		{PluginID: "40012", App: "app-1", Env: "Production"},
+		{PluginID: "40018", App: "Billing API"},
+	}}
+
+	// Unscoped rules match everywhere
+	_, ok := list.Match("10020", "any-app", "Any", "dev")
+	assert.True(suite.T(), ok)
+
+	// App and env scoping
+	_, ok = list.Match("40012", "app-1", "Billing API", "production")
+	assert.True(suite.T(), ok)
+	_, ok = list.Match("40012", "app-1", "Billing API", "Staging")
+	assert.False(suite.T(), ok)
+	_, ok = list.Match("40012", "app-2", "Other", "Production")
+	assert.False(suite.T(), ok)
+
+	// App scope matches by name as well as ID
+	_, ok = list.Match("40018", "app-9", "billing api", "")
+	assert.True(suite.T(), ok)
+
+	_, ok = list.Match("99999", "app-1", "Billing API", "Production")
+	assert.False(suite.T(), ok)
+}
+
+func TestSuppressTestSuite(t *testing.T) {
+	suite.Run(t, new(SuppressTestSuite))
+}
@@ -0,0 +1,89 @@
+// Package testutil provides shared helpers for tests, including golden-file
+// comparison and a mock StackHawk API for running commands end to end.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"hawkop/internal/api"
+	"hawkop/internal/config"
+)
+
+// MockOrgID is the organization served by the mock API
+const MockOrgID = "test-org-id"
+
+var update = flag.Bool("update", false, "update golden files")
+
+// AssertGolden compares got with the golden file at path, rewriting the file
+// when tests run with -update
+func AssertGolden(t testing.TB, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, got, 0644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file %s; rerun the tests with -update", path)
+	assert.Equal(t, string(want), string(got))
+}
+
+// CaptureJSON returns what fn writes to w, failing the test unless it is valid JSON
+func CaptureJSON(t testing.TB, fn func(w io.Writer)) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	fn(&buf)
+	require.True(t, json.Valid(buf.Bytes()), "output is not valid JSON:\n%s", buf.String())
+	return buf.Bytes()
+}
+
+// AssertJSONGolden captures the JSON fn writes to w and compares it with the
+// golden file at path
+func AssertJSONGolden(t testing.TB, path string, fn func(w io.Writer)) {
+	t.Helper()
+	AssertGolden(t, path, CaptureJSON(t, fn))
+}
+
+// MockAPI is a mock StackHawk API server with a configuration that can reach it
+type MockAPI struct {
+	Server *api.MockAPIServer
+	Config *config.Config
+}
+
+// NewMockAPI starts a mock API server that is closed when the test ends. Its
+// Config has credentials, a valid JWT, and MockOrgID as the default organization.
+func NewMockAPI(t testing.TB) *MockAPI {
+	t.Helper()
+
+	server := api.NewMockAPIServer()
+	t.Cleanup(server.Close)
+
+	return &MockAPI{Server: server, Config: NewConfig(server.URL())}
+}
+
+// NewConfig returns a config with credentials, a valid JWT for the API at
+// baseURL, and MockOrgID as the default organization
+func NewConfig(baseURL string) *config.Config {
+	cfg := &config.Config{APIKey: "test-api-key", OrgID: MockOrgID}
+	cfg.SetJWT(baseURL, "test-jwt-token", time.Now().Add(1*time.Hour))
+	return cfg
+}
+
+// NewClient creates an API client for cfg that talks to the mock server
+func (m *MockAPI) NewClient(cfg *config.Config) *api.Client {
+	client := api.NewClient(cfg)
+	client.SetBaseURL(m.Server.URL())
+	return client
+}